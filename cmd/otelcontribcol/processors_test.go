@@ -78,6 +78,10 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "groupbytrace",
 		},
+		{
+			processor:     "jaegerthroughput",
+			skipLifecycle: true, // Requires the jaegerremotesampling extension to record the throughput
+		},
 		{
 			processor:     "k8sattributes",
 			skipLifecycle: true, // Requires a k8s API to communicate with
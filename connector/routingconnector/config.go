@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+var (
+	errEmptyRoute   = errors.New("invalid route: no condition provided")
+	errNoPipelines  = errors.New("invalid route: no pipelines defined")
+	errNoTableItems = errors.New("invalid routing table: the routing table is empty")
+)
+
+// Config defines configuration for the Routing connector.
+type Config struct {
+	// DefaultPipelines contains the list of pipelines to use when the data
+	// doesn't match any route of the routing table. Data that doesn't match
+	// any route is dropped when no default pipelines are set.
+	// Optional.
+	DefaultPipelines []component.ID `mapstructure:"default_pipelines"`
+
+	// ErrorMode determines how the connector reacts to errors that occur while
+	// evaluating an OTTL condition.
+	// Valid values are `ignore` and `propagate`.
+	// `ignore` means the connector ignores errors returned by conditions and
+	// continues on to the next route.
+	// `propagate` means the connector returns the error up the pipeline.
+	// The default value is `propagate`.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+
+	// Table contains the routing table for this connector.
+	// Required.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+// Validate checks if the connector configuration is valid.
+func (c *Config) Validate() error {
+	if len(c.Table) == 0 {
+		return errNoTableItems
+	}
+
+	for _, item := range c.Table {
+		if item.Condition == "" {
+			return errEmptyRoute
+		}
+		if len(item.Pipelines) == 0 {
+			return errNoPipelines
+		}
+	}
+
+	return nil
+}
+
+// RoutingTableItem specifies how data should be routed to the different pipelines.
+type RoutingTableItem struct {
+	// Condition is an OTTL condition evaluated once for each resource of the
+	// data, in the span, data point or log context depending on the pipeline
+	// type. The resource is routed to the pipelines of the route when the
+	// condition matches. Only the resource can be referred to by the condition.
+	// Required.
+	Condition string `mapstructure:"condition"`
+
+	// Pipelines contains the list of pipelines to use when the condition matches.
+	// Required.
+	Pipelines []component.ID `mapstructure:"pipelines"`
+}
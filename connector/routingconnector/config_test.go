@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		expect *Config
+	}{
+		{
+			name: "",
+			expect: &Config{
+				DefaultPipelines: []component.ID{
+					component.NewIDWithName(component.DataTypeTraces, "otlp-all"),
+				},
+				ErrorMode: ottl.PropagateError,
+				Table: []RoutingTableItem{
+					{
+						Condition: `resource.attributes["X-Tenant"] == "acme"`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "jaeger-acme"),
+							component.NewIDWithName(component.DataTypeTraces, "otlp-acme"),
+						},
+					},
+					{
+						Condition: `IsMatch(resource.attributes["X-Tenant"], ".*corp")`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeTraces, "ecorp"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ignore_errors",
+			expect: &Config{
+				ErrorMode: ottl.IgnoreError,
+				Table: []RoutingTableItem{
+					{
+						Condition: `resource.attributes["X-Tenant"] == "acme"`,
+						Pipelines: []component.ID{
+							component.NewIDWithName(component.DataTypeLogs, "acme"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(component.NewIDWithName(typeStr, tc.name).String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tc.expect, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  *Config
+		expect string
+	}{
+		{
+			name:   "empty routing table",
+			input:  &Config{},
+			expect: "invalid routing table: the routing table is empty",
+		},
+		{
+			name: "no condition",
+			input: &Config{
+				Table: []RoutingTableItem{
+					{
+						Pipelines: []component.ID{component.NewID(component.DataTypeTraces)},
+					},
+				},
+			},
+			expect: "invalid route: no condition provided",
+		},
+		{
+			name: "no pipelines",
+			input: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `resource.attributes["X-Tenant"] == "acme"`,
+					},
+				},
+			},
+			expect: "invalid route: no pipelines defined",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expect)
+		})
+	}
+}
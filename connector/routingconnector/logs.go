@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+// logsSignal routes plog.Logs to the consumers of connector.LogsRouter, evaluating
+// the conditions in the ottllog context.
+var logsSignal = signal[consumer.Logs, ottllog.TransformContext, plog.Logs]{
+	newData: plog.NewLogs,
+	resourceCount: func(ld plog.Logs) int {
+		return ld.ResourceLogs().Len()
+	},
+	resource: func(ld plog.Logs, i int) pcommon.Resource {
+		return ld.ResourceLogs().At(i).Resource()
+	},
+	copyResource: func(src plog.Logs, i int, dst plog.Logs) {
+		src.ResourceLogs().At(i).CopyTo(dst.ResourceLogs().AppendEmpty())
+	},
+	newTransformContext: func(resource pcommon.Resource) ottllog.TransformContext {
+		return ottllog.NewTransformContext(plog.NewLogRecord(), pcommon.NewInstrumentationScope(), resource)
+	},
+	newBoolExpr: func(conditions []string, errorMode ottl.ErrorMode, set component.TelemetrySettings) (expr.BoolExpr[ottllog.TransformContext], error) {
+		return filterottl.NewBoolExprForLog(conditions, filterottl.StandardLogFuncs(), errorMode, set)
+	},
+	consume: func(ctx context.Context, c consumer.Logs, ld plog.Logs) error {
+		return c.ConsumeLogs(ctx, ld)
+	},
+}
+
+type logsConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	logger *zap.Logger
+	router *router[consumer.Logs, ottllog.TransformContext, plog.Logs]
+}
+
+func newLogsConnector(
+	set connector.CreateSettings,
+	config component.Config,
+	logs consumer.Logs,
+) (*logsConnector, error) {
+	router, err := newRouter(config.(*Config), logs, logsSignal, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logsConnector{
+		logger: set.Logger,
+		router: router,
+	}, nil
+}
+
+func (c *logsConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.router.route(ctx, ld)
+}
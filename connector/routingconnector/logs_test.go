@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+)
+
+func TestLogsRouting(t *testing.T) {
+	defaultID := component.NewIDWithName(component.DataTypeLogs, "default")
+	acmeID := component.NewIDWithName(component.DataTypeLogs, "acme")
+	corpID := component.NewIDWithName(component.DataTypeLogs, "corp")
+	auditID := component.NewIDWithName(component.DataTypeLogs, "audit")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{defaultID},
+		Table: []RoutingTableItem{
+			{
+				Condition: `resource.attributes["X-Tenant"] == "acme"`,
+				Pipelines: []component.ID{acmeID},
+			},
+			{
+				Condition: `IsMatch(resource.attributes["X-Tenant"], ".*corp|acme")`,
+				Pipelines: []component.ID{corpID},
+			},
+			{
+				// The conditions are only evaluated against the resource, so
+				// this route never matches.
+				Condition: `body == "audit"`,
+				Pipelines: []component.ID{auditID},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		input    plog.Logs
+		expected map[component.ID]plog.Logs
+	}{
+		{
+			name:  "matched by no condition",
+			input: newLogs("other"),
+			expected: map[component.ID]plog.Logs{
+				defaultID: newLogs("other"),
+			},
+		},
+		{
+			name:  "matched by one condition",
+			input: newLogs("ecorp"),
+			expected: map[component.ID]plog.Logs{
+				corpID: newLogs("ecorp"),
+			},
+		},
+		{
+			name:  "matched by all conditions on the resource",
+			input: newLogs("acme"),
+			expected: map[component.ID]plog.Logs{
+				acmeID: newLogs("acme"),
+				corpID: newLogs("acme"),
+			},
+		},
+		{
+			name:  "resources split between the routes with their log records",
+			input: newLogs("acme", "other", "ecorp", "other"),
+			expected: map[component.ID]plog.Logs{
+				defaultID: newLogs("other", "other"),
+				acmeID:    newLogs("acme"),
+				corpID:    newLogs("acme", "ecorp"),
+			},
+		},
+		{
+			name:     "no resources",
+			input:    plog.NewLogs(),
+			expected: map[component.ID]plog.Logs{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sinks := map[component.ID]*consumertest.LogsSink{
+				defaultID: new(consumertest.LogsSink),
+				acmeID:    new(consumertest.LogsSink),
+				corpID:    new(consumertest.LogsSink),
+				auditID:   new(consumertest.LogsSink),
+			}
+			consumers := make(map[component.ID]consumer.Logs, len(sinks))
+			for id, sink := range sinks {
+				consumers[id] = sink
+			}
+
+			conn, err := NewFactory().CreateLogsToLogs(context.Background(), connectortest.NewNopCreateSettings(), cfg, newMockLogsRouter(consumers))
+			require.NoError(t, err)
+			require.NoError(t, conn.Start(context.Background(), nil))
+			defer func() {
+				assert.NoError(t, conn.Shutdown(context.Background()))
+			}()
+
+			require.NoError(t, conn.ConsumeLogs(context.Background(), tc.input))
+
+			for id, sink := range sinks {
+				expected, ok := tc.expected[id]
+				if !ok {
+					assert.Empty(t, sink.AllLogs(), id.String())
+					continue
+				}
+				require.Len(t, sink.AllLogs(), 1, id.String())
+				assert.Equal(t, expected, sink.AllLogs()[0], id.String())
+			}
+		})
+	}
+}
+
+// newLogs returns logs holding a resource for each tenant, each resource
+// holding two scopes whose log records have an "audit" and a "log" body.
+func newLogs(tenants ...string) plog.Logs {
+	ld := plog.NewLogs()
+	for _, tenant := range tenants {
+		rlogs := ld.ResourceLogs().AppendEmpty()
+		rlogs.Resource().Attributes().PutStr("X-Tenant", tenant)
+		audit := rlogs.ScopeLogs().AppendEmpty()
+		audit.Scope().SetName("audit")
+		audit.LogRecords().AppendEmpty().Body().SetStr("audit")
+		logs := rlogs.ScopeLogs().AppendEmpty()
+		logs.Scope().SetName("app")
+		record := logs.LogRecords().AppendEmpty()
+		record.Body().SetStr("log")
+		record.SetSeverityNumber(plog.SeverityNumberInfo)
+	}
+	return ld
+}
+
+var _ connector.LogsRouter = (*mockLogsRouter)(nil)
+
+type mockLogsRouter struct {
+	consumer.Logs
+	*mockRouter[consumer.Logs]
+}
+
+func newMockLogsRouter(consumers map[component.ID]consumer.Logs) *mockLogsRouter {
+	return &mockLogsRouter{
+		Logs: consumertest.NewNop(),
+		mockRouter: &mockRouter[consumer.Logs]{
+			consumers: consumers,
+			fanout: func(consumers []consumer.Logs) consumer.Logs {
+				c, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+					var errs error
+					for _, c := range consumers {
+						errs = multierr.Append(errs, c.ConsumeLogs(ctx, ld))
+					}
+					return errs
+				})
+				return c
+			},
+		},
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+)
+
+// metricsSignal routes pmetric.Metrics to the consumers of connector.MetricsRouter, evaluating
+// the conditions in the ottldatapoint context.
+var metricsSignal = signal[consumer.Metrics, ottldatapoint.TransformContext, pmetric.Metrics]{
+	newData: pmetric.NewMetrics,
+	resourceCount: func(md pmetric.Metrics) int {
+		return md.ResourceMetrics().Len()
+	},
+	resource: func(md pmetric.Metrics, i int) pcommon.Resource {
+		return md.ResourceMetrics().At(i).Resource()
+	},
+	copyResource: func(src pmetric.Metrics, i int, dst pmetric.Metrics) {
+		src.ResourceMetrics().At(i).CopyTo(dst.ResourceMetrics().AppendEmpty())
+	},
+	newTransformContext: func(resource pcommon.Resource) ottldatapoint.TransformContext {
+		return ottldatapoint.NewTransformContext(nil, pmetric.NewMetric(), pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), resource)
+	},
+	newBoolExpr: func(conditions []string, errorMode ottl.ErrorMode, set component.TelemetrySettings) (expr.BoolExpr[ottldatapoint.TransformContext], error) {
+		return filterottl.NewBoolExprForDataPoint(conditions, filterottl.StandardDataPointFuncs(), errorMode, set)
+	},
+	consume: func(ctx context.Context, c consumer.Metrics, md pmetric.Metrics) error {
+		return c.ConsumeMetrics(ctx, md)
+	},
+}
+
+type metricsConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	logger *zap.Logger
+	router *router[consumer.Metrics, ottldatapoint.TransformContext, pmetric.Metrics]
+}
+
+func newMetricsConnector(
+	set connector.CreateSettings,
+	config component.Config,
+	metrics consumer.Metrics,
+) (*metricsConnector, error) {
+	router, err := newRouter(config.(*Config), metrics, metricsSignal, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricsConnector{
+		logger: set.Logger,
+		router: router,
+	}, nil
+}
+
+func (c *metricsConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.router.route(ctx, md)
+}
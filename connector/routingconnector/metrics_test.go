@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+func TestMetricsRouting(t *testing.T) {
+	defaultID := component.NewIDWithName(component.DataTypeMetrics, "default")
+	acmeID := component.NewIDWithName(component.DataTypeMetrics, "acme")
+	corpID := component.NewIDWithName(component.DataTypeMetrics, "corp")
+	auditID := component.NewIDWithName(component.DataTypeMetrics, "audit")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{defaultID},
+		Table: []RoutingTableItem{
+			{
+				Condition: `resource.attributes["X-Tenant"] == "acme"`,
+				Pipelines: []component.ID{acmeID},
+			},
+			{
+				Condition: `IsMatch(resource.attributes["X-Tenant"], ".*corp|acme")`,
+				Pipelines: []component.ID{corpID},
+			},
+			{
+				// The conditions are only evaluated against the resource, so
+				// this route never matches.
+				Condition: `metric.name == "audit"`,
+				Pipelines: []component.ID{auditID},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		input    pmetric.Metrics
+		expected map[component.ID]pmetric.Metrics
+	}{
+		{
+			name:  "matched by no condition",
+			input: newMetrics("other"),
+			expected: map[component.ID]pmetric.Metrics{
+				defaultID: newMetrics("other"),
+			},
+		},
+		{
+			name:  "matched by one condition",
+			input: newMetrics("ecorp"),
+			expected: map[component.ID]pmetric.Metrics{
+				corpID: newMetrics("ecorp"),
+			},
+		},
+		{
+			name:  "matched by all conditions on the resource",
+			input: newMetrics("acme"),
+			expected: map[component.ID]pmetric.Metrics{
+				acmeID: newMetrics("acme"),
+				corpID: newMetrics("acme"),
+			},
+		},
+		{
+			name:  "resources split between the routes with their metrics and data points",
+			input: newMetrics("acme", "other", "ecorp", "other"),
+			expected: map[component.ID]pmetric.Metrics{
+				defaultID: newMetrics("other", "other"),
+				acmeID:    newMetrics("acme"),
+				corpID:    newMetrics("acme", "ecorp"),
+			},
+		},
+		{
+			name:     "no resources",
+			input:    pmetric.NewMetrics(),
+			expected: map[component.ID]pmetric.Metrics{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sinks := map[component.ID]*consumertest.MetricsSink{
+				defaultID: new(consumertest.MetricsSink),
+				acmeID:    new(consumertest.MetricsSink),
+				corpID:    new(consumertest.MetricsSink),
+				auditID:   new(consumertest.MetricsSink),
+			}
+			consumers := make(map[component.ID]consumer.Metrics, len(sinks))
+			for id, sink := range sinks {
+				consumers[id] = sink
+			}
+
+			conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), cfg, newMockMetricsRouter(consumers))
+			require.NoError(t, err)
+			require.NoError(t, conn.Start(context.Background(), nil))
+			defer func() {
+				assert.NoError(t, conn.Shutdown(context.Background()))
+			}()
+
+			require.NoError(t, conn.ConsumeMetrics(context.Background(), tc.input))
+
+			for id, sink := range sinks {
+				expected, ok := tc.expected[id]
+				if !ok {
+					assert.Empty(t, sink.AllMetrics(), id.String())
+					continue
+				}
+				require.Len(t, sink.AllMetrics(), 1, id.String())
+				assert.Equal(t, expected, sink.AllMetrics()[0], id.String())
+			}
+		})
+	}
+}
+
+// newMetrics returns metrics holding a resource for each tenant, each resource
+// holding an "audit" gauge, a sum and a histogram.
+func newMetrics(tenants ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, tenant := range tenants {
+		rmetrics := md.ResourceMetrics().AppendEmpty()
+		rmetrics.Resource().Attributes().PutStr("X-Tenant", tenant)
+		metrics := rmetrics.ScopeMetrics().AppendEmpty().Metrics()
+
+		gauge := metrics.AppendEmpty()
+		gauge.SetName("audit")
+		gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+
+		sum := metrics.AppendEmpty()
+		sum.SetName("requests")
+		sum.SetEmptySum().SetIsMonotonic(true)
+		sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.Sum().DataPoints().AppendEmpty().SetIntValue(10)
+
+		histogram := metrics.AppendEmpty()
+		histogram.SetName("latency")
+		histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := histogram.Histogram().DataPoints().AppendEmpty()
+		dp.SetCount(3)
+		dp.ExplicitBounds().FromRaw([]float64{1, 10})
+		dp.BucketCounts().FromRaw([]uint64{1, 1, 1})
+	}
+	return md
+}
+
+var _ connector.MetricsRouter = (*mockMetricsRouter)(nil)
+
+type mockMetricsRouter struct {
+	consumer.Metrics
+	*mockRouter[consumer.Metrics]
+}
+
+func newMockMetricsRouter(consumers map[component.ID]consumer.Metrics) *mockMetricsRouter {
+	return &mockMetricsRouter{
+		Metrics: consumertest.NewNop(),
+		mockRouter: &mockRouter[consumer.Metrics]{
+			consumers: consumers,
+			fanout: func(consumers []consumer.Metrics) consumer.Metrics {
+				c, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+					var errs error
+					for _, c := range consumers {
+						errs = multierr.Append(errs, c.ConsumeMetrics(ctx, md))
+					}
+					return errs
+				})
+				return c
+			},
+		},
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+var errUnexpectedConsumer = errors.New("expected consumer to be a connector router")
+
+// defaultRoute is the index of the route holding the default pipelines.
+const defaultRoute = -1
+
+// consumerProvider is implemented by the connector.TracesRouter,
+// connector.MetricsRouter and connector.LogsRouter passed to the connector. It
+// returns a consumer feeding all the given pipelines.
+type consumerProvider[C any] interface {
+	Consumer(...component.ID) (C, error)
+}
+
+// signal adapts the router to the pdata type P of a signal, the consumer type
+// C of its pipelines and the OTTL context K its conditions are evaluated in.
+type signal[C any, K any, P any] struct {
+	// newData returns an empty P.
+	newData func() P
+	// resourceCount returns the number of resources held by data.
+	resourceCount func(data P) int
+	// resource returns the resource at index i of data.
+	resource func(data P, i int) pcommon.Resource
+	// copyResource appends a copy of the resource at index i of src, along
+	// with its telemetry, to dst.
+	copyResource func(src P, i int, dst P)
+	// newTransformContext returns the context the conditions are evaluated in
+	// for the given resource.
+	newTransformContext func(resource pcommon.Resource) K
+	// newBoolExpr parses the routing conditions, see the NewBoolExprFor*
+	// functions of the filterottl package.
+	newBoolExpr func(conditions []string, errorMode ottl.ErrorMode, set component.TelemetrySettings) (expr.BoolExpr[K], error)
+	// consume passes data to the consumer.
+	consume func(ctx context.Context, consumer C, data P) error
+}
+
+// router matches resources against the conditions of the routing table and
+// passes them to the consumers of the matching routes. It can be instantiated
+// for traces, metrics and logs through their signal.
+type router[C any, K any, P any] struct {
+	signal signal[C, K, P]
+
+	// routes holds the routes in the order of the routing table.
+	routes []routingItem[C, K]
+
+	defaultConsumer    C
+	hasDefaultConsumer bool
+}
+
+type routingItem[C any, K any] struct {
+	condition expr.BoolExpr[K]
+	consumer  C
+}
+
+// newRouter builds the router of the routing table of cfg, getting the
+// consumers of the routes from next, which must be the connector router of the
+// signal.
+func newRouter[C any, K any, P any](
+	cfg *Config,
+	next any,
+	sig signal[C, K, P],
+	settings component.TelemetrySettings,
+) (*router[C, K, P], error) {
+	provider, ok := next.(consumerProvider[C])
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+
+	r := &router[C, K, P]{signal: sig}
+
+	if len(cfg.DefaultPipelines) > 0 {
+		defaultConsumer, err := provider.Consumer(cfg.DefaultPipelines...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the consumer of the default pipelines: %w", err)
+		}
+		r.defaultConsumer = defaultConsumer
+		r.hasDefaultConsumer = true
+	}
+
+	for _, item := range cfg.Table {
+		condition, err := sig.newBoolExpr([]string{item.Condition}, cfg.ErrorMode, settings)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route condition %q: %w", item.Condition, err)
+		}
+
+		routeConsumer, err := provider.Consumer(item.Pipelines...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the consumer of the route with condition %q: %w", item.Condition, err)
+		}
+
+		r.routes = append(r.routes, routingItem[C, K]{
+			condition: condition,
+			consumer:  routeConsumer,
+		})
+	}
+
+	return r, nil
+}
+
+// route passes each resource of data to the consumers of the routes it
+// matches. The resources routed to the same route are grouped so that the data
+// isn't split up more than needed, which would cause higher CPU usage.
+func (r *router[C, K, P]) route(ctx context.Context, data P) error {
+	groups := make(map[int]P)
+
+	for i := 0; i < r.signal.resourceCount(data); i++ {
+		tCtx := r.signal.newTransformContext(r.signal.resource(data, i))
+		routes, err := r.match(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+		for _, route := range routes {
+			group, ok := groups[route]
+			if !ok {
+				group = r.signal.newData()
+				groups[route] = group
+			}
+			r.signal.copyResource(data, i, group)
+		}
+	}
+
+	var errs error
+	for route, group := range groups {
+		errs = multierr.Append(errs, r.signal.consume(ctx, r.consumer(route), group))
+	}
+	return errs
+}
+
+// match returns the indexes of the routes whose condition matches the given
+// context, which only holds a resource. The default route is returned when no
+// route matches and default pipelines are configured, no route being returned
+// otherwise. Under the ignore error mode, a condition that fails to evaluate
+// doesn't match.
+func (r *router[C, K, P]) match(ctx context.Context, tCtx K) ([]int, error) {
+	var matched []int
+	for i, route := range r.routes {
+		isMatch, err := route.condition.Eval(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if isMatch {
+			matched = append(matched, i)
+		}
+	}
+
+	if len(matched) == 0 && r.hasDefaultConsumer {
+		matched = append(matched, defaultRoute)
+	}
+	return matched, nil
+}
+
+// consumer returns the consumer of the route with the given index.
+func (r *router[C, K, P]) consumer(route int) C {
+	if route == defaultRoute {
+		return r.defaultConsumer
+	}
+	return r.routes[route].consumer
+}
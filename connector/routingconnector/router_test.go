@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestNewRouterErrors(t *testing.T) {
+	acmeID := component.NewIDWithName(component.DataTypeLogs, "acme")
+	otherID := component.NewIDWithName(component.DataTypeLogs, "other")
+	next := newMockLogsRouter(map[component.ID]consumer.Logs{
+		otherID: consumertest.NewNop(),
+	})
+
+	testCases := []struct {
+		name        string
+		cfg         *Config
+		next        any
+		expectedErr string
+	}{
+		{
+			name:        "not a connector router",
+			cfg:         &Config{},
+			next:        consumertest.NewNop(),
+			expectedErr: errUnexpectedConsumer.Error(),
+		},
+		{
+			name: "missing default pipeline",
+			cfg: &Config{
+				DefaultPipelines: []component.ID{acmeID},
+			},
+			next:        next,
+			expectedErr: `failed to get the consumer of the default pipelines: missing consumer: "logs/acme"`,
+		},
+		{
+			name: "missing route pipeline",
+			cfg: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `resource.attributes["X-Tenant"] == "acme"`,
+						Pipelines: []component.ID{acmeID},
+					},
+				},
+			},
+			next:        next,
+			expectedErr: `failed to get the consumer of the route with condition "resource.attributes[\"X-Tenant\"] == \"acme\"": missing consumer: "logs/acme"`,
+		},
+		{
+			name: "invalid condition",
+			cfg: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `resource.attributes["X-Tenant"] ==`,
+						Pipelines: []component.ID{otherID},
+					},
+				},
+			},
+			next:        next,
+			expectedErr: `invalid route condition "resource.attributes[\"X-Tenant\"] =="`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newRouter(tc.cfg, tc.next, logsSignal, componenttest.NewNopTelemetrySettings())
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRouterMatch(t *testing.T) {
+	defaultID := component.NewIDWithName(component.DataTypeLogs, "default")
+	acmeID := component.NewIDWithName(component.DataTypeLogs, "acme")
+	corpID := component.NewIDWithName(component.DataTypeLogs, "corp")
+	next := newMockLogsRouter(map[component.ID]consumer.Logs{
+		defaultID: consumertest.NewNop(),
+		acmeID:    consumertest.NewNop(),
+		corpID:    consumertest.NewNop(),
+	})
+	table := []RoutingTableItem{
+		{
+			Condition: `resource.attributes["X-Tenant"] == "acme"`,
+			Pipelines: []component.ID{acmeID},
+		},
+		{
+			Condition: `IsMatch(resource.attributes["X-Tenant"], ".*corp|acme")`,
+			Pipelines: []component.ID{corpID},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      *Config
+		tenant   string
+		expected []int
+	}{
+		{
+			name:     "no match",
+			cfg:      &Config{DefaultPipelines: []component.ID{defaultID}, Table: table},
+			tenant:   "other",
+			expected: []int{defaultRoute},
+		},
+		{
+			name:   "no match without default pipelines",
+			cfg:    &Config{Table: table},
+			tenant: "other",
+		},
+		{
+			name:     "one match",
+			cfg:      &Config{DefaultPipelines: []component.ID{defaultID}, Table: table},
+			tenant:   "ecorp",
+			expected: []int{1},
+		},
+		{
+			name:     "all matches in table order",
+			cfg:      &Config{DefaultPipelines: []component.ID{defaultID}, Table: table},
+			tenant:   "acme",
+			expected: []int{0, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := newRouter(tc.cfg, next, logsSignal, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			resource := pcommon.NewResource()
+			resource.Attributes().PutStr("X-Tenant", tc.tenant)
+			routes, err := r.match(context.Background(), logsSignal.newTransformContext(resource))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, routes)
+		})
+	}
+}
+
+// mockRouter feeds the consumers of the requested pipelines, as the routers
+// built by the collector service do.
+type mockRouter[C any] struct {
+	consumers map[component.ID]C
+	fanout    func([]C) C
+}
+
+func (r *mockRouter[C]) PipelineIDs() []component.ID {
+	ids := make([]component.ID, 0, len(r.consumers))
+	for id := range r.consumers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (r *mockRouter[C]) Consumer(pipelineIDs ...component.ID) (C, error) {
+	consumers := make([]C, 0, len(pipelineIDs))
+	for _, id := range pipelineIDs {
+		c, ok := r.consumers[id]
+		if !ok {
+			var zero C
+			return zero, fmt.Errorf("missing consumer: %q", id)
+		}
+		consumers = append(consumers, c)
+	}
+	return r.fanout(consumers), nil
+}
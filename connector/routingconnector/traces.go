@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+)
+
+// tracesSignal routes ptrace.Traces to the consumers of connector.TracesRouter, evaluating
+// the conditions in the ottlspan context.
+var tracesSignal = signal[consumer.Traces, ottlspan.TransformContext, ptrace.Traces]{
+	newData: ptrace.NewTraces,
+	resourceCount: func(td ptrace.Traces) int {
+		return td.ResourceSpans().Len()
+	},
+	resource: func(td ptrace.Traces, i int) pcommon.Resource {
+		return td.ResourceSpans().At(i).Resource()
+	},
+	copyResource: func(src ptrace.Traces, i int, dst ptrace.Traces) {
+		src.ResourceSpans().At(i).CopyTo(dst.ResourceSpans().AppendEmpty())
+	},
+	newTransformContext: func(resource pcommon.Resource) ottlspan.TransformContext {
+		return ottlspan.NewTransformContext(ptrace.NewSpan(), pcommon.NewInstrumentationScope(), resource)
+	},
+	newBoolExpr: func(conditions []string, errorMode ottl.ErrorMode, set component.TelemetrySettings) (expr.BoolExpr[ottlspan.TransformContext], error) {
+		return filterottl.NewBoolExprForSpan(conditions, filterottl.StandardSpanFuncs(), errorMode, set)
+	},
+	consume: func(ctx context.Context, c consumer.Traces, td ptrace.Traces) error {
+		return c.ConsumeTraces(ctx, td)
+	},
+}
+
+type tracesConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	logger *zap.Logger
+	router *router[consumer.Traces, ottlspan.TransformContext, ptrace.Traces]
+}
+
+func newTracesConnector(
+	set connector.CreateSettings,
+	config component.Config,
+	traces consumer.Traces,
+) (*tracesConnector, error) {
+	router, err := newRouter(config.(*Config), traces, tracesSignal, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracesConnector{
+		logger: set.Logger,
+		router: router,
+	}, nil
+}
+
+func (c *tracesConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.router.route(ctx, td)
+}
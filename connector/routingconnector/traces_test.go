@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+)
+
+func TestTracesRouting(t *testing.T) {
+	defaultID := component.NewIDWithName(component.DataTypeTraces, "default")
+	acmeID := component.NewIDWithName(component.DataTypeTraces, "acme")
+	corpID := component.NewIDWithName(component.DataTypeTraces, "corp")
+	auditID := component.NewIDWithName(component.DataTypeTraces, "audit")
+
+	cfg := &Config{
+		DefaultPipelines: []component.ID{defaultID},
+		Table: []RoutingTableItem{
+			{
+				Condition: `resource.attributes["X-Tenant"] == "acme"`,
+				Pipelines: []component.ID{acmeID},
+			},
+			{
+				Condition: `IsMatch(resource.attributes["X-Tenant"], ".*corp|acme")`,
+				Pipelines: []component.ID{corpID},
+			},
+			{
+				// The conditions are only evaluated against the resource, so
+				// this route never matches.
+				Condition: `name == "audit"`,
+				Pipelines: []component.ID{auditID},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		input    ptrace.Traces
+		expected map[component.ID]ptrace.Traces
+	}{
+		{
+			name:  "matched by no condition",
+			input: newTraces("other"),
+			expected: map[component.ID]ptrace.Traces{
+				defaultID: newTraces("other"),
+			},
+		},
+		{
+			name:  "matched by one condition",
+			input: newTraces("ecorp"),
+			expected: map[component.ID]ptrace.Traces{
+				corpID: newTraces("ecorp"),
+			},
+		},
+		{
+			name:  "matched by all conditions on the resource",
+			input: newTraces("acme"),
+			expected: map[component.ID]ptrace.Traces{
+				acmeID: newTraces("acme"),
+				corpID: newTraces("acme"),
+			},
+		},
+		{
+			name:  "resources split between the routes with their spans",
+			input: newTraces("acme", "other", "ecorp", "other"),
+			expected: map[component.ID]ptrace.Traces{
+				defaultID: newTraces("other", "other"),
+				acmeID:    newTraces("acme"),
+				corpID:    newTraces("acme", "ecorp"),
+			},
+		},
+		{
+			name:     "no resources",
+			input:    ptrace.NewTraces(),
+			expected: map[component.ID]ptrace.Traces{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sinks := map[component.ID]*consumertest.TracesSink{
+				defaultID: new(consumertest.TracesSink),
+				acmeID:    new(consumertest.TracesSink),
+				corpID:    new(consumertest.TracesSink),
+				auditID:   new(consumertest.TracesSink),
+			}
+			consumers := make(map[component.ID]consumer.Traces, len(sinks))
+			for id, sink := range sinks {
+				consumers[id] = sink
+			}
+
+			conn, err := NewFactory().CreateTracesToTraces(context.Background(), connectortest.NewNopCreateSettings(), cfg, newMockTracesRouter(consumers))
+			require.NoError(t, err)
+			require.NoError(t, conn.Start(context.Background(), nil))
+			defer func() {
+				assert.NoError(t, conn.Shutdown(context.Background()))
+			}()
+
+			require.NoError(t, conn.ConsumeTraces(context.Background(), tc.input))
+
+			for id, sink := range sinks {
+				expected, ok := tc.expected[id]
+				if !ok {
+					assert.Empty(t, sink.AllTraces(), id.String())
+					continue
+				}
+				require.Len(t, sink.AllTraces(), 1, id.String())
+				assert.Equal(t, expected, sink.AllTraces()[0], id.String())
+			}
+		})
+	}
+}
+
+// newTraces returns traces holding a resource for each tenant, each resource
+// holding an "audit" span and its child span.
+func newTraces(tenants ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, tenant := range tenants {
+		rspans := td.ResourceSpans().AppendEmpty()
+		rspans.Resource().Attributes().PutStr("X-Tenant", tenant)
+		spans := rspans.ScopeSpans().AppendEmpty().Spans()
+
+		parent := spans.AppendEmpty()
+		parent.SetName("audit")
+		parent.SetTraceID(pcommon.TraceID([16]byte{1}))
+		parent.SetSpanID(pcommon.SpanID([8]byte{1}))
+
+		child := spans.AppendEmpty()
+		child.SetName("query")
+		child.SetTraceID(pcommon.TraceID([16]byte{1}))
+		child.SetSpanID(pcommon.SpanID([8]byte{2}))
+		child.SetParentSpanID(pcommon.SpanID([8]byte{1}))
+	}
+	return td
+}
+
+var _ connector.TracesRouter = (*mockTracesRouter)(nil)
+
+type mockTracesRouter struct {
+	consumer.Traces
+	*mockRouter[consumer.Traces]
+}
+
+func newMockTracesRouter(consumers map[component.ID]consumer.Traces) *mockTracesRouter {
+	return &mockTracesRouter{
+		Traces: consumertest.NewNop(),
+		mockRouter: &mockRouter[consumer.Traces]{
+			consumers: consumers,
+			fanout: func(consumers []consumer.Traces) consumer.Traces {
+				c, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+					var errs error
+					for _, c := range consumers {
+						errs = multierr.Append(errs, c.ConsumeTraces(ctx, td))
+					}
+					return errs
+				})
+				return c
+			},
+		},
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 type routingKey int
@@ -25,6 +27,8 @@ type routingKey int
 const (
 	traceIDRouting routingKey = iota
 	svcRouting
+	resourceRouting
+	metricRouting
 )
 
 // Config defines configuration for the exporter.
@@ -43,6 +47,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -57,3 +62,13 @@ type DNSResolver struct {
 	Interval time.Duration `mapstructure:"interval"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
+
+// K8sSvcResolver defines the configuration for the resolver watching the endpoints of a Kubernetes service
+type K8sSvcResolver struct {
+	// APIConfig configures how to connect to the Kubernetes API, defaults to the service account of the pod.
+	k8sconfig.APIConfig `mapstructure:",squash"`
+	// Service is the name of the service, in the format <name>.<namespace>. The namespace defaults to "default".
+	Service string `mapstructure:"service"`
+	// Ports are the ports used for each ready endpoint of the service, defaults to 4317.
+	Ports []int32 `mapstructure:"ports"`
+}
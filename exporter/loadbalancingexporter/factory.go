@@ -28,6 +28,8 @@ const (
 	typeStr = "loadbalancing"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
+	// The stability level of the metrics exporter.
+	metricsStability = component.StabilityLevelDevelopment
 )
 
 // NewFactory creates a factory for the exporter.
@@ -39,6 +41,7 @@ func NewFactory() exporter.Factory {
 		createDefaultConfig,
 		exporter.WithTraces(createTracesExporter, stability),
 		exporter.WithLogs(createLogsExporter, stability),
+		exporter.WithMetrics(createMetricsExporter, metricsStability),
 	)
 }
 
@@ -60,3 +63,7 @@ func createTracesExporter(_ context.Context, params exporter.CreateSettings, cfg
 func createLogsExporter(_ context.Context, params exporter.CreateSettings, cfg component.Config) (exporter.Logs, error) {
 	return newLogsExporter(params, cfg)
 }
+
+func createMetricsExporter(_ context.Context, params exporter.CreateSettings, cfg component.Config) (exporter.Metrics, error) {
+	return newMetricsExporter(params, cfg)
+}
@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 const (
@@ -63,7 +65,13 @@ type loadBalancerImp struct {
 func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	numResolvers := 0
+	for _, configured := range []bool{oCfg.Resolver.Static != nil, oCfg.Resolver.DNS != nil, oCfg.Resolver.K8sSvc != nil} {
+		if configured {
+			numResolvers++
+		}
+	}
+	if numResolvers > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -85,6 +93,24 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 		}
 	}
 
+	if oCfg.Resolver.K8sSvc != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s service"))
+
+		apiConfig := oCfg.Resolver.K8sSvc.APIConfig
+		if apiConfig.AuthType == "" {
+			apiConfig.AuthType = k8sconfig.AuthTypeServiceAccount
+		}
+		clt, err := k8sconfig.MakeClient(apiConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = newK8sResolver(clt, k8sLogger, oCfg.Resolver.K8sSvc.Service, oCfg.Resolver.K8sSvc.Ports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
@@ -26,6 +26,8 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -111,6 +113,50 @@ func TestWithDNSResolver(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestWithK8sResolver(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "127.0.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "6443")
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sSvc: &K8sSvcResolver{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeNone},
+				Service:   "lb.monitoring",
+			},
+		},
+	}
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*k8sResolver)
+
+	// verify
+	require.True(t, ok)
+	assert.Equal(t, "lb", res.svcName)
+	assert.Equal(t, "monitoring", res.svcNs)
+	assert.Equal(t, []int32{4317}, res.ports)
+}
+
+func TestNewLoadBalancerInvalidK8sResolver(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "127.0.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "6443")
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sSvc: &K8sSvcResolver{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeNone},
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+
+	// verify
+	require.Nil(t, p)
+	require.Equal(t, errNoSvc, err)
+}
+
 func TestWithDNSResolverNoEndpoints(t *testing.T) {
 	// prepare
 	cfg := &Config{
@@ -152,6 +198,12 @@ func TestMultipleResolvers(t *testing.T) {
 	// verify
 	assert.Nil(t, p)
 	assert.Equal(t, errMultipleResolversProvided, err)
+
+	cfg.Resolver.Static = nil
+	cfg.Resolver.K8sSvc = &K8sSvcResolver{Service: "lb"}
+	p, err = newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
 }
 
 func TestStartFailureStaticResolver(t *testing.T) {
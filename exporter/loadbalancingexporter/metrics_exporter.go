@@ -0,0 +1,291 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+var _ exporter.Metrics = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   routingKey
+
+	stopped    bool
+	shutdownWg sync.WaitGroup
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params exporter.CreateSettings, cfg component.Config) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Component, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: svcRouting}
+
+	switch cfg.(*Config).RoutingKey {
+	case "service", "":
+	case "resource":
+		metricExporter.routingKey = resourceRouting
+	case "metric":
+		metricExporter.routingKey = metricRouting
+	default:
+		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(context.Context) error {
+	e.stopped = true
+	e.shutdownWg.Wait()
+	return nil
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	batches, err := splitMetrics(md, e.routingKey, e.loadBalancer.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	// export the batches in a stable order, which makes failures easier to reason about
+	endpoints := make([]string, 0, len(batches))
+	for endpoint := range batches {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	var errs error
+	for _, endpoint := range endpoints {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, endpoint, batches[endpoint]))
+	}
+
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, endpoint string, md pmetric.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(exporter.Metrics)
+	if !ok {
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected exporter.Metrics but got %T", exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
+
+// splitMetrics groups the given metrics per backend endpoint, as returned by the endpointFor function
+// for the routing identifier of each resource (for service and resource routing) or of each data point
+// (for metric routing).
+func splitMetrics(md pmetric.Metrics, key routingKey, endpointFor func([]byte) string) (map[string]pmetric.Metrics, error) {
+	batches := make(map[string]pmetric.Metrics)
+	batchFor := func(endpoint string) pmetric.Metrics {
+		batch, ok := batches[endpoint]
+		if !ok {
+			batch = pmetric.NewMetrics()
+			batches[endpoint] = batch
+		}
+		return batch
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		switch key {
+		case svcRouting:
+			svc, ok := rm.Resource().Attributes().Get("service.name")
+			if !ok {
+				return nil, errors.New("unable to get service name")
+			}
+			rm.CopyTo(batchFor(endpointFor([]byte(svc.Str()))).ResourceMetrics().AppendEmpty())
+		case resourceRouting:
+			rid := resourceIdentity(rm.Resource())
+			rm.CopyTo(batchFor(endpointFor(rid[:])).ResourceMetrics().AppendEmpty())
+		case metricRouting:
+			splitResourceMetricsByStream(rm, endpointFor, batchFor)
+		default:
+			return nil, fmt.Errorf("unsupported routing key for metrics: %d", key)
+		}
+	}
+
+	return batches, nil
+}
+
+// splitResourceMetricsByStream routes each data point of the resource based on the identity of its stream:
+// the resource, the scope, the metric name and the data point attributes. The resource, scope and metric
+// metadata are copied to every batch receiving at least one of their data points.
+func splitResourceMetricsByStream(rm pmetric.ResourceMetrics, endpointFor func([]byte) string, batchFor func(string) pmetric.Metrics) {
+	rid := resourceIdentity(rm.Resource())
+	destRMs := make(map[string]pmetric.ResourceMetrics)
+
+	sms := rm.ScopeMetrics()
+	for j := 0; j < sms.Len(); j++ {
+		sm := sms.At(j)
+		destSMs := make(map[string]pmetric.ScopeMetrics)
+
+		ms := sm.Metrics()
+		for k := 0; k < ms.Len(); k++ {
+			m := ms.At(k)
+			destMs := make(map[string]pmetric.Metric)
+
+			// returns the metric within the batch for the endpoint, creating its parents when needed
+			destFor := func(attrs pcommon.Map) pmetric.Metric {
+				endpoint := endpointFor(streamIdentity(rid, sm.Scope(), m.Name(), attrs))
+				if dest, ok := destMs[endpoint]; ok {
+					return dest
+				}
+
+				destSM, ok := destSMs[endpoint]
+				if !ok {
+					destRM, found := destRMs[endpoint]
+					if !found {
+						destRM = batchFor(endpoint).ResourceMetrics().AppendEmpty()
+						rm.Resource().CopyTo(destRM.Resource())
+						destRM.SetSchemaUrl(rm.SchemaUrl())
+						destRMs[endpoint] = destRM
+					}
+					destSM = destRM.ScopeMetrics().AppendEmpty()
+					sm.Scope().CopyTo(destSM.Scope())
+					destSM.SetSchemaUrl(sm.SchemaUrl())
+					destSMs[endpoint] = destSM
+				}
+
+				dest := destSM.Metrics().AppendEmpty()
+				copyMetricMetadata(m, dest)
+				destMs[endpoint] = dest
+				return dest
+			}
+
+			switch m.Type() {
+			case pmetric.MetricTypeGauge:
+				dps := m.Gauge().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dps.At(l).CopyTo(destFor(dps.At(l).Attributes()).Gauge().DataPoints().AppendEmpty())
+				}
+			case pmetric.MetricTypeSum:
+				dps := m.Sum().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dps.At(l).CopyTo(destFor(dps.At(l).Attributes()).Sum().DataPoints().AppendEmpty())
+				}
+			case pmetric.MetricTypeHistogram:
+				dps := m.Histogram().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dps.At(l).CopyTo(destFor(dps.At(l).Attributes()).Histogram().DataPoints().AppendEmpty())
+				}
+			case pmetric.MetricTypeExponentialHistogram:
+				dps := m.ExponentialHistogram().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dps.At(l).CopyTo(destFor(dps.At(l).Attributes()).ExponentialHistogram().DataPoints().AppendEmpty())
+				}
+			case pmetric.MetricTypeSummary:
+				dps := m.Summary().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dps.At(l).CopyTo(destFor(dps.At(l).Attributes()).Summary().DataPoints().AppendEmpty())
+				}
+			}
+		}
+	}
+}
+
+// copyMetricMetadata copies everything but the data points from the source metric to the destination.
+func copyMetricMetadata(src, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dest.SetEmptySummary()
+	}
+}
+
+func resourceIdentity(res pcommon.Resource) [16]byte {
+	return pdatautil.MapHash(res.Attributes())
+}
+
+func streamIdentity(rid [16]byte, scope pcommon.InstrumentationScope, name string, attrs pcommon.Map) []byte {
+	aid := pdatautil.MapHash(attrs)
+
+	id := make([]byte, 0, len(rid)+len(scope.Name())+len(scope.Version())+len(name)+len(aid)+3)
+	id = append(id, rid[:]...)
+	id = append(id, scope.Name()...)
+	id = append(id, 0)
+	id = append(id, scope.Version()...)
+	id = append(id, 0)
+	id = append(id, name...)
+	id = append(id, 0)
+	id = append(id, aid[:]...)
+	return id
+}
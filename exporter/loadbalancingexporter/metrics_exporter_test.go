@@ -0,0 +1,428 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		config     *Config
+		routingKey routingKey
+		err        error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			svcRouting,
+			nil,
+		},
+		{
+			"resource",
+			metricsRoutingConfig("resource"),
+			resourceRouting,
+			nil,
+		},
+		{
+			"metric",
+			metricsRoutingConfig("metric"),
+			metricRouting,
+			nil,
+		},
+		{
+			"traceID",
+			metricsRoutingConfig("traceID"),
+			svcRouting,
+			errors.New("unsupported routing_key: traceID"),
+		},
+		{
+			"empty",
+			&Config{},
+			svcRouting,
+			errNoResolver,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+			if err == nil {
+				assert.Equal(t, tt.routingKey, p.routingKey)
+			}
+		})
+	}
+}
+
+func TestMetricsExporterStart(t *testing.T) {
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), simpleConfig(), nil)
+	require.NoError(t, err)
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		onStart: func(context.Context) error {
+			return errors.New("some expected err")
+		},
+	}
+	p.loadBalancer = lb
+
+	// test
+	res := p.Start(context.Background(), componenttest.NewNopHost())
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	require.Equal(t, errors.New("some expected err"), res)
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		routingKey string
+	}{
+		{"service", "service"},
+		{"resource", "resource"},
+		{"metric", "metric"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var mu sync.Mutex
+			received := map[string][]pmetric.Metrics{}
+			componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+				return newMockMetricsExporter(func(ctx context.Context, md pmetric.Metrics) error {
+					mu.Lock()
+					defer mu.Unlock()
+					received[endpoint] = append(received[endpoint], md)
+					return nil
+				}), nil
+			}
+			cfg := metricsRoutingConfig(tt.routingKey)
+			lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+			require.NoError(t, err)
+
+			p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			lb.res = &mockResolver{
+				triggerCallbacks: true,
+				onResolve: func(ctx context.Context) ([]string, error) {
+					return []string{"endpoint-1"}, nil
+				},
+			}
+			p.loadBalancer = lb
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			// test
+			res := p.ConsumeMetrics(context.Background(), twoServicesMetrics())
+
+			// verify
+			assert.Nil(t, res)
+			require.Len(t, received, 1)
+			require.Len(t, received["endpoint-1:4317"], 1)
+			md := received["endpoint-1:4317"][0]
+			assert.Equal(t, 2, md.ResourceMetrics().Len())
+			assert.Equal(t, 8, md.DataPointCount())
+		})
+	}
+}
+
+func TestConsumeMetricsServiceWithoutName(t *testing.T) {
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+
+	_, err := splitMetrics(md, svcRouting, newHashRing([]string{"endpoint-1"}).endpointFor)
+	assert.EqualError(t, err, "unable to get service name")
+}
+
+func TestSplitMetricsByResource(t *testing.T) {
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"})
+
+	for _, key := range []routingKey{svcRouting, resourceRouting} {
+		// test
+		batches, err := splitMetrics(twoServicesMetrics(), key, ring.endpointFor)
+		require.NoError(t, err)
+
+		// verify: each resource is kept as a whole, and is sent to a single backend
+		total := 0
+		for _, md := range batches {
+			rms := md.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				assert.Equal(t, 2, rms.At(i).ScopeMetrics().At(0).Metrics().Len())
+			}
+			total += rms.Len()
+		}
+		assert.Equal(t, 2, total)
+	}
+}
+
+func TestSplitMetricsByStream(t *testing.T) {
+	// one endpoint per routing identifier, so that every stream ends up in its own batch
+	endpointFor := func(id []byte) string {
+		return string(id)
+	}
+
+	// test
+	batches, err := splitMetrics(twoServicesMetrics(), metricRouting, endpointFor)
+	require.NoError(t, err)
+
+	// verify
+	require.Len(t, batches, 8)
+	for _, md := range batches {
+		require.Equal(t, 1, md.ResourceMetrics().Len())
+		rm := md.ResourceMetrics().At(0)
+		_, ok := rm.Resource().Attributes().Get("service.name")
+		assert.True(t, ok)
+
+		require.Equal(t, 1, rm.ScopeMetrics().Len())
+		sm := rm.ScopeMetrics().At(0)
+		assert.Equal(t, "scope", sm.Scope().Name())
+
+		require.Equal(t, 1, sm.Metrics().Len())
+		m := sm.Metrics().At(0)
+		assert.Equal(t, 1, md.DataPointCount())
+		if m.Type() == pmetric.MetricTypeSum {
+			assert.Equal(t, "requests", m.Name())
+			assert.True(t, m.Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+		} else {
+			assert.Equal(t, "duration", m.Name())
+			assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Histogram().AggregationTemporality())
+		}
+	}
+}
+
+func TestSplitMetricsByStreamIsConsistent(t *testing.T) {
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"})
+	first, err := splitMetrics(twoServicesMetrics(), metricRouting, ring.endpointFor)
+	require.NoError(t, err)
+
+	// the same streams, with the resources in a different order
+	md := pmetric.NewMetrics()
+	src := twoServicesMetrics()
+	for i := src.ResourceMetrics().Len() - 1; i >= 0; i-- {
+		src.ResourceMetrics().At(i).CopyTo(md.ResourceMetrics().AppendEmpty())
+	}
+
+	// test
+	second, err := splitMetrics(md, metricRouting, ring.endpointFor)
+	require.NoError(t, err)
+
+	// verify
+	require.Equal(t, len(first), len(second))
+	for endpoint, batch := range first {
+		other, ok := second[endpoint]
+		require.True(t, ok)
+		assert.ElementsMatch(t, streamsOf(batch), streamsOf(other))
+	}
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.addMissingExporters(context.Background(), []string{"endpoint-1"})
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), twoServicesMetrics())
+
+	// verify
+	assert.Error(t, res)
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected exporter.Metrics but got %T", newNopMockExporter()))
+}
+
+func TestConsumeMetricsExporterNoEndpoint(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newNopMockMetricsExporter(), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return nil, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), twoServicesMetrics())
+
+	// verify
+	assert.Error(t, res)
+	assert.EqualError(t, res, fmt.Sprintf("couldn't find the exporter for the endpoint %q", ""))
+}
+
+func metricsRoutingConfig(key string) *Config {
+	return &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+		},
+		RoutingKey: key,
+	}
+}
+
+// twoServicesMetrics returns two resources, each with a cumulative sum and a delta histogram, each with
+// two data points.
+func twoServicesMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, svc := range []string{"svc-1", "svc-2"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", svc)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("scope")
+
+		sum := sm.Metrics().AppendEmpty()
+		sum.SetName("requests")
+		sum.SetEmptySum().SetIsMonotonic(true)
+		sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		hist := sm.Metrics().AppendEmpty()
+		hist.SetName("duration")
+		hist.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+
+		for _, method := range []string{"GET", "POST"} {
+			dp := sum.Sum().DataPoints().AppendEmpty()
+			dp.Attributes().PutStr("http.method", method)
+			dp.SetIntValue(1)
+
+			hdp := hist.Histogram().DataPoints().AppendEmpty()
+			hdp.Attributes().PutStr("http.method", method)
+			hdp.SetCount(1)
+			hdp.SetStartTimestamp(pcommon.Timestamp(1))
+		}
+	}
+	return md
+}
+
+// streamsOf returns an identifier for each data point in the given metrics, made of the service name,
+// the metric name and the value of the http.method attribute.
+func streamsOf(md pmetric.Metrics) []string {
+	var streams []string
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		svc, _ := rms.At(i).Resource().Attributes().Get("service.name")
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				var attrs []pcommon.Map
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					for l := 0; l < m.Sum().DataPoints().Len(); l++ {
+						attrs = append(attrs, m.Sum().DataPoints().At(l).Attributes())
+					}
+				case pmetric.MetricTypeHistogram:
+					for l := 0; l < m.Histogram().DataPoints().Len(); l++ {
+						attrs = append(attrs, m.Histogram().DataPoints().At(l).Attributes())
+					}
+				}
+				for _, attr := range attrs {
+					method, _ := attr.Get("http.method")
+					streams = append(streams, fmt.Sprintf("%s/%s/%s", svc.Str(), m.Name(), method.Str()))
+				}
+			}
+		}
+	}
+	return streams
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	ConsumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) exporter.Metrics {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		ConsumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func newNopMockMetricsExporter() exporter.Metrics {
+	return &mockMetricsExporter{
+		Component: mockComponent{},
+		ConsumeMetricsFn: func(ctx context.Context, md pmetric.Metrics) error {
+			return nil
+		},
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.ConsumeMetricsFn == nil {
+		return nil
+	}
+	return e.ConsumeMetricsFn(ctx, md)
+}
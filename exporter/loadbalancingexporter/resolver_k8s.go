@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace   = "default"
+	defaultK8sSyncTimeout = 5 * time.Second
+)
+
+var (
+	errNoSvc = errors.New("no service specified to resolve the backends")
+
+	k8sResolverMutator = tag.Upsert(tag.MustNewKey("resolver"), "k8s")
+
+	k8sResolverSuccessTrueMutators  = []tag.Mutator{k8sResolverMutator, successTrueMutator}
+	k8sResolverSuccessFalseMutators = []tag.Mutator{k8sResolverMutator, successFalseMutator}
+)
+
+// k8sResolver resolves the backends from the ready endpoints of a Kubernetes service,
+// watching its EndpointSlices so that changes are applied as soon as they happen.
+type k8sResolver struct {
+	logger *zap.Logger
+
+	svcName     string
+	svcNs       string
+	ports       []int32
+	syncTimeout time.Duration
+
+	listWatcher cache.ListerWatcher
+
+	// slices holds the ready addresses of each EndpointSlice of the service, by slice name.
+	slices    map[string][]string
+	endpoints []string
+
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sResolver(clt kubernetes.Interface, logger *zap.Logger, service string, ports []int32) (*k8sResolver, error) {
+	name, namespace, _ := strings.Cut(service, ".")
+	if len(name) == 0 {
+		return nil, errNoSvc
+	}
+	if len(namespace) == 0 {
+		namespace = defaultK8sNamespace
+	}
+	if len(ports) == 0 {
+		port, _ := strconv.ParseInt(defaultPort, 10, 32)
+		ports = []int32{int32(port)}
+	}
+
+	selector := fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, name)
+	listWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector
+			return clt.DiscoveryV1().EndpointSlices(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			return clt.DiscoveryV1().EndpointSlices(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	return &k8sResolver{
+		logger:      logger,
+		svcName:     name,
+		svcNs:       namespace,
+		ports:       ports,
+		syncTimeout: defaultK8sSyncTimeout,
+		listWatcher: listWatcher,
+		slices:      map[string][]string{},
+		stopCh:      make(chan struct{}),
+	}, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	informer := cache.NewSharedInformer(r.listWatcher, &discoveryv1.EndpointSlice{}, 0)
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.onSliceUpdate,
+		UpdateFunc: func(_, obj interface{}) { r.onSliceUpdate(obj) },
+		DeleteFunc: r.onSliceDelete,
+	}); err != nil {
+		return err
+	}
+
+	r.shutdownWg.Add(1)
+	go func() {
+		defer r.shutdownWg.Done()
+		informer.Run(r.stopCh)
+	}()
+
+	syncCtx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		r.logger.Warn("failed to sync the endpoints of the service, backends will be added once available",
+			zap.String("service", r.svcName), zap.String("namespace", r.svcNs))
+	}
+
+	r.logger.Debug("Kubernetes resolver started",
+		zap.String("service", r.svcName), zap.String("namespace", r.svcNs), zap.Int32s("ports", r.ports))
+	return nil
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) onSliceUpdate(obj interface{}) {
+	slice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		_ = stats.RecordWithTags(context.Background(), k8sResolverSuccessFalseMutators, mNumResolutions.M(1))
+		r.logger.Warn("unexpected object received by the Kubernetes resolver", zap.Any("object", obj))
+		return
+	}
+
+	var addresses []string
+	for _, endpoint := range slice.Endpoints {
+		// endpoints with an unknown readiness should be considered ready
+		if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+			continue
+		}
+		addresses = append(addresses, endpoint.Addresses...)
+	}
+
+	r.updateLock.Lock()
+	r.slices[slice.Name] = addresses
+	r.updateLock.Unlock()
+
+	_, _ = r.resolve(context.Background())
+}
+
+func (r *k8sResolver) onSliceDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	slice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		r.logger.Warn("unexpected object deleted from the Kubernetes resolver", zap.Any("object", obj))
+		return
+	}
+
+	r.updateLock.Lock()
+	delete(r.slices, slice.Name)
+	r.updateLock.Unlock()
+
+	_, _ = r.resolve(context.Background())
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	r.shutdownWg.Add(1)
+	defer r.shutdownWg.Done()
+
+	r.updateLock.Lock()
+	seen := map[string]bool{}
+	var backends []string
+	for _, addresses := range r.slices {
+		for _, address := range addresses {
+			if seen[address] {
+				continue
+			}
+			seen[address] = true
+			for _, port := range r.ports {
+				backends = append(backends, net.JoinHostPort(address, strconv.Itoa(int(port))))
+			}
+		}
+	}
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumResolutions.M(1))
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	if equalStringSlice(r.endpoints, backends) {
+		r.updateLock.Unlock()
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestK8sResolverErrNoSvc(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), ".monitoring", nil)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
+
+func TestK8sResolverDefaults(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", nil)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "lb", res.svcName)
+	assert.Equal(t, defaultK8sNamespace, res.svcNs)
+	assert.Equal(t, []int32{4317}, res.ports)
+}
+
+func TestK8sResolve(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(
+		newEndpointSlice("lb-1", "lb", "monitoring", map[string]bool{"10.0.0.1": true, "10.0.0.2": true, "10.0.0.3": false}),
+		newEndpointSlice("lb-2", "lb", "monitoring", map[string]bool{"fd00::1": true}),
+		newEndpointSlice("other-1", "other", "monitoring", map[string]bool{"10.0.0.9": true}),
+	)
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.monitoring", []int32{4317, 4318})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		resolved = endpoints
+		mu.Unlock()
+	})
+	current := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return resolved
+	}
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{
+			"10.0.0.1:4317", "10.0.0.1:4318",
+			"10.0.0.2:4317", "10.0.0.2:4318",
+			"[fd00::1]:4317", "[fd00::1]:4318",
+		}, current())
+	}, 5*time.Second, 10*time.Millisecond, "initial endpoints not resolved: %v", current())
+
+	// an endpoint becomes ready and another one goes away
+	_, err = clt.DiscoveryV1().EndpointSlices("monitoring").Update(context.Background(),
+		newEndpointSlice("lb-1", "lb", "monitoring", map[string]bool{"10.0.0.2": true, "10.0.0.3": true}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{
+			"10.0.0.2:4317", "10.0.0.2:4318",
+			"10.0.0.3:4317", "10.0.0.3:4318",
+			"[fd00::1]:4317", "[fd00::1]:4318",
+		}, current())
+	}, 5*time.Second, 10*time.Millisecond, "updated endpoints not resolved: %v", current())
+
+	// a whole slice is removed
+	require.NoError(t, clt.DiscoveryV1().EndpointSlices("monitoring").Delete(context.Background(), "lb-2", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{
+			"10.0.0.2:4317", "10.0.0.2:4318",
+			"10.0.0.3:4317", "10.0.0.3:4318",
+		}, current())
+	}, 5*time.Second, 10*time.Millisecond, "deleted endpoints not removed: %v", current())
+}
+
+func TestK8sResolverIgnoresUnexpectedObjects(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	changed := false
+	res.onChange(func([]string) { changed = true })
+
+	// test
+	res.onSliceUpdate(&corev1.Pod{})
+	res.onSliceDelete(&corev1.Pod{})
+
+	// verify
+	assert.False(t, changed)
+	assert.Empty(t, res.endpoints)
+}
+
+func newEndpointSlice(name, service, namespace string, addresses map[string]bool) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+	}
+	for address, ready := range addresses {
+		ready := ready
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{address},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		})
+	}
+	return slice
+}
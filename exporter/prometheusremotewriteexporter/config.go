@@ -53,6 +53,9 @@ type Config struct {
 
 	// CreatedMetric allows customizing creation of _created metrics
 	CreatedMetric *CreatedMetric `mapstructure:"export_created_metric,omitempty"`
+
+	// SendMetadata if true the type, help and unit of the metric families are sent in the write requests
+	SendMetadata bool `mapstructure:"send_metadata"`
 }
 
 type CreatedMetric struct {
@@ -86,6 +86,7 @@ func TestLoadConfig(t *testing.T) {
 					Enabled: true,
 				},
 				CreatedMetric: &CreatedMetric{Enabled: true},
+				SendMetadata:  true,
 			},
 		},
 		{
@@ -36,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
@@ -370,7 +371,7 @@ func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) error {
 		return err
 	}
 
-	return prwe.handleExport(context.Background(), testmap)
+	return prwe.handleExport(context.Background(), testmap, nil)
 }
 
 // Test_PushMetrics checks the number of TimeSeries received by server and the number of metrics dropped is the same as
@@ -728,6 +729,101 @@ func Test_PushMetrics(t *testing.T) {
 	}
 }
 
+// Test_PushMetricsMetadataAndNativeHistograms checks the metadata and the native histograms received by the server
+func Test_PushMetricsMetadataAndNativeHistograms(t *testing.T) {
+	counter := pmetric.NewMetric()
+	counter.SetName("http.server.requests")
+	counter.SetDescription("The number of requests.")
+	counter.SetEmptySum().SetIsMonotonic(true)
+	counter.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	counter.Sum().DataPoints().AppendEmpty().SetDoubleValue(floatVal1)
+	counter.Sum().DataPoints().At(0).SetTimestamp(pcommon.Timestamp(time1))
+
+	// the scale 10 is higher than the highest native histogram schema, the buckets are merged by 4
+	expHistogram := getExpHistogramMetric("http.server.duration", lbs1, time1, &floatVal2, uint64(5), 0, []uint64{1, 1, 1, 1, 1})
+	expHistogram.SetUnit("s")
+	expHistogram.SetDescription("The duration of the requests.")
+	expHistogram.ExponentialHistogram().DataPoints().At(0).SetScale(10)
+
+	metrics := getMetricsFromMetricList(counter, expHistogram)
+
+	tests := []struct {
+		name         string
+		sendMetadata bool
+		wantMetadata []prompb.MetricMetadata
+	}{
+		{
+			name:         "with_metadata",
+			sendMetadata: true,
+			wantMetadata: []prompb.MetricMetadata{
+				{
+					Type:             prompb.MetricMetadata_COUNTER,
+					MetricFamilyName: "http_server_requests_total",
+					Help:             "The number of requests.",
+				},
+				{
+					Type:             prompb.MetricMetadata_HISTOGRAM,
+					MetricFamilyName: "http_server_duration_seconds",
+					Help:             "The duration of the requests.",
+					Unit:             "seconds",
+				},
+			},
+		},
+		{
+			name:         "without_metadata",
+			sendMetadata: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []*prompb.WriteRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				dest, err := snappy.Decode(nil, body)
+				require.NoError(t, err)
+				wr := &prompb.WriteRequest{}
+				require.NoError(t, proto.Unmarshal(dest, wr))
+
+				mu.Lock()
+				requests = append(requests, wr)
+				mu.Unlock()
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.HTTPClientSettings.Endpoint = server.URL
+			cfg.RemoteWriteQueue.NumConsumers = 1
+			cfg.TargetInfo.Enabled = false
+			cfg.SendMetadata = tt.sendMetadata
+
+			prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+			require.NoError(t, err)
+			require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, prwe.Shutdown(context.Background()))
+			}()
+			require.NoError(t, prwe.PushMetrics(context.Background(), metrics))
+
+			require.Len(t, requests, 1)
+			assert.Equal(t, tt.wantMetadata, requests[0].Metadata)
+
+			var histograms []prompb.Histogram
+			for _, ts := range requests[0].Timeseries {
+				histograms = append(histograms, ts.Histograms...)
+			}
+			require.Len(t, histograms, 1)
+			assert.Equal(t, int32(8), histograms[0].Schema)
+			assert.Equal(t, []prompb.BucketSpan{{Offset: 1, Length: 2}}, histograms[0].PositiveSpans)
+			assert.Equal(t, []int64{4, -3}, histograms[0].PositiveDeltas)
+			assert.Equal(t, uint64(5), histograms[0].GetCountInt())
+		})
+	}
+}
+
 func Test_validateAndSanitizeExternalLabels(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -912,7 +1008,7 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 		"timeseries1": ts1,
 		"timeseries2": ts2,
 	}
-	errs := prwe.handleExport(ctx, tsMap)
+	errs := prwe.handleExport(ctx, tsMap, nil)
 	assert.NoError(t, errs)
 	// Shutdown after we've written to the WAL. This ensures that our
 	// exported data in-flight will flushed flushed to the WAL before exiting.
@@ -21,21 +21,23 @@ import (
 	"github.com/prometheus/prometheus/prompb"
 )
 
-// batchTimeSeries splits series into multiple batch write requests.
-func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int) ([]*prompb.WriteRequest, error) {
+// batchTimeSeries splits series and metadata into multiple batch write requests, the metadata
+// fills the batches after the series.
+func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, metadata []*prompb.MetricMetadata, maxBatchByteSize int) ([]*prompb.WriteRequest, error) {
 	if len(tsMap) == 0 {
 		return nil, errors.New("invalid tsMap: cannot be empty map")
 	}
 
 	var requests []*prompb.WriteRequest
 	var tsArray []prompb.TimeSeries
+	var mArray []prompb.MetricMetadata
 	sizeOfCurrentBatch := 0
 
 	for _, v := range tsMap {
 		sizeOfSeries := v.Size()
 
 		if sizeOfCurrentBatch+sizeOfSeries >= maxBatchByteSize {
-			wrapped := convertTimeseriesToRequest(tsArray)
+			wrapped := convertTimeseriesToRequest(tsArray, nil)
 			requests = append(requests, wrapped)
 
 			tsArray = nil
@@ -46,23 +48,38 @@ func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int)
 		sizeOfCurrentBatch += sizeOfSeries
 	}
 
-	if len(tsArray) != 0 {
-		wrapped := convertTimeseriesToRequest(tsArray)
+	for _, m := range metadata {
+		sizeOfMetadata := m.Size()
+
+		if sizeOfCurrentBatch+sizeOfMetadata >= maxBatchByteSize {
+			wrapped := convertTimeseriesToRequest(tsArray, mArray)
+			requests = append(requests, wrapped)
+
+			tsArray = nil
+			mArray = nil
+			sizeOfCurrentBatch = 0
+		}
+
+		mArray = append(mArray, *m)
+		sizeOfCurrentBatch += sizeOfMetadata
+	}
+
+	if len(tsArray) != 0 || len(mArray) != 0 {
+		wrapped := convertTimeseriesToRequest(tsArray, mArray)
 		requests = append(requests, wrapped)
 	}
 
 	return requests, nil
 }
 
-func convertTimeseriesToRequest(tsArray []prompb.TimeSeries) *prompb.WriteRequest {
-	// the remote_write endpoint only requires the timeseries.
-	// otlp defines it's own way to handle metric metadata
+func convertTimeseriesToRequest(tsArray []prompb.TimeSeries, mArray []prompb.MetricMetadata) *prompb.WriteRequest {
 	return &prompb.WriteRequest{
 		// Prometheus requires time series to be sorted by Timestamp to avoid out of order problems.
 		// See:
 		// * https://github.com/open-telemetry/wg-prometheus/issues/10
 		// * https://github.com/open-telemetry/opentelemetry-collector/issues/2315
 		Timeseries: orderBySampleTimestamp(tsArray),
+		Metadata:   mArray,
 	}
 }
 
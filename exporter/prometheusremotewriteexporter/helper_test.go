@@ -68,7 +68,7 @@ func Test_batchTimeSeries(t *testing.T) {
 	// run tests
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			requests, err := batchTimeSeries(tt.tsMap, tt.maxBatchByteSize)
+			requests, err := batchTimeSeries(tt.tsMap, nil, tt.maxBatchByteSize)
 			if tt.returnErr {
 				assert.Error(t, err)
 				return
@@ -79,6 +79,34 @@ func Test_batchTimeSeries(t *testing.T) {
 	}
 }
 
+// Test_batchTimeSeriesWithMetadata checks the metadata is sent in the batches after the series.
+func Test_batchTimeSeriesWithMetadata(t *testing.T) {
+	labels := getPromLabels(label11, value11, label12, value12, label21, value21, label22, value22)
+	ts1 := getTimeSeries(labels, getSample(floatVal1, msTime1), getSample(floatVal2, msTime2))
+	tsMap := getTimeseriesMap([]*prompb.TimeSeries{ts1})
+	metadata := []*prompb.MetricMetadata{
+		{
+			Type:             prompb.MetricMetadata_COUNTER,
+			MetricFamilyName: "http_server_requests_total",
+			Help:             "The number of requests.",
+		},
+	}
+
+	requests, err := batchTimeSeries(tsMap, metadata, 300)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, 1, len(requests[0].Timeseries))
+	assert.Equal(t, []prompb.MetricMetadata{*metadata[0]}, requests[0].Metadata)
+
+	requests, err = batchTimeSeries(tsMap, metadata, ts1.Size()+1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(requests))
+	assert.Equal(t, 1, len(requests[0].Timeseries))
+	assert.Empty(t, requests[0].Metadata)
+	assert.Empty(t, requests[1].Timeseries)
+	assert.Equal(t, []prompb.MetricMetadata{*metadata[0]}, requests[1].Metadata)
+}
+
 // Ensure that before a prompb.WriteRequest is created, that the points per TimeSeries
 // are sorted by Timestamp value, to prevent Prometheus from barfing when it gets poorly
 // sorted values. See issues:
@@ -127,7 +155,7 @@ func TestEnsureTimeseriesPointsAreSortedByTimestamp(t *testing.T) {
 			},
 		},
 	}
-	got := convertTimeseriesToRequest(outOfOrder)
+	got := convertTimeseriesToRequest(outOfOrder, nil)
 
 	// We must ensure that the resulting Timeseries' sample points are sorted by Timestamp.
 	want := &prompb.WriteRequest{
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jaegertracing/jaeger/pkg/metrics"
+	jaegeradaptive "github.com/jaegertracing/jaeger/plugin/sampling/strategystore/adaptive"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/adaptive"
+)
+
+// The defaults of the Jaeger collector adaptive sampling.
+const (
+	defaultTargetSamplesPerSecond       = 1
+	defaultDeltaTolerance               = 0.3
+	defaultBucketsForCalculation        = 1
+	defaultCalculationInterval          = time.Minute
+	defaultAggregationBuckets           = 10
+	defaultDelay                        = 2 * time.Minute
+	defaultInitialSamplingProbability   = 0.001
+	defaultMinSamplingProbability       = 1e-5
+	defaultMinSamplesPerSecond          = 1.0 / 60
+	defaultLeaderLeaseRefreshInterval   = 5 * time.Second
+	defaultFollowerLeaseRefreshInterval = time.Minute
+)
+
+// ThroughputRecorder is implemented by the extension to record the span throughput the adaptive
+// strategies are computed from. Recording is a no-op unless the `adaptive` source is used.
+type ThroughputRecorder interface {
+	RecordThroughput(td ptrace.Traces)
+}
+
+var _ ThroughputRecorder = (*jrsExtension)(nil)
+
+// RecordThroughput implements ThroughputRecorder.
+func (jrse *jrsExtension) RecordThroughput(td ptrace.Traces) {
+	if jrse.aggregator == nil {
+		return
+	}
+	adaptive.RecordThroughput(jrse.aggregator, td)
+}
+
+func (jrse *jrsExtension) startAdaptive(ctx context.Context, host component.Host) error {
+	cfg := jrse.cfg.Source.Adaptive
+	opts := cfg.options()
+
+	var client storage.Client
+	if cfg.StorageID != nil {
+		var err error
+		client, err = getStorageClient(ctx, host, *cfg.StorageID, jrse.id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the processor reads the throughput of the last aggregation buckets, behind the delay
+	retention := opts.Delay + time.Duration(opts.AggregationBuckets+1)*opts.CalculationInterval
+	store := adaptive.NewStore(client, retention)
+	if err := store.Load(ctx); err != nil {
+		return fmt.Errorf("failed to load the adaptive sampling state: %w", err)
+	}
+
+	ss, err := jaegeradaptive.NewStrategyStore(opts, metrics.NullFactory, jrse.telemetry.Logger, adaptive.NewLock(), store)
+	if err != nil {
+		return fmt.Errorf("failed to create the adaptive strategy store: %w", err)
+	}
+	if err = ss.Start(); err != nil {
+		return fmt.Errorf("failed to start the adaptive strategy store: %w", err)
+	}
+
+	aggregator := jaegeradaptive.NewAggregator(metrics.NullFactory, opts.CalculationInterval, store)
+	aggregator.Start()
+
+	jrse.samplingStore = ss
+	jrse.aggregator = aggregator
+	jrse.closers = append(jrse.closers, aggregator.Close, ss.Close)
+	if client != nil {
+		jrse.closers = append(jrse.closers, func() error {
+			return client.Close(context.Background())
+		})
+	}
+
+	return nil
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindExtension, componentID, "")
+}
+
+func (cfg *AdaptiveConfig) options() jaegeradaptive.Options {
+	opts := jaegeradaptive.Options{
+		TargetSamplesPerSecond:       cfg.TargetSamplesPerSecond,
+		DeltaTolerance:               cfg.DeltaTolerance,
+		CalculationInterval:          cfg.CalculationInterval,
+		AggregationBuckets:           cfg.AggregationBuckets,
+		BucketsForCalculation:        cfg.BucketsForCalculation,
+		Delay:                        cfg.Delay,
+		InitialSamplingProbability:   cfg.InitialSamplingProbability,
+		MinSamplingProbability:       cfg.MinSamplingProbability,
+		MinSamplesPerSecond:          cfg.MinSamplesPerSecond,
+		LeaderLeaseRefreshInterval:   defaultLeaderLeaseRefreshInterval,
+		FollowerLeaseRefreshInterval: defaultFollowerLeaseRefreshInterval,
+	}
+
+	if opts.TargetSamplesPerSecond == 0 {
+		opts.TargetSamplesPerSecond = defaultTargetSamplesPerSecond
+	}
+	if opts.DeltaTolerance == 0 {
+		opts.DeltaTolerance = defaultDeltaTolerance
+	}
+	if opts.CalculationInterval == 0 {
+		opts.CalculationInterval = defaultCalculationInterval
+	}
+	if opts.AggregationBuckets == 0 {
+		opts.AggregationBuckets = defaultAggregationBuckets
+	}
+	if opts.BucketsForCalculation == 0 {
+		opts.BucketsForCalculation = defaultBucketsForCalculation
+	}
+	if opts.Delay == 0 {
+		opts.Delay = defaultDelay
+	}
+	if opts.InitialSamplingProbability == 0 {
+		opts.InitialSamplingProbability = defaultInitialSamplingProbability
+	}
+	if opts.MinSamplingProbability == 0 {
+		opts.MinSamplingProbability = defaultMinSamplingProbability
+	}
+	if opts.MinSamplesPerSecond == 0 {
+		opts.MinSamplesPerSecond = defaultMinSamplesPerSecond
+	}
+
+	return opts
+}
@@ -24,9 +24,11 @@ import (
 )
 
 var (
-	errTooManySources     = errors.New("too many sources specified, has to be either 'file' or 'remote'")
-	errNoSources          = errors.New("no sources specified, has to be either 'file' or 'remote'")
+	errTooManySources     = errors.New("too many sources specified, has to be either 'file', 'remote' or 'adaptive'")
+	errNoSources          = errors.New("no sources specified, has to be either 'file', 'remote' or 'adaptive'")
 	errAtLeastOneProtocol = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+	errNegativeAdaptive   = errors.New("the adaptive sampling settings cannot be negative")
+	errProbabilityRange   = errors.New("the adaptive sampling probabilities have to be between 0 and 1")
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -35,7 +37,7 @@ type Config struct {
 	*confighttp.HTTPServerSettings `mapstructure:"http"`
 	*configgrpc.GRPCServerSettings `mapstructure:"grpc"`
 
-	// Source configures the source for the strategies file. One of `remote` or `file` has to be specified.
+	// Source configures the source for the strategies file. One of `remote`, `file` or `adaptive` has to be specified.
 	Source Source `mapstructure:"source"`
 }
 
@@ -48,6 +50,43 @@ type Source struct {
 
 	// ReloadInterval determines the periodicity to refresh the strategies
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// Adaptive computes the strategies from the span throughput recorded by the jaegerthroughput processor
+	Adaptive *AdaptiveConfig `mapstructure:"adaptive"`
+}
+
+// AdaptiveConfig has the settings of the adaptive sampling strategies, following the ones of the Jaeger collector.
+// The settings left to zero use the Jaeger defaults.
+type AdaptiveConfig struct {
+	// StorageID is the storage extension keeping the throughput and the probabilities across restarts
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// TargetSamplesPerSecond is the number of samples per second targeted for each operation
+	TargetSamplesPerSecond float64 `mapstructure:"target_samples_per_second"`
+
+	// DeltaTolerance is the ratio of deviation from the target samples per second keeping the probability unchanged
+	DeltaTolerance float64 `mapstructure:"delta_tolerance"`
+
+	// CalculationInterval determines how often the probabilities are computed
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
+
+	// AggregationBuckets is the number of throughput buckets kept to compute the probabilities
+	AggregationBuckets int `mapstructure:"aggregation_buckets"`
+
+	// BucketsForCalculation is the number of the latest buckets used to compute the weighted throughput
+	BucketsForCalculation int `mapstructure:"buckets_for_calculation"`
+
+	// Delay is how far back the latest throughput bucket is, leaving time for the throughput to be recorded
+	Delay time.Duration `mapstructure:"delay"`
+
+	// InitialSamplingProbability is the probability of the operations without throughput yet
+	InitialSamplingProbability float64 `mapstructure:"initial_sampling_probability"`
+
+	// MinSamplingProbability is the lowest probability given to an operation
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+
+	// MinSamplesPerSecond is the lowest rate of samples per second of each operation
+	MinSamplesPerSecond float64 `mapstructure:"min_samples_per_second"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -58,13 +97,43 @@ func (cfg *Config) Validate() error {
 		return errAtLeastOneProtocol
 	}
 
-	if cfg.Source.File != "" && cfg.Source.Remote != nil {
+	sources := 0
+	if cfg.Source.File != "" {
+		sources++
+	}
+	if cfg.Source.Remote != nil {
+		sources++
+	}
+	if cfg.Source.Adaptive != nil {
+		sources++
+	}
+
+	if sources > 1 {
 		return errTooManySources
 	}
 
-	if cfg.Source.File == "" && cfg.Source.Remote == nil {
+	if sources == 0 {
 		return errNoSources
 	}
 
+	if cfg.Source.Adaptive != nil {
+		return cfg.Source.Adaptive.Validate()
+	}
+
+	return nil
+}
+
+// Validate checks if the adaptive sampling configuration is valid
+func (cfg *AdaptiveConfig) Validate() error {
+	if cfg.TargetSamplesPerSecond < 0 || cfg.DeltaTolerance < 0 || cfg.CalculationInterval < 0 ||
+		cfg.AggregationBuckets < 0 || cfg.BucketsForCalculation < 0 || cfg.Delay < 0 || cfg.MinSamplesPerSecond < 0 {
+		return errNegativeAdaptive
+	}
+
+	if cfg.InitialSamplingProbability < 0 || cfg.InitialSamplingProbability > 1 ||
+		cfg.MinSamplingProbability < 0 || cfg.MinSamplingProbability > 1 {
+		return errProbabilityRange
+	}
+
 	return nil
 }
@@ -31,6 +31,7 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -64,6 +65,23 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "2"),
+			expected: &Config{
+				HTTPServerSettings: &confighttp.HTTPServerSettings{Endpoint: ":5778"},
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{NetAddr: confignet.NetAddr{
+					Endpoint:  ":14250",
+					Transport: "tcp",
+				}},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{
+						StorageID:              &storageID,
+						TargetSamplesPerSecond: 2,
+						CalculationInterval:    30 * time.Second,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -110,6 +128,46 @@ func TestValidate(t *testing.T) {
 			},
 			expected: errTooManySources,
 		},
+		{
+			desc: "adaptive and file sources",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{},
+					File:     "/tmp/some-file",
+				},
+			},
+			expected: errTooManySources,
+		},
+		{
+			desc: "adaptive source",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{},
+				},
+			},
+		},
+		{
+			desc: "negative adaptive settings",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{CalculationInterval: -time.Second},
+				},
+			},
+			expected: errNegativeAdaptive,
+		},
+		{
+			desc: "adaptive probability out of range",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveConfig{InitialSamplingProbability: 1.5},
+				},
+			},
+			expected: errProbabilityRange,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
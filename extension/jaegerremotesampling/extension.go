@@ -32,18 +32,21 @@ var _ extension.Extension = (*jrsExtension)(nil)
 
 type jrsExtension struct {
 	cfg       *Config
+	id        component.ID
 	telemetry component.TelemetrySettings
 
 	httpServer    component.Component
 	grpcServer    component.Component
 	samplingStore strategystore.StrategyStore
+	aggregator    strategystore.Aggregator
 
 	closers []func() error
 }
 
-func newExtension(cfg *Config, telemetry component.TelemetrySettings) *jrsExtension {
+func newExtension(cfg *Config, id component.ID, telemetry component.TelemetrySettings) *jrsExtension {
 	jrse := &jrsExtension{
 		cfg:       cfg,
+		id:        id,
 		telemetry: telemetry,
 	}
 	return jrse
@@ -54,6 +57,7 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	// source of the sampling config:
 	// - remote (gRPC)
 	// - local file
+	// - adaptive, computed from the recorded span throughput
 	// we can then use a simplified logic here to assign the appropriate store
 	if jrse.cfg.Source.File != "" {
 		opts := static.Options{
@@ -82,6 +86,12 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 		})
 	}
 
+	if jrse.cfg.Source.Adaptive != nil {
+		if err := jrse.startAdaptive(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	if jrse.cfg.HTTPServerSettings != nil {
 		httpServer, err := internal.NewHTTP(jrse.telemetry, *jrse.cfg.HTTPServerSettings, jrse.samplingStore)
 		if err != nil {
@@ -20,20 +20,25 @@ import (
 	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestNewExtension(t *testing.T) {
 	// test
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, component.NewID(typeStr), componenttest.NewNopTelemetrySettings())
 
 	// verify
 	assert.NotNil(t, e)
@@ -44,7 +49,7 @@ func TestStartAndShutdownLocalFile(t *testing.T) {
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
 
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, component.NewID(typeStr), componenttest.NewNopTelemetrySettings())
 	require.NotNil(t, e)
 	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -77,7 +82,7 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	}
 
 	// create the extension
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, component.NewID(typeStr), componenttest.NewNopTelemetrySettings())
 	require.NotNil(t, e)
 
 	// test
@@ -85,6 +90,86 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	assert.NoError(t, e.Shutdown(context.Background()))
 }
 
+func TestStartAndShutdownAdaptive(t *testing.T) {
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveConfig{}
+
+	e := newExtension(cfg, component.NewID(typeStr), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+
+	// no throughput was recorded yet, the initial probability is served
+	resp, err := e.samplingStore.GetSamplingStrategy(context.Background(), "svc")
+	require.NoError(t, err)
+	assert.Equal(t, defaultInitialSamplingProbability, resp.OperationSampling.DefaultSamplingProbability)
+
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestAdaptiveProbabilities(t *testing.T) {
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveConfig{
+		CalculationInterval: 20 * time.Millisecond,
+		Delay:               time.Millisecond,
+	}
+
+	e := newExtension(cfg, component.NewID(typeStr), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, e.Shutdown(context.Background())) }()
+
+	// far more spans than the target samples per second are sampled, the probability is lowered
+	td := rootSpans("svc", "op", 100)
+	assert.Eventually(t, func() bool {
+		e.RecordThroughput(td)
+		resp, err := e.samplingStore.GetSamplingStrategy(context.Background(), "svc")
+		require.NoError(t, err)
+		for _, strategy := range resp.OperationSampling.PerOperationStrategies {
+			if strategy.Operation == "op" {
+				return strategy.ProbabilisticSampling.SamplingRate < defaultInitialSamplingProbability
+			}
+		}
+		return false
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestAdaptiveStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("adaptive")
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveConfig{StorageID: &storageID}
+
+	e := newExtension(cfg, component.NewID(typeStr), componenttest.NewNopTelemetrySettings())
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("adaptive")
+	require.NoError(t, e.Start(context.Background(), host))
+	assert.NoError(t, e.Shutdown(context.Background()))
+
+	e = newExtension(cfg, component.NewID(typeStr), componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, e.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'test_storage/adaptive' not found")
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestRecordThroughputWithoutAdaptive(t *testing.T) {
+	cfg := testConfig()
+	cfg.Source.File = filepath.Join("testdata", "strategy.json")
+
+	e := newExtension(cfg, component.NewID(typeStr), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	e.RecordThroughput(rootSpans("svc", "op", 1))
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func rootSpans(service, operation string, count int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", service)
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < count; i++ {
+		span := spans.AppendEmpty()
+		span.SetName(operation)
+		span.Attributes().PutStr("sampler.type", "probabilistic")
+		span.Attributes().PutDouble("sampler.param", defaultInitialSamplingProbability)
+	}
+	return td
+}
+
 type samplingServer struct {
 	api_v2.UnimplementedSamplingManagerServer
 }
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/adaptive"
+
+import (
+	"strconv"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/uber/jaeger-client-go"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// RecordThroughput records the root spans of the traces in the aggregator, as the Jaeger collector does. Only the
+// spans with the `sampler.type` and `sampler.param` attributes set by the Jaeger SDKs are counted.
+func RecordThroughput(aggregator strategystore.Aggregator, td ptrace.Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		serviceName, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+		if !ok || serviceName.Str() == "" {
+			continue
+		}
+
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if !span.ParentSpanID().IsEmpty() || span.Name() == "" {
+					continue
+				}
+
+				samplerType, samplerParam, ok := samplerParams(span.Attributes())
+				if !ok {
+					continue
+				}
+				aggregator.RecordThroughput(serviceName.Str(), span.Name(), samplerType, samplerParam)
+			}
+		}
+	}
+}
+
+func samplerParams(attrs pcommon.Map) (string, float64, bool) {
+	samplerType, ok := attrs.Get(jaeger.SamplerTypeTagKey)
+	if !ok || samplerType.Type() != pcommon.ValueTypeStr {
+		return "", 0, false
+	}
+
+	switch samplerType.Str() {
+	case jaeger.SamplerTypeProbabilistic, jaeger.SamplerTypeLowerBound, jaeger.SamplerTypeRateLimiting:
+	default:
+		return "", 0, false
+	}
+
+	samplerParam, ok := attrs.Get(jaeger.SamplerParamTagKey)
+	if !ok {
+		return "", 0, false
+	}
+
+	switch samplerParam.Type() {
+	case pcommon.ValueTypeDouble:
+		return samplerType.Str(), samplerParam.Double(), true
+	case pcommon.ValueTypeInt:
+		return samplerType.Str(), float64(samplerParam.Int()), true
+	case pcommon.ValueTypeStr:
+		param, err := strconv.ParseFloat(samplerParam.Str(), 64)
+		if err != nil {
+			return "", 0, false
+		}
+		return samplerType.Str(), param, true
+	default:
+		return "", 0, false
+	}
+}
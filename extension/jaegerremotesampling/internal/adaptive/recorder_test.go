@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type recordedThroughput struct {
+	service     string
+	operation   string
+	samplerType string
+	probability float64
+}
+
+type testAggregator struct {
+	recorded []recordedThroughput
+}
+
+func (a *testAggregator) RecordThroughput(service, operation, samplerType string, probability float64) {
+	a.recorded = append(a.recorded, recordedThroughput{service, operation, samplerType, probability})
+}
+
+func (a *testAggregator) Start() {}
+
+func (a *testAggregator) Close() error {
+	return nil
+}
+
+func TestRecordThroughput(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "svc")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	probabilistic := spans.AppendEmpty()
+	probabilistic.SetName("probabilistic")
+	probabilistic.Attributes().PutStr("sampler.type", "probabilistic")
+	probabilistic.Attributes().PutDouble("sampler.param", 0.001)
+
+	lowerBound := spans.AppendEmpty()
+	lowerBound.SetName("lowerbound")
+	lowerBound.Attributes().PutStr("sampler.type", "lowerbound")
+	lowerBound.Attributes().PutStr("sampler.param", "0.5")
+
+	rateLimiting := spans.AppendEmpty()
+	rateLimiting.SetName("ratelimiting")
+	rateLimiting.Attributes().PutStr("sampler.type", "ratelimiting")
+	rateLimiting.Attributes().PutInt("sampler.param", 2)
+
+	child := spans.AppendEmpty()
+	child.SetName("child")
+	child.SetParentSpanID(pcommon.SpanID([8]byte{1}))
+	child.Attributes().PutStr("sampler.type", "probabilistic")
+	child.Attributes().PutDouble("sampler.param", 0.001)
+
+	const unsampled = "unsampled"
+	spans.AppendEmpty().SetName(unsampled)
+
+	constSampler := spans.AppendEmpty()
+	constSampler.SetName("const")
+	constSampler.Attributes().PutStr("sampler.type", "const")
+	constSampler.Attributes().PutBool("sampler.param", true)
+
+	invalidParam := spans.AppendEmpty()
+	invalidParam.SetName("invalid")
+	invalidParam.Attributes().PutStr("sampler.type", "probabilistic")
+	invalidParam.Attributes().PutStr("sampler.param", "high")
+
+	// spans without a service are ignored
+	noService := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	noService.SetName("probabilistic")
+	noService.Attributes().PutStr("sampler.type", "probabilistic")
+	noService.Attributes().PutDouble("sampler.param", 0.001)
+
+	aggregator := &testAggregator{}
+	RecordThroughput(aggregator, td)
+
+	assert.Equal(t, []recordedThroughput{
+		{"svc", "probabilistic", "probabilistic", 0.001},
+		{"svc", "lowerbound", "lowerbound", 0.5},
+		{"svc", "ratelimiting", "ratelimiting", 2},
+	}, aggregator.recorded)
+}
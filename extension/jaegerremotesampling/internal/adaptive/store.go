@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/adaptive"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const (
+	throughputKey    = "throughput"
+	probabilitiesKey = "probabilities"
+)
+
+var _ samplingstore.Store = (*Store)(nil)
+
+// Store keeps the throughput and the latest probabilities of the adaptive sampling strategies in memory.
+// When a storage client is set, the store is saved in it on every insert and loaded from it on start,
+// so that the probabilities survive a restart.
+type Store struct {
+	sync.Mutex
+
+	client    storage.Client
+	retention time.Duration
+	now       func() time.Time
+
+	throughput    []throughputBucket
+	probabilities model.ServiceOperationProbabilities
+}
+
+type throughputBucket struct {
+	Timestamp  time.Time           `json:"timestamp"`
+	Throughput []*model.Throughput `json:"throughput"`
+}
+
+// NewStore creates a store keeping the throughput for the retention duration. The client is optional.
+func NewStore(client storage.Client, retention time.Duration) *Store {
+	return &Store{
+		client:        client,
+		retention:     retention,
+		now:           time.Now,
+		probabilities: make(model.ServiceOperationProbabilities),
+	}
+}
+
+// Load reads the throughput and the probabilities saved in the storage client.
+func (s *Store) Load(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	throughput, err := s.client.Get(ctx, throughputKey)
+	if err != nil {
+		return err
+	}
+	if throughput != nil {
+		if err = json.Unmarshal(throughput, &s.throughput); err != nil {
+			return fmt.Errorf("failed to decode the throughput: %w", err)
+		}
+	}
+
+	probabilities, err := s.client.Get(ctx, probabilitiesKey)
+	if err != nil {
+		return err
+	}
+	if probabilities != nil {
+		if err = json.Unmarshal(probabilities, &s.probabilities); err != nil {
+			return fmt.Errorf("failed to decode the probabilities: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// InsertThroughput implements samplingstore.Store.
+func (s *Store) InsertThroughput(throughput []*model.Throughput) error {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+	s.throughput = append(s.throughput, throughputBucket{Timestamp: now, Throughput: throughput})
+
+	// the buckets are appended in order, the expired ones are at the start
+	expired := 0
+	for expired < len(s.throughput) && now.Sub(s.throughput[expired].Timestamp) > s.retention {
+		expired++
+	}
+	s.throughput = s.throughput[expired:]
+
+	return s.save(throughputKey, s.throughput)
+}
+
+// InsertProbabilitiesAndQPS implements samplingstore.Store.
+func (s *Store) InsertProbabilitiesAndQPS(_ string, probabilities model.ServiceOperationProbabilities, _ model.ServiceOperationQPS) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.probabilities = copyProbabilities(probabilities)
+	return s.save(probabilitiesKey, s.probabilities)
+}
+
+// GetThroughput implements samplingstore.Store.
+func (s *Store) GetThroughput(start, end time.Time) ([]*model.Throughput, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var throughput []*model.Throughput
+	for _, bucket := range s.throughput {
+		if !bucket.Timestamp.Before(start) && bucket.Timestamp.Before(end) {
+			throughput = append(throughput, bucket.Throughput...)
+		}
+	}
+	return throughput, nil
+}
+
+// GetLatestProbabilities implements samplingstore.Store.
+func (s *Store) GetLatestProbabilities() (model.ServiceOperationProbabilities, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return copyProbabilities(s.probabilities), nil
+}
+
+func (s *Store) save(key string, value interface{}) error {
+	if s.client == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(context.Background(), key, data)
+}
+
+func copyProbabilities(probabilities model.ServiceOperationProbabilities) model.ServiceOperationProbabilities {
+	copied := make(model.ServiceOperationProbabilities, len(probabilities))
+	for service, operations := range probabilities {
+		copied[service] = make(map[string]float64, len(operations))
+		for operation, probability := range operations {
+			copied[service][operation] = probability
+		}
+	}
+	return copied
+}
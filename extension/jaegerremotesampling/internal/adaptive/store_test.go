@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adaptive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestStoreThroughput(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := NewStore(nil, time.Minute)
+	s.now = func() time.Time { return now }
+
+	first := &model.Throughput{Service: "svc", Operation: "op", Count: 1, Probabilities: map[string]struct{}{"0.001000": {}}}
+	require.NoError(t, s.InsertThroughput([]*model.Throughput{first}))
+
+	now = now.Add(30 * time.Second)
+	second := &model.Throughput{Service: "svc", Operation: "op", Count: 2, Probabilities: map[string]struct{}{"0.001000": {}}}
+	require.NoError(t, s.InsertThroughput([]*model.Throughput{second}))
+
+	throughput, err := s.GetThroughput(time.Unix(1000, 0), time.Unix(1030, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Throughput{first}, throughput)
+
+	throughput, err = s.GetThroughput(time.Unix(1000, 0), time.Unix(1031, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Throughput{first, second}, throughput)
+
+	// the first bucket is past the retention
+	now = now.Add(45 * time.Second)
+	require.NoError(t, s.InsertThroughput(nil))
+	throughput, err = s.GetThroughput(time.Unix(0, 0), now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Throughput{second}, throughput)
+}
+
+func TestStoreProbabilities(t *testing.T) {
+	s := NewStore(nil, time.Minute)
+
+	probabilities, err := s.GetLatestProbabilities()
+	require.NoError(t, err)
+	assert.Empty(t, probabilities)
+
+	inserted := model.ServiceOperationProbabilities{"svc": {"op": 0.5}}
+	require.NoError(t, s.InsertProbabilitiesAndQPS("host", inserted, nil))
+	inserted["svc"]["op"] = 1
+
+	probabilities, err = s.GetLatestProbabilities()
+	require.NoError(t, err)
+	assert.Equal(t, model.ServiceOperationProbabilities{"svc": {"op": 0.5}}, probabilities)
+}
+
+func TestStorePersistence(t *testing.T) {
+	dir := t.TempDir()
+	id := component.NewID("jaegerremotesampling")
+
+	client := storagetest.NewFileBackedClient(component.KindExtension, id, "", dir)
+	s := NewStore(client, time.Hour)
+	require.NoError(t, s.Load(context.Background()))
+
+	throughput := []*model.Throughput{{Service: "svc", Operation: "op", Count: 3, Probabilities: map[string]struct{}{"0.001000": {}}}}
+	require.NoError(t, s.InsertThroughput(throughput))
+	require.NoError(t, s.InsertProbabilitiesAndQPS("host", model.ServiceOperationProbabilities{"svc": {"op": 0.25}}, nil))
+	require.NoError(t, client.Close(context.Background()))
+
+	// a restarted store reads the saved state
+	client = storagetest.NewFileBackedClient(component.KindExtension, id, "", dir)
+	defer func() { require.NoError(t, client.Close(context.Background())) }()
+	restarted := NewStore(client, time.Hour)
+	require.NoError(t, restarted.Load(context.Background()))
+
+	got, err := restarted.GetThroughput(time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, throughput, got)
+
+	probabilities, err := restarted.GetLatestProbabilities()
+	require.NoError(t, err)
+	assert.Equal(t, model.ServiceOperationProbabilities{"svc": {"op": 0.25}}, probabilities)
+}
+
+func TestStoreLoadInvalid(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindExtension, component.NewID("jaegerremotesampling"), "")
+	require.NoError(t, client.Set(context.Background(), throughputKey, []byte("invalid")))
+
+	s := NewStore(client, time.Hour)
+	assert.ErrorContains(t, s.Load(context.Background()), "failed to decode the throughput")
+}
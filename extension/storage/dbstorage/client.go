@@ -4,7 +4,7 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//     http://www.apache.org/licenses/LICENSE-2.0
+//      http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -18,92 +18,94 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
+	"time"
 
 	// Postgres driver
 	_ "github.com/jackc/pgx/v4/stdlib"
 	// SQLite driver
 	_ "github.com/mattn/go-sqlite3"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/multierr"
 )
 
 const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
-	deleteQueryText = "delete from %s where key=?"
+	getQueryText    = "select value from " + storageTable + " where namespace=? and key=? and (expires_at is null or expires_at>?)"
+	setQueryText    = "insert into " + storageTable + " (namespace, key, value, expires_at) values(?,?,?,?) on conflict(namespace, key) do update set value=excluded.value, expires_at=excluded.expires_at"
+	deleteQueryText = "delete from " + storageTable + " where namespace=? and key=?"
 )
 
+// dbStorageClient stores the values of a component in the shared storage table, under its own namespace.
 type dbStorageClient struct {
 	db          *sql.DB
+	namespace   string
+	ttl         time.Duration
+	now         func() time.Time
 	getQuery    *sql.Stmt
 	setQuery    *sql.Stmt
 	deleteQuery *sql.Stmt
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
+func newClient(ctx context.Context, db *sql.DB, d dialect, namespace string, ttl time.Duration, now func() time.Time) (*dbStorageClient, error) {
+	getQuery, err := db.PrepareContext(ctx, d.rebind(getQueryText))
 	if err != nil {
 		return nil, err
 	}
-
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
+	setQuery, err := db.PrepareContext(ctx, d.rebind(setQueryText))
 	if err != nil {
-		return nil, err
-	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
-	if err != nil {
-		return nil, err
+		return nil, multierr.Append(err, getQuery.Close())
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
+	deleteQuery, err := db.PrepareContext(ctx, d.rebind(deleteQueryText))
 	if err != nil {
-		return nil, err
+		return nil, multierr.Combine(err, getQuery.Close(), setQuery.Close())
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
+	return &dbStorageClient{
+		db:          db,
+		namespace:   namespace,
+		ttl:         ttl,
+		now:         now,
+		getQuery:    getQuery,
+		setQuery:    setQuery,
+		deleteQuery: deleteQuery,
+	}, nil
 }
 
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-	if !rows.Next() {
-		return nil, nil
-	}
-	var result []byte
-	err = rows.Scan(&result)
-	if err != nil {
-		return result, err
-	}
-	err = rows.Close()
-	return result, err
+	return c.get(ctx, c.getQuery, key)
 }
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
-	return err
+	return c.set(ctx, c.setQuery, key, value)
 }
 
 // Delete will delete data associated with the specified key
 func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
-	_, err := c.deleteQuery.ExecContext(ctx, key)
-	return err
+	return c.delete(ctx, c.deleteQuery, key)
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
+// Batch executes the specified operations in order, in a single transaction. Get operation results are updated in place
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
-	var err error
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	getQuery := tx.StmtContext(ctx, c.getQuery)
+	setQuery := tx.StmtContext(ctx, c.setQuery)
+	deleteQuery := tx.StmtContext(ctx, c.deleteQuery)
+
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = c.get(ctx, getQuery, op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			err = c.set(ctx, setQuery, op.Key, op.Value)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			err = c.delete(ctx, deleteQuery, op.Key)
 		default:
 			return errors.New("wrong operation type")
 		}
@@ -112,19 +114,37 @@ func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) e
 			return err
 		}
 	}
-	return err
+	return tx.Commit()
 }
 
 // Close will close the database
 func (c *dbStorageClient) Close(_ context.Context) error {
-	if err := c.setQuery.Close(); err != nil {
-		return err
-	}
-	if err := c.deleteQuery.Close(); err != nil {
-		return err
+	return multierr.Combine(
+		c.setQuery.Close(),
+		c.deleteQuery.Close(),
+		c.getQuery.Close(),
+	)
+}
+
+func (c *dbStorageClient) get(ctx context.Context, query *sql.Stmt, key string) ([]byte, error) {
+	var result []byte
+	err := query.QueryRowContext(ctx, c.namespace, key, c.now().UnixNano()).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
 	}
-	if err := c.getQuery.Close(); err != nil {
-		return err
+	return result, err
+}
+
+func (c *dbStorageClient) set(ctx context.Context, query *sql.Stmt, key string, value []byte) error {
+	var expiresAt sql.NullInt64
+	if c.ttl > 0 {
+		expiresAt = sql.NullInt64{Int64: c.now().Add(c.ttl).UnixNano(), Valid: true}
 	}
-	return nil
+	_, err := query.ExecContext(ctx, c.namespace, key, value, expiresAt)
+	return err
+}
+
+func (c *dbStorageClient) delete(ctx context.Context, query *sql.Stmt, key string) error {
+	_, err := query.ExecContext(ctx, c.namespace, key)
+	return err
 }
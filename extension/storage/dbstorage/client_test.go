@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Skip tests on Windows temporarily, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/11451
+//go:build !windows
+// +build !windows
+
+package dbstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Unix(1000, 0)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	require.NoError(t, migrate(context.Background(), db, sqliteDialect))
+	return db
+}
+
+func newTestClient(t *testing.T, db *sql.DB, namespace string, ttl time.Duration, clock *testClock) *dbStorageClient {
+	client, err := newClient(context.Background(), db, sqliteDialect, namespace, ttl, clock.Now)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+	return client
+}
+
+func TestClientNamespaces(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	clock := newTestClock()
+	first := newTestClient(t, db, "first", 0, clock)
+	second := newTestClient(t, db, "second", 0, clock)
+
+	require.NoError(t, first.Set(ctx, "key", []byte("first")))
+	require.NoError(t, second.Set(ctx, "key", []byte("second")))
+
+	value, err := first.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), value)
+
+	require.NoError(t, second.Delete(ctx, "key"))
+	value, err = second.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	value, err = first.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), value)
+}
+
+func TestClientTTL(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	clock := newTestClock()
+	client := newTestClient(t, db, "ttl", time.Minute, clock)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	clock.Advance(59 * time.Second)
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// setting the value again extends its expiry
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	clock.Advance(59 * time.Second)
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	clock.Advance(time.Second)
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// values set without ttl never expire
+	noTTL := newTestClient(t, db, "no_ttl", 0, clock)
+	require.NoError(t, noTTL.Set(ctx, "key", []byte("value")))
+	clock.Advance(24 * time.Hour)
+	value, err = noTTL.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	clock := newTestClock()
+	client := newTestClient(t, db, "batch", 0, clock)
+
+	require.NoError(t, client.Set(ctx, "c", []byte("c")))
+
+	getA := storage.GetOperation("a")
+	getC := storage.GetOperation("c")
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("a", []byte("a")),
+		storage.SetOperation("b", []byte("b")),
+		getA,
+		storage.DeleteOperation("b"),
+		getC,
+		storage.DeleteOperation("c"),
+	))
+	assert.Equal(t, []byte("a"), getA.Value)
+	assert.Equal(t, []byte("c"), getC.Value)
+
+	for key, expected := range map[string][]byte{"a": []byte("a"), "b": nil, "c": nil} {
+		value, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, value, key)
+	}
+
+	// the operations of a failed batch are rolled back
+	invalid := storage.GetOperation("a")
+	invalid.Type = 42
+	err := client.Batch(ctx,
+		storage.SetOperation("d", []byte("d")),
+		invalid,
+	)
+	require.Error(t, err)
+	value, err := client.Get(ctx, "d")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
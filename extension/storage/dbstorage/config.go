@@ -16,12 +16,37 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+
+	// NamespacePrefix is prepended to the namespaces of the components, so that several collectors sharing
+	// the same database keep their values apart. It is typically set to an ID of the collector instance.
+	NamespacePrefix string `mapstructure:"namespace_prefix,omitempty"`
+
+	// TTLs lists the storage clients whose values expire. The values of the other clients are kept forever.
+	TTLs []TTLConfig `mapstructure:"ttls,omitempty"`
+	// CleanupInterval specifies how often the expired values are deleted from the database when TTLs are set
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval,omitempty"`
+}
+
+// TTLConfig specifies how long the values of the storage clients of a component are kept after being set.
+type TTLConfig struct {
+	// Kind is the kind of the component: receiver, processor, exporter or extension.
+	Kind string `mapstructure:"kind"`
+	// Component is the ID of the component.
+	Component component.ID `mapstructure:"component"`
+	// Name is the name of the storage client of the component. All the clients of the component match when empty.
+	Name string `mapstructure:"name,omitempty"`
+	// TTL specifies how long the values are kept after being set.
+	TTL time.Duration `mapstructure:"ttl"`
 }
 
 func (cfg *Config) Validate() error {
@@ -32,5 +57,49 @@ func (cfg *Config) Validate() error {
 		return errors.New("missing driver name")
 	}
 
+	type client struct {
+		kind      string
+		component component.ID
+		name      string
+	}
+	clients := make(map[client]struct{}, len(cfg.TTLs))
+	for _, ttl := range cfg.TTLs {
+		switch ttl.Kind {
+		case "receiver", "processor", "exporter", "extension":
+		default:
+			return fmt.Errorf("invalid kind %q of the ttl of component %s", ttl.Kind, ttl.Component)
+		}
+		if ttl.TTL <= 0 {
+			return fmt.Errorf("ttl of %s %s must be positive", ttl.Kind, ttl.Component)
+		}
+		c := client{kind: ttl.Kind, component: ttl.Component, name: ttl.Name}
+		if _, ok := clients[c]; ok {
+			return fmt.Errorf("duplicate ttl of %s %s", ttl.Kind, ttl.Component)
+		}
+		clients[c] = struct{}{}
+	}
+	if len(cfg.TTLs) > 0 && cfg.CleanupInterval <= 0 {
+		return errors.New("cleanup interval must be positive when ttls are set")
+	}
+
 	return nil
 }
+
+// clientTTL returns the TTL of the values of the given storage client, zero when they never expire. A TTL
+// configured for the name of the client takes precedence over the one configured for all the clients of the
+// component.
+func (cfg *Config) clientTTL(kind string, ent component.ID, name string) time.Duration {
+	var ttl time.Duration
+	for _, c := range cfg.TTLs {
+		if c.Kind != kind || c.Component != ent {
+			continue
+		}
+		if c.Name == name {
+			return c.TTL
+		}
+		if c.Name == "" {
+			ttl = c.TTL
+		}
+	}
+	return ttl
+}
@@ -21,8 +21,10 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
 )
 
 func TestConfigValidate(t *testing.T) {
@@ -46,6 +48,44 @@ func TestConfigValidate(t *testing.T) {
 			Config{DriverName: "foo", DataSource: "bar"},
 			nil,
 		},
+		{
+			"Invalid ttl kind",
+			Config{DriverName: "foo", DataSource: "bar", CleanupInterval: time.Minute, TTLs: []TTLConfig{
+				{Kind: "connector", Component: component.NewID("nop"), TTL: time.Hour},
+			}},
+			errors.New(`invalid kind "connector" of the ttl of component nop`),
+		},
+		{
+			"Negative ttl",
+			Config{DriverName: "foo", DataSource: "bar", CleanupInterval: time.Minute, TTLs: []TTLConfig{
+				{Kind: "receiver", Component: component.NewID("nop"), TTL: -time.Second},
+			}},
+			errors.New("ttl of receiver nop must be positive"),
+		},
+		{
+			"Duplicate ttl",
+			Config{DriverName: "foo", DataSource: "bar", CleanupInterval: time.Minute, TTLs: []TTLConfig{
+				{Kind: "receiver", Component: component.NewID("nop"), TTL: time.Hour},
+				{Kind: "receiver", Component: component.NewID("nop"), TTL: time.Minute},
+			}},
+			errors.New("duplicate ttl of receiver nop"),
+		},
+		{
+			"Missing cleanup interval",
+			Config{DriverName: "foo", DataSource: "bar", TTLs: []TTLConfig{
+				{Kind: "receiver", Component: component.NewID("nop"), TTL: time.Hour},
+			}},
+			errors.New("cleanup interval must be positive when ttls are set"),
+		},
+		{
+			"valid with ttls",
+			Config{DriverName: "foo", DataSource: "bar", CleanupInterval: time.Minute, TTLs: []TTLConfig{
+				{Kind: "receiver", Component: component.NewID("nop"), TTL: time.Hour},
+				{Kind: "receiver", Component: component.NewID("nop"), Name: "client", TTL: time.Minute},
+				{Kind: "exporter", Component: component.NewID("nop"), TTL: time.Hour},
+			}},
+			nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -57,3 +97,29 @@ func TestConfigValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, "sqlite3", cfg.DriverName)
+	assert.Equal(t, getDefaultDataSource(), cfg.DataSource)
+	assert.Empty(t, cfg.NamespacePrefix)
+	assert.Empty(t, cfg.TTLs)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfigClientTTL(t *testing.T) {
+	cfg := &Config{
+		TTLs: []TTLConfig{
+			{Kind: "exporter", Component: component.NewID("otlp"), Name: "traces", TTL: time.Minute},
+			{Kind: "exporter", Component: component.NewID("otlp"), TTL: time.Hour},
+			{Kind: "receiver", Component: component.NewIDWithName("filelog", "app"), TTL: 24 * time.Hour},
+		},
+	}
+
+	assert.Equal(t, time.Minute, cfg.clientTTL("exporter", component.NewID("otlp"), "traces"))
+	assert.Equal(t, time.Hour, cfg.clientTTL("exporter", component.NewID("otlp"), "logs"))
+	assert.Equal(t, time.Hour, cfg.clientTTL("exporter", component.NewID("otlp"), ""))
+	assert.Equal(t, 24*time.Hour, cfg.clientTTL("receiver", component.NewIDWithName("filelog", "app"), ""))
+	assert.Zero(t, cfg.clientTTL("receiver", component.NewID("filelog"), ""))
+	assert.Zero(t, cfg.clientTTL("receiver", component.NewID("otlp"), ""))
+}
@@ -19,6 +19,8 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -26,11 +28,17 @@ import (
 	"go.uber.org/zap"
 )
 
+const cleanupQueryText = "delete from " + storageTable + " where expires_at is not null and expires_at<=?"
+
 type databaseStorage struct {
-	driverName     string
-	datasourceName string
-	logger         *zap.Logger
-	db             *sql.DB
+	config  *Config
+	logger  *zap.Logger
+	db      *sql.DB
+	dialect dialect
+	now     func() time.Time
+
+	cancel       context.CancelFunc
+	cleanupGroup sync.WaitGroup
 }
 
 // Ensure this storage extension implements the appropriate interface
@@ -38,28 +46,42 @@ var _ storage.Extension = (*databaseStorage)(nil)
 
 func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
 	return &databaseStorage{
-		driverName:     config.DriverName,
-		datasourceName: config.DataSource,
-		logger:         logger,
+		config:  config,
+		logger:  logger,
+		dialect: dialectFor(config.DriverName),
+		now:     time.Now,
 	}, nil
 }
 
-// Start opens a connection to the database
-func (ds *databaseStorage) Start(context.Context, component.Host) error {
-	db, err := sql.Open(ds.driverName, ds.datasourceName)
+// Start opens a connection to the database, migrates its schema and starts deleting the expired values
+func (ds *databaseStorage) Start(ctx context.Context, _ component.Host) error {
+	db, err := sql.Open(ds.config.DriverName, ds.config.DataSource)
 	if err != nil {
 		return err
 	}
 
-	if err := db.Ping(); err != nil {
+	if err = db.PingContext(ctx); err != nil {
+		_ = db.Close()
 		return err
 	}
+	if err = migrate(ctx, db, ds.dialect); err != nil {
+		_ = db.Close()
+		return fmt.Errorf("failed to migrate the database schema: %w", err)
+	}
 	ds.db = db
+
+	if len(ds.config.TTLs) > 0 {
+		ds.startCleanupLoop()
+	}
 	return nil
 }
 
-// Shutdown closes the connection to the database
+// Shutdown stops deleting the expired values and closes the connection to the database
 func (ds *databaseStorage) Shutdown(context.Context) error {
+	if ds.cancel != nil {
+		ds.cancel()
+		ds.cleanupGroup.Wait()
+	}
 	if ds.db == nil {
 		return nil
 	}
@@ -75,7 +97,60 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+
+	namespace := fullName
+	if ds.config.NamespacePrefix != "" {
+		namespace = ds.config.NamespacePrefix + "/" + fullName
+	}
+
+	// the legacy tables were named after the component, regardless of the namespace prefix
+	migrated, err := migrateLegacyTable(ctx, ds.db, ds.dialect, fullName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate the values of table %s: %w", fullName, err)
+	}
+	if migrated {
+		ds.logger.Info("migrated the values of the component to the shared storage table", zap.String("namespace", namespace))
+	}
+
+	ttl := ds.config.clientTTL(kindString(kind), ent, name)
+	return newClient(ctx, ds.db, ds.dialect, namespace, ttl, ds.now)
+}
+
+// startCleanupLoop periodically deletes the expired values of all the components
+func (ds *databaseStorage) startCleanupLoop() {
+	var ctx context.Context
+	ctx, ds.cancel = context.WithCancel(context.Background())
+
+	ds.cleanupGroup.Add(1)
+	go func() {
+		defer ds.cleanupGroup.Done()
+
+		ticker := time.NewTicker(ds.config.CleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				deleted, err := ds.deleteExpired(ctx)
+				if err != nil {
+					ds.logger.Error("failed to delete the expired values", zap.Error(err))
+					continue
+				}
+				ds.logger.Debug("deleted the expired values", zap.Int64("deleted", deleted))
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// deleteExpired deletes the expired values and returns how many were deleted
+func (ds *databaseStorage) deleteExpired(ctx context.Context) (int64, error) {
+	result, err := ds.db.ExecContext(ctx, ds.dialect.rebind(cleanupQueryText), ds.now().UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 func kindString(k component.Kind) string {
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -112,6 +113,101 @@ func TestExtensionIntegrity(t *testing.T) {
 	wg.Wait()
 }
 
+func TestExtensionDeletesExpiredValues(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.DataSource = fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir())
+	cfg.TTLs = []TTLConfig{{Kind: "processor", Component: newTestEntity("ttl"), TTL: time.Minute}}
+	cfg.CleanupInterval = 10 * time.Millisecond
+
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	ds := extension.(*databaseStorage)
+	clock := newTestClock()
+	ds.now = clock.Now
+	require.NoError(t, ds.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, ds.Shutdown(ctx))
+	}()
+
+	client, err := ds.GetClient(ctx, component.KindProcessor, newTestEntity("ttl"), "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, client.Close(ctx))
+	}()
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+
+	// the values of the components without ttl never expire
+	noTTL, err := ds.GetClient(ctx, component.KindProcessor, newTestEntity("no_ttl"), "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, noTTL.Close(ctx))
+	}()
+	require.NoError(t, noTTL.Set(ctx, "key", []byte("value")))
+
+	countRows := func() int {
+		var count int
+		require.NoError(t, ds.db.QueryRowContext(ctx, "select count(*) from "+storageTable).Scan(&count))
+		return count
+	}
+	assert.Equal(t, 2, countRows())
+
+	deleted, err := ds.deleteExpired(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	clock.Advance(time.Minute)
+	assert.Eventually(t, func() bool {
+		return countRows() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	value, err := noTTL.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestExtensionNamespacePrefix(t *testing.T) {
+	ctx := context.Background()
+	dataSource := fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir())
+
+	// collectors sharing the database run the same component
+	getClient := func(prefix string) storage.Client {
+		f := NewFactory()
+		cfg := f.CreateDefaultConfig().(*Config)
+		cfg.DataSource = dataSource
+		cfg.NamespacePrefix = prefix
+
+		extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+		require.NoError(t, err)
+		require.NoError(t, extension.Start(ctx, componenttest.NewNopHost()))
+		t.Cleanup(func() {
+			assert.NoError(t, extension.Shutdown(ctx))
+		})
+
+		client, err := extension.(storage.Extension).GetClient(ctx, component.KindReceiver, newTestEntity("shared"), "")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			assert.NoError(t, client.Close(ctx))
+		})
+		return client
+	}
+
+	clients := map[string]storage.Client{
+		"":           getClient(""),
+		"instance-1": getClient("instance-1"),
+		"instance-2": getClient("instance-2"),
+	}
+	for prefix, client := range clients {
+		require.NoError(t, client.Set(ctx, "key", []byte(prefix)))
+	}
+	for prefix, client := range clients {
+		value, err := client.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte(prefix), value)
+	}
+}
+
 func newTestExtension(t *testing.T) storage.Extension {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
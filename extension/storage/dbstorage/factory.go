@@ -16,6 +16,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -24,6 +25,11 @@ import (
 // The value of extension "type" in configuration.
 const typeStr component.Type = "db_storage"
 
+const (
+	defaultDriverName      = "sqlite3"
+	defaultCleanupInterval = time.Minute
+)
+
 // NewFactory creates a factory for DBStorage extension.
 func NewFactory() extension.Factory {
 	return extension.NewFactory(
@@ -35,7 +41,11 @@ func NewFactory() extension.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		DriverName:      defaultDriverName,
+		DataSource:      getDefaultDataSource(),
+		CleanupInterval: defaultCleanupInterval,
+	}
 }
 
 func createExtension(
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	storageTable       = "otelcol_storage"
+	schemaVersionTable = "otelcol_storage_schema_version"
+)
+
+// dialect holds the differences between the supported databases.
+type dialect struct {
+	// blobType is the column type of the stored values
+	blobType string
+	// numberedPlaceholders tells whether the placeholders are $1, $2... instead of ?
+	numberedPlaceholders bool
+	// tableExistsQuery counts the tables with the given name
+	tableExistsQuery string
+}
+
+var (
+	sqliteDialect = dialect{
+		blobType:         "blob",
+		tableExistsQuery: "select count(*) from sqlite_master where type='table' and name=?",
+	}
+	postgresDialect = dialect{
+		blobType:             "bytea",
+		numberedPlaceholders: true,
+		tableExistsQuery:     "select count(*) from information_schema.tables where table_schema=current_schema() and table_name=?",
+	}
+)
+
+func dialectFor(driverName string) dialect {
+	switch driverName {
+	case "pgx", "postgres":
+		return postgresDialect
+	default:
+		return sqliteDialect
+	}
+}
+
+// rebind rewrites the ? placeholders of the query in the format expected by the database.
+func (d dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			sb.WriteRune(r)
+			continue
+		}
+		n++
+		sb.WriteByte('$')
+		sb.WriteString(strconv.Itoa(n))
+	}
+	return sb.String()
+}
+
+// migrations are the statements creating the schema, applied in order. The schema version is the
+// number of migrations applied, so existing migrations must never be changed or reordered: changes
+// to the schema must be made by adding new migrations at the end of the list.
+var migrations = []func(d dialect) string{
+	func(d dialect) string {
+		return "create table if not exists " + storageTable + " (" +
+			"namespace text not null, " +
+			"key text not null, " +
+			"value " + d.blobType + ", " +
+			"expires_at bigint, " +
+			"primary key (namespace, key))"
+	},
+	func(d dialect) string {
+		return "create index if not exists " + storageTable + "_expires_at on " + storageTable + " (expires_at)"
+	},
+}
+
+// migrate applies the migrations not applied yet to the database.
+func migrate(ctx context.Context, db *sql.DB, d dialect) error {
+	if _, err := db.ExecContext(ctx, "create table if not exists "+schemaVersionTable+" (version integer not null)"); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var version int
+	if err = tx.QueryRowContext(ctx, "select coalesce(max(version), 0) from "+schemaVersionTable).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than the latest known version %d", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		if _, err = tx.ExecContext(ctx, migrations[i](d)); err != nil {
+			return fmt.Errorf("failed to migrate the database schema to version %d: %w", i+1, err)
+		}
+	}
+	if version < len(migrations) {
+		if _, err = tx.ExecContext(ctx, "delete from "+schemaVersionTable); err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, d.rebind("insert into "+schemaVersionTable+" (version) values (?)"), len(migrations)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// migrateLegacyTable moves the values of the table used by a component before the shared
+// storage table was introduced to its namespace, and drops the table.
+func migrateLegacyTable(ctx context.Context, db *sql.DB, d dialect, table string, namespace string) (bool, error) {
+	var count int
+	if err := db.QueryRowContext(ctx, d.rebind(d.tableExistsQuery), table).Scan(&count); err != nil {
+		return false, err
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	insert := d.rebind("insert into " + storageTable + " (namespace, key, value) select ?, key, value from " + table +
+		// the where clause avoids the parsing ambiguity of the on conflict clause in sqlite
+		" where true on conflict (namespace, key) do nothing")
+	if _, err = tx.ExecContext(ctx, insert, namespace); err != nil {
+		return false, err
+	}
+	if _, err = tx.ExecContext(ctx, "drop table "+table); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Skip tests on Windows temporarily, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/11451
+//go:build !windows
+// +build !windows
+
+package dbstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebind(t *testing.T) {
+	query := "select value from t where a=? and b=?"
+	assert.Equal(t, query, sqliteDialect.rebind(query))
+	assert.Equal(t, "select value from t where a=$1 and b=$2", postgresDialect.rebind(query))
+	assert.Equal(t, postgresDialect, dialectFor("pgx"))
+	assert.Equal(t, sqliteDialect, dialectFor("sqlite3"))
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// migrating again is a no-op
+	require.NoError(t, migrate(ctx, db, sqliteDialect))
+
+	var version int
+	require.NoError(t, db.QueryRowContext(ctx, "select version from "+schemaVersionTable).Scan(&version))
+	assert.Equal(t, len(migrations), version)
+
+	// a schema migrated by a newer version isn't supported
+	_, err := db.ExecContext(ctx, "update "+schemaVersionTable+" set version=?", len(migrations)+1)
+	require.NoError(t, err)
+	assert.Error(t, migrate(ctx, db, sqliteDialect))
+}
+
+func TestMigrateLegacyTable(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	clock := newTestClock()
+
+	const namespace = "receiver_nop_legacy"
+	_, err := db.ExecContext(ctx, "create table "+namespace+" (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "insert into "+namespace+" (key, value) values ('a', 'legacy_a'), ('b', 'legacy_b')")
+	require.NoError(t, err)
+
+	// values already in the shared table are kept
+	client := newTestClient(t, db, namespace, 0, clock)
+	require.NoError(t, client.Set(ctx, "b", []byte("b")))
+
+	migrated, err := migrateLegacyTable(ctx, db, sqliteDialect, namespace, namespace)
+	require.NoError(t, err)
+	assert.True(t, migrated)
+
+	value, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("legacy_a"), value)
+	value, err = client.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), value)
+
+	// the legacy table was dropped
+	migrated, err = migrateLegacyTable(ctx, db, sqliteDialect, namespace, namespace)
+	require.NoError(t, err)
+	assert.False(t, migrated)
+}
@@ -43,6 +43,7 @@ type fileStorageClient struct {
 	compactionMutex sync.RWMutex
 	db              *bbolt.DB
 	compactionCfg   *CompactionConfig
+	encryptor       *encryptor
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
@@ -57,7 +58,7 @@ func bboltOptions(timeout time.Duration) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, encryptor *encryptor) (*fileStorageClient, error) {
 	options := bboltOptions(timeout)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -73,7 +74,7 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, encryptor: encryptor, openTimeout: timeout}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -115,16 +116,29 @@ func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation)
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value == nil:
+					op.Value = nil
+				case c.encryptor != nil:
+					// reading the value already makes a copy of it
+					op.Value, err = c.encryptor.read([]byte(op.Key), value)
+					if err != nil {
+						err = fmt.Errorf("failed to decrypt value of key %q: %w", op.Key, err)
+					}
+				default:
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
-					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.encryptor != nil {
+					if value, err = c.encryptor.encrypt([]byte(op.Key), op.Value); err != nil {
+						return err
+					}
+				}
+				err = bucket.Put([]byte(op.Key), value)
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
@@ -208,6 +222,23 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 		return err
 	}
 
+	// re-encrypt the values encrypted with a previous key, or not encrypted yet, before replacing the current db
+	if c.encryptor != nil {
+		rotated, skipped, rotateErr := c.encryptor.rotate(compactedDb, maxTransactionSize)
+		if rotateErr != nil {
+			compactedDb.Close()
+			return fmt.Errorf("failed to re-encrypt values during compaction: %w", rotateErr)
+		}
+		if skipped > 0 {
+			c.logger.Warn("values that could not be decrypted were left as they are during compaction",
+				zap.String(directoryKey, c.db.Path()),
+				zap.Int("skipped", skipped))
+		}
+		c.logger.Debug("re-encrypted values during compaction",
+			zap.String(directoryKey, c.db.Path()),
+			zap.Int("rotated", rotated))
+	}
+
 	dbPath := c.db.Path()
 	compactedDbPath := compactedDb.Path()
 
@@ -28,6 +28,31 @@ type Config struct {
 	Timeout   time.Duration `mapstructure:"timeout,omitempty"`
 
 	Compaction *CompactionConfig `mapstructure:"compaction,omitempty"`
+
+	// Encryption enables the encryption of the stored values when set
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+}
+
+// EncryptionConfig defines configuration for optional encryption of the stored values with AES-GCM.
+type EncryptionConfig struct {
+	// Key is the key used to encrypt the values
+	Key KeyConfig `mapstructure:"key"`
+	// PreviousKeys are the keys used before the current one. The values encrypted with them can still
+	// be read, and are re-encrypted with the current key during compaction
+	PreviousKeys []KeyConfig `mapstructure:"previous_keys,omitempty"`
+	// MigratePlaintext treats the values that aren't in the encrypted format as plain text, so that encryption
+	// can be enabled on existing files: these values can be read, and are encrypted during compaction. It must
+	// only be set until a compaction has run
+	MigratePlaintext bool `mapstructure:"migrate_plaintext,omitempty"`
+}
+
+// KeyConfig defines where a base64 encoded AES key of 16, 24 or 32 bytes is loaded from.
+// Exactly one of File and Env must be set.
+type KeyConfig struct {
+	// File is the path of a file containing the key
+	File string `mapstructure:"file,omitempty"`
+	// Env is the name of an environment variable containing the key
+	Env string `mapstructure:"env,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -87,5 +112,23 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Encryption != nil {
+		if err := cfg.Encryption.Key.validate(); err != nil {
+			return fmt.Errorf("invalid encryption key: %w", err)
+		}
+		for i, key := range cfg.Encryption.PreviousKeys {
+			if err := key.validate(); err != nil {
+				return fmt.Errorf("invalid previous encryption key %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cfg KeyConfig) validate() error {
+	if (cfg.File == "") == (cfg.Env == "") {
+		return errors.New("exactly one of file and env must be set")
+	}
 	return nil
 }
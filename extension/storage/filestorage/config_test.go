@@ -58,6 +58,19 @@ func TestLoadConfig(t *testing.T) {
 				Timeout: 2 * time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "encryption"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig()
+				ret.(*Config).Directory = "."
+				ret.(*Config).Encryption = &EncryptionConfig{
+					Key:              KeyConfig{File: "/etc/otelcol/file_storage.key"},
+					PreviousKeys:     []KeyConfig{{Env: "FILE_STORAGE_PREVIOUS_KEY"}},
+					MigratePlaintext: true,
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -102,3 +115,18 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestHandleInvalidEncryptionKeyWithAnError(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+
+	cfg.Encryption = &EncryptionConfig{}
+	require.EqualError(t, component.ValidateConfig(cfg), "invalid encryption key: exactly one of file and env must be set")
+
+	cfg.Encryption = &EncryptionConfig{
+		Key:          KeyConfig{Env: "KEY"},
+		PreviousKeys: []KeyConfig{{File: "key", Env: "KEY"}},
+	}
+	require.EqualError(t, component.ValidateConfig(cfg), "invalid previous encryption key 0: exactly one of file and env must be set")
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+// encryptedValueVersion is the first byte of every encrypted value, followed by
+// the ID of the key, the nonce and the sealed value.
+const (
+	encryptedValueVersion byte = 1
+	keyIDSize                  = 4
+)
+
+var (
+	errUnknownKey     = errors.New("value encrypted with an unknown key")
+	errInvalidValue   = errors.New("value is not encrypted")
+	errInvalidKeySize = errors.New("encryption key must be 16, 24 or 32 bytes long")
+)
+
+type encryptionKey struct {
+	id   []byte
+	aead cipher.AEAD
+}
+
+// encryptor encrypts the values with AES-GCM using the current key, and decrypts
+// the values encrypted either with the current key or with one of the previous ones.
+type encryptor struct {
+	current  *encryptionKey
+	previous []*encryptionKey
+	// migratePlaintext tells whether the values not encrypted with a known key are plain text
+	migratePlaintext bool
+}
+
+func newEncryptor(cfg *EncryptionConfig) (*encryptor, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	current, err := loadKey(cfg.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption key: %w", err)
+	}
+
+	e := &encryptor{current: current, migratePlaintext: cfg.MigratePlaintext}
+	for i, keyCfg := range cfg.PreviousKeys {
+		key, err := loadKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load previous encryption key %d: %w", i, err)
+		}
+		e.previous = append(e.previous, key)
+	}
+	return e, nil
+}
+
+// loadKey reads the base64-encoded key from the file or the environment variable.
+func loadKey(cfg KeyConfig) (*encryptionKey, error) {
+	var encoded string
+	if cfg.File != "" {
+		content, err := os.ReadFile(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(content)
+	} else {
+		var ok bool
+		if encoded, ok = os.LookupEnv(cfg.Env); !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", cfg.Env)
+		}
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64 encoded: %w", err)
+	}
+	return newEncryptionKey(raw)
+}
+
+func newEncryptionKey(raw []byte) (*encryptionKey, error) {
+	switch len(raw) {
+	case 16, 24, 32:
+	default:
+		return nil, errInvalidKeySize
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(raw)
+	return &encryptionKey{id: sum[:keyIDSize], aead: aead}, nil
+}
+
+// encrypt seals the value with the current key, authenticating the storage key along with it
+// so that values can't be swapped between keys.
+func (e *encryptor) encrypt(key, value []byte) ([]byte, error) {
+	aead := e.current.aead
+	headerSize := 1 + keyIDSize + aead.NonceSize()
+
+	result := make([]byte, headerSize, headerSize+len(value)+aead.Overhead())
+	result[0] = encryptedValueVersion
+	copy(result[1:], e.current.id)
+	nonce := result[1+keyIDSize : headerSize]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(result, nonce, value, key), nil
+}
+
+// decrypt opens a value sealed by encrypt with the current key or one of the previous ones.
+func (e *encryptor) decrypt(key, value []byte) ([]byte, error) {
+	if len(value) < 1+keyIDSize || value[0] != encryptedValueVersion {
+		return nil, errInvalidValue
+	}
+
+	k := e.keyFor(value[1 : 1+keyIDSize])
+	if k == nil {
+		return nil, errUnknownKey
+	}
+
+	nonceSize := k.aead.NonceSize()
+	if len(value) < 1+keyIDSize+nonceSize {
+		return nil, errInvalidValue
+	}
+	nonce := value[1+keyIDSize : 1+keyIDSize+nonceSize]
+	return k.aead.Open(nil, nonce, value[1+keyIDSize+nonceSize:], key)
+}
+
+// read returns the plain text of a stored value. When migrating plain text
+// values, the values not in the encrypted format are returned as they are. The
+// values encrypted with an unknown key are still an error, so that they don't
+// get encrypted again if the key was dropped by mistake.
+func (e *encryptor) read(key, value []byte) ([]byte, error) {
+	plain, err := e.decrypt(key, value)
+	if err != nil && e.migratePlaintext && errors.Is(err, errInvalidValue) {
+		return append([]byte(nil), value...), nil
+	}
+	return plain, err
+}
+
+func (e *encryptor) keyFor(id []byte) *encryptionKey {
+	if bytes.Equal(id, e.current.id) {
+		return e.current
+	}
+	for _, k := range e.previous {
+		if bytes.Equal(id, k.id) {
+			return k
+		}
+	}
+	return nil
+}
+
+// needsRotation tells whether the value was encrypted with a key other than the current one.
+func (e *encryptor) needsRotation(value []byte) bool {
+	return len(value) < 1+keyIDSize || !bytes.Equal(value[1:1+keyIDSize], e.current.id)
+}
+
+// rotate re-encrypts with the current key all the values of the db encrypted with
+// a previous key, or not encrypted when migrating plain text values, using
+// transactions of at most maxTransactionSize values.
+// It returns the number of values re-encrypted, and the number of values left
+// as they are because they couldn't be decrypted.
+func (e *encryptor) rotate(db *bbolt.DB, maxTransactionSize int64) (rotated int, skipped int, err error) {
+	var next []byte
+	for done := false; !done; {
+		err = db.Update(func(tx *bbolt.Tx) error {
+			bucket := tx.Bucket(defaultBucket)
+			if bucket == nil {
+				done = true
+				return nil
+			}
+
+			var keys, values [][]byte
+			cursor := bucket.Cursor()
+			k, v := cursor.First()
+			if next != nil {
+				k, v = cursor.Seek(next)
+			}
+			for ; k != nil; k, v = cursor.Next() {
+				if maxTransactionSize > 0 && int64(len(keys)) >= maxTransactionSize {
+					next = append([]byte(nil), k...)
+					break
+				}
+				if !e.needsRotation(v) {
+					continue
+				}
+
+				plain, err := e.read(k, v)
+				if err != nil {
+					skipped++
+					continue
+				}
+				sealed, err := e.encrypt(k, plain)
+				if err != nil {
+					return err
+				}
+				keys = append(keys, append([]byte(nil), k...))
+				values = append(values, sealed)
+			}
+			done = k == nil
+
+			for i := range keys {
+				if err := bucket.Put(keys[i], values[i]); err != nil {
+					return err
+				}
+			}
+			rotated += len(keys)
+			return nil
+		})
+		if err != nil {
+			return rotated, skipped, err
+		}
+	}
+	return rotated, skipped, nil
+}
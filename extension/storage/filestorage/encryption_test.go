@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.uber.org/zap"
+)
+
+func newTestEncryptor(t *testing.T, current byte, previous ...byte) *encryptor {
+	key, err := newEncryptionKey(bytes.Repeat([]byte{current}, 32))
+	require.NoError(t, err)
+	e := &encryptor{current: key}
+	for _, p := range previous {
+		key, err = newEncryptionKey(bytes.Repeat([]byte{p}, 16))
+		require.NoError(t, err)
+		e.previous = append(e.previous, key)
+	}
+	return e
+}
+
+func TestEncryptorRoundTrip(t *testing.T) {
+	e := newTestEncryptor(t, 1)
+	value := []byte("some sensitive value")
+
+	encrypted, err := e.encrypt([]byte("key"), value)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), string(value))
+
+	decrypted, err := e.decrypt([]byte("key"), encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, value, decrypted)
+
+	// the value is bound to its key
+	_, err = e.decrypt([]byte("other_key"), encrypted)
+	assert.Error(t, err)
+
+	// the value is authenticated
+	encrypted[len(encrypted)-1] ^= 0xff
+	_, err = e.decrypt([]byte("key"), encrypted)
+	assert.Error(t, err)
+}
+
+func TestEncryptorKeys(t *testing.T) {
+	old := newTestEncryptor(t, 2)
+	encrypted, err := old.encrypt([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	// the value can't be read without the key
+	_, err = newTestEncryptor(t, 1).decrypt([]byte("key"), encrypted)
+	assert.ErrorIs(t, err, errUnknownKey)
+
+	_, err = newTestEncryptor(t, 1).decrypt([]byte("key"), []byte("value"))
+	assert.ErrorIs(t, err, errInvalidValue)
+
+	// the value can be read with a previous key, and needs to be rotated
+	e := &encryptor{current: newTestEncryptor(t, 1).current, previous: []*encryptionKey{old.current}}
+	decrypted, err := e.decrypt([]byte("key"), encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), decrypted)
+	assert.True(t, e.needsRotation(encrypted))
+
+	encrypted, err = e.encrypt([]byte("key"), decrypted)
+	require.NoError(t, err)
+	assert.False(t, e.needsRotation(encrypted))
+}
+
+func TestLoadEncryptionKey(t *testing.T) {
+	raw := bytes.Repeat([]byte{1}, 32)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(raw)+"\n"), 0600))
+	t.Setenv("FILE_STORAGE_TEST_KEY", base64.StdEncoding.EncodeToString(raw))
+	t.Setenv("FILE_STORAGE_TEST_SHORT_KEY", base64.StdEncoding.EncodeToString(raw[:8]))
+	t.Setenv("FILE_STORAGE_TEST_INVALID_KEY", "not base64")
+
+	fromFile, err := newEncryptor(&EncryptionConfig{Key: KeyConfig{File: keyFile}})
+	require.NoError(t, err)
+	fromEnv, err := newEncryptor(&EncryptionConfig{
+		Key:          KeyConfig{Env: "FILE_STORAGE_TEST_KEY"},
+		PreviousKeys: []KeyConfig{{File: keyFile}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fromFile.current.id, fromEnv.current.id)
+	assert.Len(t, fromEnv.previous, 1)
+
+	disabled, err := newEncryptor(nil)
+	require.NoError(t, err)
+	assert.Nil(t, disabled)
+
+	for _, keyCfg := range []KeyConfig{
+		{File: filepath.Join(t.TempDir(), "missing")},
+		{Env: "FILE_STORAGE_TEST_MISSING_KEY"},
+		{Env: "FILE_STORAGE_TEST_SHORT_KEY"},
+		{Env: "FILE_STORAGE_TEST_INVALID_KEY"},
+	} {
+		_, err = newEncryptor(&EncryptionConfig{Key: keyCfg})
+		assert.Error(t, err)
+		_, err = newEncryptor(&EncryptionConfig{Key: KeyConfig{File: keyFile}, PreviousKeys: []KeyConfig{keyCfg}})
+		assert.Error(t, err)
+	}
+}
+
+func TestClientEncryption(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	value := []byte("some sensitive value")
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(t, 1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.Set(ctx, "key", value))
+	retrieved, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, value, retrieved)
+	require.NoError(t, client.Close(ctx))
+
+	// the value isn't stored in plain text
+	content, err := os.ReadFile(dbFile)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(content, value))
+
+	// the value can't be read with another key
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(t, 2))
+	require.NoError(t, err)
+	_, err = client.Get(ctx, "key")
+	assert.ErrorIs(t, err, errUnknownKey)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientKeyRotationOnCompaction(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := context.Background()
+
+	// write values with the old key
+	old := newTestEncryptor(t, 2)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, old)
+	require.NoError(t, err)
+	keys := []string{"a", "b", "c", "d", "e"}
+	for _, key := range keys {
+		require.NoError(t, client.Set(ctx, key, []byte("value_"+key)))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// rotate the key, the values are still readable and get re-encrypted on compaction
+	rotated := &encryptor{current: newTestEncryptor(t, 1).current, previous: []*encryptionKey{old.current}}
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, rotated)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "new", []byte("value_new")))
+	require.NoError(t, client.Compact(tempDir, time.Second, 2))
+
+	for _, key := range append(keys, "new") {
+		retrieved, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value_"+key), retrieved)
+	}
+
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+			assert.False(t, rotated.needsRotation(v), "value of key %s was not re-encrypted", k)
+			return nil
+		})
+	}))
+	require.NoError(t, client.Close(ctx))
+
+	// the previous key is no longer needed
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, &encryptor{current: rotated.current})
+	require.NoError(t, err)
+	retrieved, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value_a"), retrieved)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientPlaintextMigrationOnCompaction(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := context.Background()
+
+	// write values without encryption
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(t, err)
+	keys := []string{"a", "b", "c", "d", "e"}
+	for _, key := range keys {
+		require.NoError(t, client.Set(ctx, key, []byte("value_"+key)))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the values can't be read once encryption is enabled
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(t, 1))
+	require.NoError(t, err)
+	_, err = client.Get(ctx, "a")
+	assert.ErrorIs(t, err, errInvalidValue)
+	require.NoError(t, client.Close(ctx))
+
+	// unless migrating, in which case they get encrypted on compaction
+	migrating := newTestEncryptor(t, 1)
+	migrating.migratePlaintext = true
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, migrating)
+	require.NoError(t, err)
+	retrieved, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value_a"), retrieved)
+	require.NoError(t, client.Set(ctx, "new", []byte("value_new")))
+	require.NoError(t, client.Compact(tempDir, time.Second, 2))
+
+	for _, key := range append(keys, "new") {
+		retrieved, err = client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value_"+key), retrieved)
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the values can be read once the migration is over
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestEncryptor(t, 1))
+	require.NoError(t, err)
+	for _, key := range append(keys, "new") {
+		retrieved, err = client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value_"+key), retrieved)
+	}
+	require.NoError(t, client.Close(ctx))
+
+	content, err := os.ReadFile(dbFile)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(content, []byte("value_a")))
+}
+
+func TestClientPlaintextMigrationKeepsValuesOfUnknownKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := context.Background()
+
+	// write a value with a key
+	old := newTestEncryptor(t, 2)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, old)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	var stored []byte
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		stored = append(stored, tx.Bucket(defaultBucket).Get([]byte("key"))...)
+		return nil
+	}))
+	require.NoError(t, client.Close(ctx))
+
+	// the key is dropped by mistake while migrating plain text values
+	migrating := newTestEncryptor(t, 1)
+	migrating.migratePlaintext = true
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, migrating)
+	require.NoError(t, err)
+	_, err = client.Get(ctx, "key")
+	assert.ErrorIs(t, err, errUnknownKey)
+
+	rotated, skipped, err := migrating.rotate(client.db, 0)
+	require.NoError(t, err)
+	assert.Zero(t, rotated)
+	assert.Equal(t, 1, skipped)
+	require.NoError(t, client.Compact(tempDir, time.Second, 0))
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		assert.Equal(t, stored, tx.Bucket(defaultBucket).Get([]byte("key")))
+		return nil
+	}))
+	require.NoError(t, client.Close(ctx))
+
+	// the value can be read once the key is configured again
+	restored := &encryptor{current: migrating.current, previous: []*encryptionKey{old.current}, migratePlaintext: true}
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, restored)
+	require.NoError(t, err)
+	retrieved, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), retrieved)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestExtensionEncryption(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{Key: KeyConfig{Env: "FILE_STORAGE_TEST_KEY"}}
+
+	// the key must be available when the extension is created
+	_, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.Error(t, err)
+
+	t.Setenv("FILE_STORAGE_TEST_KEY", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)))
+	extension, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	client, err := extension.(storage.Extension).GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NotNil(t, client.(*fileStorageClient).encryptor)
+	retrieved, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), retrieved)
+}
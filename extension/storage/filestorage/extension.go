@@ -26,17 +26,24 @@ import (
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg       *Config
+	logger    *zap.Logger
+	encryptor *encryptor
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
 func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+	encryptor, err := newEncryptor(config.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:       config,
+		logger:    logger,
+		encryptor: encryptor,
 	}, nil
 }
 
@@ -62,7 +69,7 @@ func (lfs *localFileStorage) GetClient(ctx context.Context, kind component.Kind,
 	}
 	// TODO sanitize rawName
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.encryptor)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	csvparser "encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+var errNoFields = errors.New("no fields found")
+
+// ReadCSVRow parses a single CSV row, which must hold fieldCount fields.
+// The row comes from a single value, so the line breaks it contains are
+// treated as part of the field they appear in, not as the start of a new record:
+// given the row "aa,b\nb,cc,d\nd,ee", the reader returns the lines ["aa","b"],
+// ["b","cc","d"] and ["d","ee"], which are joined into ["aa","b\nb","cc","d\nd","ee"].
+func ReadCSVRow(row string, delimiter rune, lazyQuotes bool, fieldCount int) ([]string, error) {
+	reader := csvparser.NewReader(strings.NewReader(row))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = fieldCount
+	reader.LazyQuotes = lazyQuotes
+
+	var fields []string
+	for {
+		line, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && len(line) == 0 {
+			return nil, err
+		}
+		if fields == nil {
+			fields = line
+			continue
+		}
+		// the first element of the line is a continuation of the last field of the previous one
+		fields[len(fields)-1] += "\n" + line[0]
+		fields = append(fields, line[1:]...)
+	}
+	if fields == nil {
+		return nil, errNoFields
+	}
+	return fields, nil
+}
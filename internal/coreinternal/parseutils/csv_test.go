@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSVRow(t *testing.T) {
+	cases := []struct {
+		name       string
+		row        string
+		delimiter  rune
+		lazyQuotes bool
+		fieldCount int
+		expected   []string
+		err        string
+	}{
+		{
+			name:       "simple",
+			row:        "a,b,c",
+			delimiter:  ',',
+			fieldCount: 3,
+			expected:   []string{"a", "b", "c"},
+		},
+		{
+			name:       "quoted delimiter",
+			row:        `a;"b;c";d`,
+			delimiter:  ';',
+			fieldCount: 3,
+			expected:   []string{"a", "b;c", "d"},
+		},
+		{
+			name:       "line breaks",
+			row:        "aa,b\nb,cc,d\nd,ee",
+			delimiter:  ',',
+			fieldCount: 5,
+			expected:   []string{"aa", "b\nb", "cc", "d\nd", "ee"},
+		},
+		{
+			name:       "lazy quotes",
+			row:        `a,b"c,d`,
+			delimiter:  ',',
+			lazyQuotes: true,
+			fieldCount: 3,
+			expected:   []string{"a", `b"c`, "d"},
+		},
+		{
+			name:       "empty",
+			row:        "",
+			delimiter:  ',',
+			fieldCount: 1,
+			err:        "no fields found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields, err := ReadCSVRow(tc.row, tc.delimiter, tc.lazyQuotes, tc.fieldCount)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, fields)
+		})
+	}
+}
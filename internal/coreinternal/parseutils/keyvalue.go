@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// SplitStringByWhitespace splits the input on the spaces that are not enclosed in quotes.
+func SplitStringByWhitespace(input string) []string {
+	quoted := false
+	return strings.FieldsFunc(input, func(r rune) bool {
+		if r == '"' || r == '\'' {
+			quoted = !quoted
+		}
+		return !quoted && r == ' '
+	})
+}
+
+// ParseKeyValuePairs splits each of the pairs by the delimiter into a key and a value, trimmed from
+// their spaces and quotes. The pairs which can't be split into two items are skipped, and reported
+// in the returned error along with the other pairs.
+func ParseKeyValuePairs(pairs []string, delimiter string) (map[string]string, error) {
+	parsed := make(map[string]string, len(pairs))
+	var err error
+	for _, raw := range pairs {
+		m := strings.Split(raw, delimiter)
+		if len(m) != 2 {
+			err = multierr.Append(err, fmt.Errorf("expected '%s' to split by '%s' into two items, got %d", raw, delimiter, len(m)))
+			continue
+		}
+
+		key := strings.TrimSpace(strings.Trim(m[0], "\"'"))
+		value := strings.TrimSpace(strings.Trim(m[1], "\"'"))
+		parsed[key] = value
+	}
+	return parsed, err
+}
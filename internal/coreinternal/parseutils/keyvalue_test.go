@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStringByWhitespace(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		output []string
+	}{
+		{
+			"simple",
+			"k=v a=b x=\" y \" job=\"software engineering\"",
+			[]string{
+				"k=v",
+				"a=b",
+				"x=\" y \"",
+				"job=\"software engineering\"",
+			},
+		},
+		{
+			"single quotes",
+			"k='a b' c=d",
+			[]string{
+				"k='a b'",
+				"c=d",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.output, SplitStringByWhitespace(tc.input))
+		})
+	}
+}
+
+func TestParseKeyValuePairs(t *testing.T) {
+	parsed, err := ParseKeyValuePairs([]string{`k=v`, `"quoted"=' value '`}, "=")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"k": "v", "quoted": "value"}, parsed)
+
+	parsed, err = ParseKeyValuePairs([]string{"k=v", "invalid", "a=b=c"}, "=")
+	assert.EqualError(t, err, "expected 'invalid' to split by '=' into two items, got 1; expected 'a=b=c' to split by '=' into two items, got 3")
+	assert.Equal(t, map[string]string{"k": "v"}, parsed)
+}
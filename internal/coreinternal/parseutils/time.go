@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import "time"
+
+// SetTimestampYear sets the year of a timestamp without one, such as the RFC 3164 ones, to the
+// year of now. The timestamps which would then be more than 7 days after now are assumed to be
+// from the previous year.
+func SetTimestampYear(t time.Time, now time.Time) time.Time {
+	if t.Year() > 0 {
+		return t
+	}
+	d := time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if d.After(now.AddDate(0, 0, 7)) {
+		d = d.AddDate(-1, 0, 0)
+	}
+	return d
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parseutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTimestampYear(t *testing.T) {
+	cases := []struct {
+		name     string
+		now      time.Time
+		noYear   time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Normal",
+			now:      time.Date(2020, 06, 16, 3, 31, 34, 525, time.UTC),
+			noYear:   time.Date(0, 06, 16, 3, 31, 34, 525, time.UTC),
+			expected: time.Date(2020, 06, 16, 3, 31, 34, 525, time.UTC),
+		},
+		{
+			name:     "FutureOneDay",
+			now:      time.Date(2020, 01, 16, 3, 31, 34, 525, time.UTC),
+			noYear:   time.Date(0, 01, 17, 3, 31, 34, 525, time.UTC),
+			expected: time.Date(2020, 01, 17, 3, 31, 34, 525, time.UTC),
+		},
+		{
+			name:     "FutureEightDays",
+			now:      time.Date(2020, 01, 16, 3, 31, 34, 525, time.UTC),
+			noYear:   time.Date(0, 01, 24, 3, 31, 34, 525, time.UTC),
+			expected: time.Date(2019, 01, 24, 3, 31, 34, 525, time.UTC),
+		},
+		{
+			name:     "RolloverYear",
+			now:      time.Date(2020, 01, 01, 3, 31, 34, 525, time.UTC),
+			noYear:   time.Date(0, 12, 31, 3, 31, 34, 525, time.UTC),
+			expected: time.Date(2019, 12, 31, 3, 31, 34, 525, time.UTC),
+		},
+		{
+			name:     "YearSet",
+			now:      time.Date(2023, 01, 03, 0, 0, 0, 0, time.UTC),
+			noYear:   time.Date(2021, 05, 01, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 05, 01, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, SetTimestampYear(tc.noYear, tc.now))
+		})
+	}
+}
@@ -16,6 +16,7 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"bytes"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/exp/constraints"
@@ -23,7 +24,7 @@ import (
 
 // The functions in this file implement a general-purpose comparison of two
 // values of type any, which for the purposes of OTTL mean values that are one of
-// int, float, string, bool, or pointers to those, or []byte, or nil.
+// int, float, string, bool, or pointers to those, or []byte, time.Time, time.Duration, or nil.
 
 // invalidComparison returns false for everything except NE (where it returns true to indicate that the
 // objects were definitely not equivalent).
@@ -92,6 +93,25 @@ func compareBytes(a []byte, b []byte, op compareOp) bool {
 	}
 }
 
+func compareTimes(a time.Time, b time.Time, op compareOp) bool {
+	switch op {
+	case EQ:
+		return a.Equal(b)
+	case NE:
+		return !a.Equal(b)
+	case LT:
+		return a.Before(b)
+	case LTE:
+		return a.Before(b) || a.Equal(b)
+	case GTE:
+		return a.After(b) || a.Equal(b)
+	case GT:
+		return a.After(b)
+	default:
+		return false
+	}
+}
+
 func (p *Parser[K]) compareBool(a bool, b any, op compareOp) bool {
 	switch v := b.(type) {
 	case bool:
@@ -146,6 +166,24 @@ func (p *Parser[K]) compareFloat64(a float64, b any, op compareOp) bool {
 	}
 }
 
+func (p *Parser[K]) compareTime(a time.Time, b any, op compareOp) bool {
+	switch v := b.(type) {
+	case time.Time:
+		return compareTimes(a, v, op)
+	default:
+		return p.invalidComparison("time to non-time value", op)
+	}
+}
+
+func (p *Parser[K]) compareDuration(a time.Duration, b any, op compareOp) bool {
+	switch v := b.(type) {
+	case time.Duration:
+		return comparePrimitives(a, v, op)
+	default:
+		return p.invalidComparison("duration to non-duration value", op)
+	}
+}
+
 // a and b are the return values from a Getter; we try to compare them
 // according to the given operator.
 func (p *Parser[K]) compare(a any, b any, op compareOp) bool {
@@ -173,6 +211,10 @@ func (p *Parser[K]) compare(a any, b any, op compareOp) bool {
 			return p.compare(b, nil, op)
 		}
 		return p.compareByte(v, b, op)
+	case time.Time:
+		return p.compareTime(v, b, op)
+	case time.Duration:
+		return p.compareDuration(v, b, op)
 	default:
 		// If we don't know what type it is, we can't do inequalities yet. So we can fall back to the old behavior where we just
 		// use Go's standard equality.
@@ -17,6 +17,7 @@ package ottl
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"go.opentelemetry.io/collector/component/componenttest"
 )
@@ -35,6 +36,11 @@ var (
 	i64b = int64(2)
 	f64a = float64(1)
 	f64b = float64(2)
+	tma  = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tmb  = time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	tmc  = tma.In(time.FixedZone("UTC+1", 3600))
+	da   = time.Second
+	db   = time.Minute
 )
 
 type testA struct {
@@ -106,6 +112,17 @@ func Test_compare(t *testing.T) {
 		{"float64 nil", f64a, nil, []bool{false, true, false, false, false, false}},
 		{"float64 int64", f64a, i64b, []bool{false, true, true, true, false, false}},
 
+		{"diff times", tma, tmb, []bool{false, true, true, true, false, false}},
+		{"same time in different zones", tma, tmc, []bool{true, false, false, true, true, false}},
+		{"time string", tma, sa, []bool{false, true, false, false, false, false}},
+		{"time int64", tma, i64a, []bool{false, true, false, false, false, false}},
+		{"time nil", tma, nil, []bool{false, true, false, false, false, false}},
+		{"time duration", tma, da, []bool{false, true, false, false, false, false}},
+
+		{"diff durations", db, da, []bool{false, true, false, false, true, true}},
+		{"duration int64", da, i64a, []bool{false, true, false, false, false, false}},
+		{"duration time", da, tma, []bool{false, true, false, false, false, false}},
+
 		{"non-prim, same type, equal", testA{"hi"}, testA{"hi"}, []bool{true, false, false, false, false, false}},
 		{"non-prim, same type, not equal", testA{"hi"}, testA{"byte"}, []bool{false, true, false, false, false, false}},
 		{"non-prim, diff type", testA{"hi"}, testB{"hi"}, []bool{false, true, false, false, false, false}},
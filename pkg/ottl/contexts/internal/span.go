@@ -84,6 +84,10 @@ func SpanPathGetSetter[K SpanContext](path []ottl.Field) (ottl.GetSetter[K], err
 		return accessStartTimeUnixNano[K](), nil
 	case "end_time_unix_nano":
 		return accessEndTimeUnixNano[K](), nil
+	case "start_time":
+		return accessStartTime[K](), nil
+	case "end_time":
+		return accessEndTime[K](), nil
 	case "attributes":
 		mapKeys := path[0].Keys
 		if mapKeys == nil {
@@ -328,6 +332,34 @@ func accessEndTimeUnixNano[K SpanContext]() ottl.StandardGetSetter[K] {
 	}
 }
 
+func accessStartTime[K SpanContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(ctx context.Context, tCtx K) (interface{}, error) {
+			return tCtx.GetSpan().StartTimestamp().AsTime(), nil
+		},
+		Setter: func(ctx context.Context, tCtx K, val interface{}) error {
+			if t, ok := val.(time.Time); ok {
+				tCtx.GetSpan().SetStartTimestamp(pcommon.NewTimestampFromTime(t))
+			}
+			return nil
+		},
+	}
+}
+
+func accessEndTime[K SpanContext]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(ctx context.Context, tCtx K) (interface{}, error) {
+			return tCtx.GetSpan().EndTimestamp().AsTime(), nil
+		},
+		Setter: func(ctx context.Context, tCtx K, val interface{}) error {
+			if t, ok := val.(time.Time); ok {
+				tCtx.GetSpan().SetEndTimestamp(pcommon.NewTimestampFromTime(t))
+			}
+			return nil
+		},
+	}
+}
+
 func accessAttributes[K SpanContext]() ottl.StandardGetSetter[K] {
 	return ottl.StandardGetSetter[K]{
 		Getter: func(ctx context.Context, tCtx K) (interface{}, error) {
@@ -214,6 +214,19 @@ func TestSpanPathGetSetter(t *testing.T) {
 				span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "start_time",
+			path: []ottl.Field{
+				{
+					Name: "start_time",
+				},
+			},
+			orig:   time.UnixMilli(100).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(span ptrace.Span) {
+				span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "end_time_unix_nano",
 			path: []ottl.Field{
@@ -227,6 +240,19 @@ func TestSpanPathGetSetter(t *testing.T) {
 				span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "end_time",
+			path: []ottl.Field{
+				{
+					Name: "end_time",
+				},
+			},
+			orig:   time.UnixMilli(500).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(span ptrace.Span) {
+				span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "attributes",
 			path: []ottl.Field{
@@ -131,6 +131,19 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 				datapoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "start_time",
+			path: []ottl.Field{
+				{
+					Name: "start_time",
+				},
+			},
+			orig:   time.UnixMilli(100).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(datapoint pmetric.NumberDataPoint) {
+				datapoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "time_unix_nano",
 			path: []ottl.Field{
@@ -144,6 +157,19 @@ func Test_newPathGetSetter_NumberDataPoint(t *testing.T) {
 				datapoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "time",
+			path: []ottl.Field{
+				{
+					Name: "time",
+				},
+			},
+			orig:   time.UnixMilli(500).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(datapoint pmetric.NumberDataPoint) {
+				datapoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "value_double",
 			path: []ottl.Field{
@@ -585,6 +611,19 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 				datapoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "start_time",
+			path: []ottl.Field{
+				{
+					Name: "start_time",
+				},
+			},
+			orig:   time.UnixMilli(100).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(datapoint pmetric.HistogramDataPoint) {
+				datapoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "time_unix_nano",
 			path: []ottl.Field{
@@ -598,6 +637,19 @@ func Test_newPathGetSetter_HistogramDataPoint(t *testing.T) {
 				datapoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "time",
+			path: []ottl.Field{
+				{
+					Name: "time",
+				},
+			},
+			orig:   time.UnixMilli(500).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(datapoint pmetric.HistogramDataPoint) {
+				datapoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "flags",
 			path: []ottl.Field{
@@ -1070,6 +1122,19 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 				datapoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "start_time",
+			path: []ottl.Field{
+				{
+					Name: "start_time",
+				},
+			},
+			orig:   time.UnixMilli(100).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(datapoint pmetric.ExponentialHistogramDataPoint) {
+				datapoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "time_unix_nano",
 			path: []ottl.Field{
@@ -1083,6 +1148,19 @@ func Test_newPathGetSetter_ExpoHistogramDataPoint(t *testing.T) {
 				datapoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "time",
+			path: []ottl.Field{
+				{
+					Name: "time",
+				},
+			},
+			orig:   time.UnixMilli(500).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(datapoint pmetric.ExponentialHistogramDataPoint) {
+				datapoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "flags",
 			path: []ottl.Field{
@@ -1646,6 +1724,19 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 				datapoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "start_time",
+			path: []ottl.Field{
+				{
+					Name: "start_time",
+				},
+			},
+			orig:   time.UnixMilli(100).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(datapoint pmetric.SummaryDataPoint) {
+				datapoint.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "time_unix_nano",
 			path: []ottl.Field{
@@ -1659,6 +1750,19 @@ func Test_newPathGetSetter_SummaryDataPoint(t *testing.T) {
 				datapoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
 			},
 		},
+		{
+			name: "time",
+			path: []ottl.Field{
+				{
+					Name: "time",
+				},
+			},
+			orig:   time.UnixMilli(500).UTC(),
+			newVal: time.UnixMilli(200).UTC(),
+			modified: func(datapoint pmetric.SummaryDataPoint) {
+				datapoint.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
 		{
 			name: "flags",
 			path: []ottl.Field{
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -69,6 +70,16 @@ func (l literal[K]) Get(context.Context, K) (interface{}, error) {
 	return l.value, nil
 }
 
+// IsLiteral returns whether the getter evaluates to a value written in the statement, rather than to the result of a
+// path, converter or element reference.
+func IsLiteral[K any](getter Getter[K]) bool {
+	switch getter.(type) {
+	case literal[K], *literal[K]:
+		return true
+	}
+	return false
+}
+
 type exprGetter[K any] struct {
 	expr Expr[K]
 	keys []Key
@@ -80,11 +91,12 @@ func (g exprGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
 		return nil, err
 	}
 
-	if g.keys == nil {
-		return result, nil
-	}
+	return indexValue(result, g.keys)
+}
 
-	for _, k := range g.keys {
+// indexValue walks the given keys into result, which must be a map or slice for each key.
+func indexValue(result interface{}, keys []Key) (interface{}, error) {
+	for _, k := range keys {
 		switch {
 		case k.String != nil:
 			switch r := result.(type) {
@@ -143,6 +155,60 @@ func (l *listGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
 	return evaluated, nil
 }
 
+type mapGetter[K any] struct {
+	keys   []string
+	values []Getter[K]
+}
+
+func (m *mapGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
+	result := pcommon.NewMap()
+	result.EnsureCapacity(len(m.keys))
+
+	for i, k := range m.keys {
+		val, err := m.values[i].Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		err = ottlcommon.SetValue(result.PutEmpty(k), val)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+type elementContextKey struct{}
+
+// element holds the key and value of the map or slice element currently being visited.
+type element struct {
+	key   interface{}
+	value interface{}
+}
+
+// WithElement returns a copy of ctx in which `$key` and `$value` resolve to the given key and value.
+// Functions that accept an ElementGetter must call it for every element they visit before evaluating the getter.
+func WithElement(ctx context.Context, key interface{}, value interface{}) context.Context {
+	return context.WithValue(ctx, elementContextKey{}, element{key: key, value: value})
+}
+
+type elementGetter[K any] struct {
+	name string
+	keys []Key
+}
+
+func (e *elementGetter[K]) Get(ctx context.Context, _ K) (interface{}, error) {
+	el, ok := ctx.Value(elementContextKey{}).(element)
+	if !ok {
+		return nil, fmt.Errorf("%v is not bound to an element; this is an error in the function evaluating it", e.name)
+	}
+	result := el.value
+	if e.name == elementKey {
+		result = el.key
+	}
+	return indexValue(result, e.keys)
+}
+
 // StringGetter is a Getter that must return a string.
 type StringGetter[K any] interface {
 	// Get retrieves a string value.  If the value is not a string, an error is returned.
@@ -157,6 +223,22 @@ type PMapGetter[K any] interface {
 	Get(ctx context.Context, tCtx K) (pcommon.Map, error)
 }
 
+// TimeGetter is a Getter that must return a time.Time.
+type TimeGetter[K any] interface {
+	Get(ctx context.Context, tCtx K) (time.Time, error)
+}
+
+// DurationGetter is a Getter that must return a time.Duration.
+type DurationGetter[K any] interface {
+	Get(ctx context.Context, tCtx K) (time.Duration, error)
+}
+
+// ElementGetter is a Getter that is evaluated once for each element of a map or slice.
+// Within its expression `$key` and `$value` refer to the element being visited; see WithElement.
+type ElementGetter[K any] interface {
+	Get(ctx context.Context, tCtx K) (interface{}, error)
+}
+
 type StandardTypeGetter[K any, T any] struct {
 	Getter func(ctx context.Context, tCtx K) (interface{}, error)
 }
@@ -258,6 +340,12 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		if eL.Path != nil {
 			return p.pathParser(eL.Path)
 		}
+		if eL.Element != nil {
+			if p.elementScope == 0 {
+				return nil, fmt.Errorf("%v can only be used in an argument that is evaluated for each element", eL.Element.Name)
+			}
+			return &elementGetter[K]{name: eL.Element.Name, keys: eL.Element.Keys}, nil
+		}
 		if eL.Converter != nil {
 			return p.newGetterFromConverter(*eL.Converter)
 		}
@@ -275,6 +363,22 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		return &lg, nil
 	}
 
+	if val.Map != nil {
+		mg := mapGetter[K]{
+			keys:   make([]string, len(val.Map.Values)),
+			values: make([]Getter[K], len(val.Map.Values)),
+		}
+		for i, item := range val.Map.Values {
+			getter, err := p.newGetter(*item.Value)
+			if err != nil {
+				return nil, err
+			}
+			mg.keys[i] = *item.Key
+			mg.values[i] = getter
+		}
+		return &mg, nil
+	}
+
 	if val.MathExpression == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the OpenTelemetry Transformation Language")
@@ -423,6 +423,60 @@ func Test_newGetter(t *testing.T) {
 			},
 			want: []any{"test0", int64(1)},
 		},
+		{
+			name: "map literal",
+			val: value{
+				Map: &mapValue{
+					Values: []mapItem{
+						{
+							Key:   ottltest.Strp("string"),
+							Value: &value{String: ottltest.Strp("test0")},
+						},
+						{
+							Key: ottltest.Strp("int"),
+							Value: &value{
+								Literal: &mathExprLiteral{
+									Int: ottltest.Intp(1),
+								},
+							},
+						},
+						{
+							Key: ottltest.Strp("list"),
+							Value: &value{
+								List: &list{
+									Values: []value{
+										{
+											String: ottltest.Strp("test1"),
+										},
+									},
+								},
+							},
+						},
+						{
+							Key: ottltest.Strp("map"),
+							Value: &value{
+								Map: &mapValue{
+									Values: []mapItem{
+										{
+											Key:   ottltest.Strp("nested"),
+											Value: &value{Bool: (*boolean)(ottltest.Boolp(true))},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutStr("string", "test0")
+				m.PutInt("int", 1)
+				m.PutEmptySlice("list").AppendEmpty().SetStr("test1")
+				m.PutEmptyMap("map").PutBool("nested", true)
+				return m
+			}(),
+		},
 	}
 
 	functions := CreateFactoryMap(
@@ -462,6 +516,60 @@ func Test_newGetter(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+func Test_elementGetter(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("nested", "value")
+
+	tests := []struct {
+		name   string
+		getter elementGetter[any]
+		want   any
+	}{
+		{
+			name:   "key",
+			getter: elementGetter[any]{name: elementKey},
+			want:   "key",
+		},
+		{
+			name:   "value",
+			getter: elementGetter[any]{name: elementValue},
+			want:   m,
+		},
+		{
+			name: "indexed value",
+			getter: elementGetter[any]{
+				name: elementValue,
+				keys: []Key{
+					{
+						String: ottltest.Strp("nested"),
+					},
+				},
+			},
+			want: "value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := tt.getter.Get(WithElement(context.Background(), "key", m), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, val)
+		})
+	}
+
+	t.Run("unbound", func(t *testing.T) {
+		getter := elementGetter[any]{name: elementKey}
+		_, err := getter.Get(context.Background(), nil)
+		assert.Error(t, err)
+	})
+}
+
+func Test_IsLiteral(t *testing.T) {
+	assert.True(t, IsLiteral[any](&literal[any]{value: "$1"}))
+	assert.True(t, IsLiteral[any](literal[any]{value: int64(1)}))
+	assert.False(t, IsLiteral[any](&elementGetter[any]{name: elementValue}))
+	assert.False(t, IsLiteral[any](StandardGetSetter[any]{}))
+}
+
 func Test_exprGetter_Get_Invalid(t *testing.T) {
 	tests := []struct {
 		name string
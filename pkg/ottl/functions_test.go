@@ -242,6 +242,21 @@ func Test_NewFunctionCall_invalid(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "element reference outside of elementgetter",
+			inv: invocation{
+				Function: "testing_getter",
+				Arguments: []value{
+					{
+						Literal: &mathExprLiteral{
+							Element: &elementRef{
+								Name: "$value",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "not matching arg type",
 			inv: invocation{
@@ -882,6 +897,30 @@ func Test_NewFunctionCall(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "timegetter arg",
+			inv: invocation{
+				Function: "testing_timegetter",
+				Arguments: []value{
+					{
+						String: ottltest.Strp("test"),
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "durationgetter arg",
+			inv: invocation{
+				Function: "testing_durationgetter",
+				Arguments: []value{
+					{
+						String: ottltest.Strp("test"),
+					},
+				},
+			},
+			want: nil,
+		},
 		{
 			name: "pmapgetter arg",
 			inv: invocation{
@@ -902,6 +941,50 @@ func Test_NewFunctionCall(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "elementgetter arg",
+			inv: invocation{
+				Function: "testing_elementgetter",
+				Arguments: []value{
+					{
+						Literal: &mathExprLiteral{
+							Converter: &converter{
+								Function: "testing_getter",
+								Arguments: []value{
+									{
+										Literal: &mathExprLiteral{
+											Element: &elementRef{
+												Name: "$key",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "getter arg with map",
+			inv: invocation{
+				Function: "testing_getter",
+				Arguments: []value{
+					{
+						Map: &mapValue{
+							Values: []mapItem{
+								{
+									Key:   ottltest.Strp("foo"),
+									Value: &value{String: ottltest.Strp("bar")},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
 		{
 			name: "string arg",
 			inv: invocation{
@@ -1171,6 +1254,26 @@ func functionWithIntGetter(IntGetter[interface{}]) (ExprFunc[interface{}], error
 	}, nil
 }
 
+type timeGetterArguments struct {
+	TimeGetterArg TimeGetter[any] `ottlarg:"0"`
+}
+
+func functionWithTimeGetter(TimeGetter[interface{}]) (ExprFunc[interface{}], error) {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return "anything", nil
+	}, nil
+}
+
+type durationGetterArguments struct {
+	DurationGetterArg DurationGetter[any] `ottlarg:"0"`
+}
+
+func functionWithDurationGetter(DurationGetter[interface{}]) (ExprFunc[interface{}], error) {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return "anything", nil
+	}, nil
+}
+
 type pMapGetterArguments struct {
 	PMapArg PMapGetter[any] `ottlarg:"0"`
 }
@@ -1181,6 +1284,16 @@ func functionWithPMapGetter(PMapGetter[interface{}]) (ExprFunc[interface{}], err
 	}, nil
 }
 
+type elementGetterArguments struct {
+	ElementArg ElementGetter[any] `ottlarg:"0"`
+}
+
+func functionWithElementGetter(ElementGetter[interface{}]) (ExprFunc[interface{}], error) {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return "anything", nil
+	}, nil
+}
+
 type stringArguments struct {
 	StringArg string `ottlarg:"0"`
 }
@@ -1385,11 +1498,26 @@ func defaultFunctionsForTests() map[string]Factory[any] {
 			&intGetterArguments{},
 			functionWithIntGetter,
 		),
+		createFactory[any](
+			"testing_timegetter",
+			&timeGetterArguments{},
+			functionWithTimeGetter,
+		),
+		createFactory[any](
+			"testing_durationgetter",
+			&durationGetterArguments{},
+			functionWithDurationGetter,
+		),
 		createFactory[any](
 			"testing_pmapgetter",
 			&pMapGetterArguments{},
 			functionWithPMapGetter,
 		),
+		createFactory[any](
+			"testing_elementgetter",
+			&elementGetterArguments{},
+			functionWithElementGetter,
+		),
 		createFactory[any](
 			"testing_string",
 			&stringArguments{},
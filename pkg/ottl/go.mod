@@ -6,7 +6,10 @@ require (
 	github.com/alecthomas/participle/v2 v2.0.0
 	github.com/gobwas/glob v0.2.3
 	github.com/iancoleman/strcase v0.2.0
+	github.com/influxdata/go-syslog/v3 v3.0.1-0.20210608084020-ac565dc76ba6
 	github.com/json-iterator/go v1.1.12
+	github.com/observiq/ctimefmt v1.0.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.76.3
 	github.com/stretchr/testify v1.8.2
 	go.opentelemetry.io/collector/component v0.76.2-0.20230502195822-4df44379e094
 	go.opentelemetry.io/collector/pdata v1.0.0-rcv0011.0.20230502184615-d93102a4c623
@@ -42,6 +45,8 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal => ../../internal/coreinternal
+
 retract (
 	v0.76.2
 	v0.76.1
@@ -23,9 +23,10 @@ import (
 
 // parsedStatement represents a parsed statement. It is the entry point into the statement DSL.
 type parsedStatement struct {
-	Invocation invocation `parser:"(@@"`
+	Control    *controlStatement `parser:"( @@"`
+	Invocation invocation        `parser:"| @@"`
 	// If converter is matched then return error
-	Converter   *converter         `parser:"|@@)"`
+	Converter   *converter         `parser:"| @@ )"`
 	WhereClause *booleanExpression `parser:"( 'where' @@ )?"`
 }
 
@@ -33,6 +34,12 @@ func (p *parsedStatement) checkForCustomError() error {
 	if p.Converter != nil {
 		return fmt.Errorf("invocation names must start with a lowercase letter but got '%v'", p.Converter.Function)
 	}
+	if p.Control != nil {
+		if p.WhereClause != nil && p.Control.Keyword != keywordStop {
+			return fmt.Errorf("'%v' does not accept a where clause", p.Control.Keyword)
+		}
+		return p.Control.checkForCustomError()
+	}
 	err := p.Invocation.checkForCustomError()
 	if err != nil {
 		return err
@@ -43,6 +50,40 @@ func (p *parsedStatement) checkForCustomError() error {
 	return nil
 }
 
+const (
+	keywordIf   = "if"
+	keywordElse = "else"
+	keywordEnd  = "end"
+	keywordStop = "stop"
+)
+
+// controlStatement represents a statement that controls which of the following statements are executed:
+// `if <condition>`, `else if <condition>`, `else` and `end` delimit conditional blocks,
+// while `stop` ends the execution of the remaining statements.
+type controlStatement struct {
+	Keyword   string             `parser:"@( 'if' | 'else' | 'end' | 'stop' )"`
+	ElseIf    bool               `parser:"@'if'?"`
+	Condition *booleanExpression `parser:"@@?"`
+}
+
+func (c *controlStatement) checkForCustomError() error {
+	switch {
+	case c.ElseIf && c.Keyword != keywordElse:
+		return fmt.Errorf("unexpected 'if' after '%v'", c.Keyword)
+	case c.Keyword == keywordIf || c.ElseIf:
+		if c.Condition == nil {
+			return fmt.Errorf("'if' requires a condition")
+		}
+		return c.Condition.checkForCustomError()
+	case c.Condition != nil:
+		if c.Keyword == keywordStop {
+			return fmt.Errorf("the condition of 'stop' must be given in a where clause")
+		}
+		return fmt.Errorf("'%v' does not accept a condition", c.Keyword)
+	}
+	return nil
+}
+
 type constExpr struct {
 	Boolean   *boolean   `parser:"( @Boolean"`
 	Converter *converter `parser:"| @@ )"`
@@ -237,6 +278,7 @@ type value struct {
 	String         *string          `parser:"| @String"`
 	Bool           *boolean         `parser:"| @Boolean"`
 	Enum           *EnumSymbol      `parser:"| @Uppercase"`
+	Map            *mapValue        `parser:"| @@"`
 	List           *list            `parser:"| @@)"`
 }
 
@@ -247,6 +289,12 @@ func (v *value) checkForCustomError() error {
 	if v.MathExpression != nil {
 		return v.MathExpression.checkForCustomError()
 	}
+	if v.Map != nil {
+		return v.Map.checkForCustomError()
+	}
+	if v.List != nil {
+		return v.List.checkForCustomError()
+	}
 	return nil
 }
 
@@ -270,6 +318,60 @@ type list struct {
 	Values []value `parser:"'[' (@@)* (',' @@)* ']'"`
 }
 
+func (l *list) checkForCustomError() error {
+	for _, v := range l.Values {
+		err := v.checkForCustomError()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapValue represents a map literal, e.g. {"key": "value", "other": attributes["other"]}.
+type mapValue struct {
+	Values []mapItem `parser:"'{' ( @@ ( ',' @@ )* )? '}'"`
+}
+
+func (m *mapValue) checkForCustomError() error {
+	seen := make(map[string]struct{}, len(m.Values))
+	for _, item := range m.Values {
+		if _, ok := seen[*item.Key]; ok {
+			return fmt.Errorf("duplicate key %q in map literal", *item.Key)
+		}
+		seen[*item.Key] = struct{}{}
+		err := item.Value.checkForCustomError()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type mapItem struct {
+	Key   *string `parser:"@String ':'"`
+	Value *value  `parser:"@@"`
+}
+
+// elementRef refers to the element currently being visited by a function that
+// iterates over a map or slice, e.g. $key or $value["nested"].
+type elementRef struct {
+	Name string `parser:"@Element"`
+	Keys []Key  `parser:"( @@ )*"`
+}
+
+const (
+	elementKey   = "$key"
+	elementValue = "$value"
+)
+
+func (e *elementRef) checkForCustomError() error {
+	if e.Name != elementKey && e.Name != elementValue {
+		return fmt.Errorf("unknown element reference '%v', must be either '%v' or '%v'", e.Name, elementKey, elementValue)
+	}
+	return nil
+}
+
 // byteSlice type for capturing byte slices
 type byteSlice []byte
 
@@ -305,6 +407,7 @@ type mathExprLiteral struct {
 	Converter  *converter  `parser:"| @@"`
 	Float      *float64    `parser:"| @Float"`
 	Int        *int64      `parser:"| @Int"`
+	Element    *elementRef `parser:"| @@"`
 	Path       *Path       `parser:"| @@ )"`
 }
 
@@ -312,6 +415,17 @@ func (m *mathExprLiteral) checkForCustomError() error {
 	if m.Invocation != nil {
 		return fmt.Errorf("converter names must start with an uppercase letter but got '%v'", m.Invocation.Function)
 	}
+	if m.Converter != nil {
+		for _, arg := range m.Converter.Arguments {
+			err := arg.checkForCustomError()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if m.Element != nil {
+		return m.Element.checkForCustomError()
+	}
 	return nil
 }
 
@@ -443,7 +557,8 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
-		{Name: `Punct`, Pattern: `[,.\[\]]`},
+		{Name: `Punct`, Pattern: `[,.:\[\]{}]`},
+		{Name: `Element`, Pattern: `\$[a-z][a-z0-9_]*`},
 		{Name: `Uppercase`, Pattern: `[A-Z][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z][a-z0-9_]*`},
 		{Name: "whitespace", Pattern: `\s+`},
@@ -15,6 +15,8 @@
 package ottlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
@@ -37,3 +39,45 @@ func GetValue(val pcommon.Value) interface{} {
 	}
 	return nil
 }
+
+// SetValue stores val, as returned by an OTTL Getter, into value.
+func SetValue(value pcommon.Value, val interface{}) error {
+	switch v := val.(type) {
+	case nil:
+	case string:
+		value.SetStr(v)
+	case bool:
+		value.SetBool(v)
+	case int64:
+		value.SetInt(v)
+	case float64:
+		value.SetDouble(v)
+	case []byte:
+		value.SetEmptyBytes().FromRaw(v)
+	case pcommon.Map:
+		v.CopyTo(value.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(value.SetEmptySlice())
+	case pcommon.Value:
+		v.CopyTo(value)
+	case []interface{}:
+		s := value.SetEmptySlice()
+		s.EnsureCapacity(len(v))
+		for _, a := range v {
+			if err := SetValue(s.AppendEmpty(), a); err != nil {
+				return err
+			}
+		}
+	case []string:
+		s := value.SetEmptySlice()
+		s.EnsureCapacity(len(v))
+		for _, str := range v {
+			s.AppendEmpty().SetStr(str)
+		}
+	case map[string]interface{}:
+		return value.SetEmptyMap().FromRaw(v)
+	default:
+		return fmt.Errorf("unsupported value type %T", val)
+	}
+	return nil
+}
@@ -89,9 +89,19 @@ func Test_lexer(t *testing.T) {
 			{"OpNot", "not"},
 			{"Boolean", "false"},
 		}},
-		{"nothing_recognizable", "{}", true, []result{
+		{"nothing_recognizable", "#@", true, []result{
 			{"", ""},
 		}},
+		{"map_literal", `{"foo": $value["bar"]}`, false, []result{
+			{"Punct", "{"},
+			{"String", `"foo"`},
+			{"Punct", ":"},
+			{"Element", "$value"},
+			{"Punct", "["},
+			{"String", `"bar"`},
+			{"Punct", "]"},
+			{"Punct", "}"},
+		}},
 		{"basic_ident_expr", `set(attributes["bytes"], 0x0102030405060708)`, false, []result{
 			{"Lowercase", "set"},
 			{"LParen", "("},
@@ -17,6 +17,7 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 func (p *Parser[K]) evaluateMathExpression(expr *mathExpression) (Getter[K], error) {
@@ -109,8 +110,12 @@ func attemptMathOperation[K any](lhs Getter[K], op mathOp, rhs Getter[K]) Getter
 					default:
 						return nil, fmt.Errorf("%v must be int64 or float64", y)
 					}
+				case time.Time:
+					return performTimeOp(newX, y, op)
+				case time.Duration:
+					return performDurationOp(newX, y, op)
 				default:
-					return nil, fmt.Errorf("%v must be int64 or float64", x)
+					return nil, fmt.Errorf("%v must be int64, float64, time.Time or time.Duration", x)
 				}
 			},
 		},
@@ -133,3 +138,63 @@ func performOp[N int64 | float64](x N, y N, op mathOp) (N, error) {
 	}
 	return 0, fmt.Errorf("invalid operation %v", op)
 }
+
+// performTimeOp supports shifting a time by a duration and subtracting two times, which results in a duration.
+func performTimeOp(x time.Time, y interface{}, op mathOp) (interface{}, error) {
+	switch newY := y.(type) {
+	case time.Duration:
+		switch op {
+		case ADD:
+			return x.Add(newY), nil
+		case SUB:
+			return x.Add(-newY), nil
+		}
+		return nil, fmt.Errorf("only addition and subtraction are supported between time.Time and time.Duration")
+	case time.Time:
+		if op == SUB {
+			return x.Sub(newY), nil
+		}
+		return nil, fmt.Errorf("only subtraction is supported between two time.Time values")
+	default:
+		return nil, fmt.Errorf("%v must be time.Time or time.Duration", y)
+	}
+}
+
+// performDurationOp supports adding and subtracting durations, adding a duration to a time and
+// scaling a duration by a number.
+func performDurationOp(x time.Duration, y interface{}, op mathOp) (interface{}, error) {
+	switch newY := y.(type) {
+	case time.Duration:
+		switch op {
+		case ADD:
+			return x + newY, nil
+		case SUB:
+			return x - newY, nil
+		}
+		return nil, fmt.Errorf("only addition and subtraction are supported between two time.Duration values")
+	case time.Time:
+		if op == ADD {
+			return newY.Add(x), nil
+		}
+		return nil, fmt.Errorf("only addition is supported between time.Duration and time.Time")
+	case int64:
+		return scaleDuration(x, float64(newY), op)
+	case float64:
+		return scaleDuration(x, newY, op)
+	default:
+		return nil, fmt.Errorf("%v must be time.Duration, time.Time, int64 or float64", y)
+	}
+}
+
+func scaleDuration(x time.Duration, factor float64, op mathOp) (interface{}, error) {
+	switch op {
+	case MULT:
+		return time.Duration(float64(x) * factor), nil
+	case DIV:
+		if factor == 0 {
+			return nil, fmt.Errorf("attempted to divide by 0")
+		}
+		return time.Duration(float64(x) / factor), nil
+	}
+	return nil, fmt.Errorf("only multiplication and division are supported between time.Duration and a number")
+}
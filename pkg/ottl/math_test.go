@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -283,3 +284,149 @@ func Test_evaluateMathExpression_error(t *testing.T) {
 		})
 	}
 }
+
+func Test_attemptMathOperation_timeAndDuration(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 1, 0, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		lhs      interface{}
+		op       mathOp
+		rhs      interface{}
+		expected interface{}
+	}{
+		{
+			name:     "time minus time",
+			lhs:      end,
+			op:       SUB,
+			rhs:      start,
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "time plus duration",
+			lhs:      start,
+			op:       ADD,
+			rhs:      5 * time.Minute,
+			expected: end,
+		},
+		{
+			name:     "time minus duration",
+			lhs:      end,
+			op:       SUB,
+			rhs:      5 * time.Minute,
+			expected: start,
+		},
+		{
+			name:     "duration plus time",
+			lhs:      5 * time.Minute,
+			op:       ADD,
+			rhs:      start,
+			expected: end,
+		},
+		{
+			name:     "duration plus duration",
+			lhs:      time.Minute,
+			op:       ADD,
+			rhs:      time.Second,
+			expected: 61 * time.Second,
+		},
+		{
+			name:     "duration minus duration",
+			lhs:      time.Minute,
+			op:       SUB,
+			rhs:      time.Second,
+			expected: 59 * time.Second,
+		},
+		{
+			name:     "duration times int",
+			lhs:      time.Minute,
+			op:       MULT,
+			rhs:      int64(3),
+			expected: 3 * time.Minute,
+		},
+		{
+			name:     "duration divided by float",
+			lhs:      time.Minute,
+			op:       DIV,
+			rhs:      float64(4),
+			expected: 15 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := attemptMathOperation[any](&literal[any]{value: tt.lhs}, tt.op, &literal[any]{value: tt.rhs})
+			result, err := getter.Get(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_attemptMathOperation_timeAndDuration_error(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		lhs  interface{}
+		op   mathOp
+		rhs  interface{}
+	}{
+		{
+			name: "time plus time",
+			lhs:  now,
+			op:   ADD,
+			rhs:  now,
+		},
+		{
+			name: "time times duration",
+			lhs:  now,
+			op:   MULT,
+			rhs:  time.Second,
+		},
+		{
+			name: "time plus int",
+			lhs:  now,
+			op:   ADD,
+			rhs:  int64(1),
+		},
+		{
+			name: "duration minus time",
+			lhs:  time.Second,
+			op:   SUB,
+			rhs:  now,
+		},
+		{
+			name: "duration times duration",
+			lhs:  time.Second,
+			op:   MULT,
+			rhs:  time.Second,
+		},
+		{
+			name: "duration plus int",
+			lhs:  time.Second,
+			op:   ADD,
+			rhs:  int64(1),
+		},
+		{
+			name: "duration divided by zero",
+			lhs:  time.Second,
+			op:   DIV,
+			rhs:  int64(0),
+		},
+		{
+			name: "int plus duration",
+			lhs:  int64(1),
+			op:   ADD,
+			rhs:  time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := attemptMathOperation[any](&literal[any]{value: tt.lhs}, tt.op, &literal[any]{value: tt.rhs})
+			result, err := getter.Get(context.Background(), nil)
+			assert.Nil(t, result)
+			assert.Error(t, err)
+		})
+	}
+}
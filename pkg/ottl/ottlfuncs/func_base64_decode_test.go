@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Base64Decode(t *testing.T) {
+	result, err := base64Decode[any](literalStringGetter("aGVsbG8gd29ybGQ="))(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", result)
+}
+
+func Test_Base64Decode_error(t *testing.T) {
+	_, err := base64Decode[any](literalStringGetter("not base64!"))(context.Background(), nil)
+	assert.ErrorContains(t, err, "unable to decode base64 value")
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Duration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration string
+		expected time.Duration
+	}{
+		{
+			name:     "minutes",
+			duration: "5m",
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "compound",
+			duration: "1h15m30.5s",
+			expected: time.Hour + 15*time.Minute + 30500*time.Millisecond,
+		},
+		{
+			name:     "negative",
+			duration: "-300ms",
+			expected: -300 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := duration[any](literalStringGetter(tt.duration))(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Duration_error(t *testing.T) {
+	for _, d := range []string{"", "5", "five minutes"} {
+		_, err := duration[any](literalStringGetter(d))(context.Background(), nil)
+		assert.Error(t, err, d)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type FNVArguments[K any] struct {
+	Target ottl.StringGetter[K] `ottlarg:"0"`
+}
+
+func NewFNVFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("FNV", &FNVArguments[K]{}, createFNVFunction[K])
+}
+
+func createFNVFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FNVArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("FNVFactory args must be of type *FNVArguments[K]")
+	}
+
+	return fnvHash(args.Target), nil
+}
+
+// fnvHash returns the 64-bit FNV-1a hash of the target string as an int64.
+func fnvHash[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		h := fnv.New64a()
+		// hash.Hash.Write never returns an error.
+		_, _ = h.Write([]byte(val))
+		return int64(h.Sum64()), nil
+	}
+}
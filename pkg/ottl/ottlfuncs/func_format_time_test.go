@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_FormatTime(t *testing.T) {
+	ts := time.Date(2023, 4, 12, 15, 4, 5, 123000000, time.UTC)
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:     "date",
+			format:   "%Y-%m-%d",
+			expected: "2023-04-12",
+		},
+		{
+			name:     "date and time with milliseconds",
+			format:   "%d/%b/%Y:%H:%M:%S.%L %z",
+			expected: "12/Apr/2023:15:04:05.123 +0000",
+		},
+		{
+			name:     "weekday",
+			format:   "%A %T",
+			expected: "Wednesday 15:04:05",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardTypeGetter[any, time.Time]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return ts, nil
+				},
+			}
+			exprFunc, err := formatTime[any](target, tt.format)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_FormatTime_error(t *testing.T) {
+	target := ottl.StandardTypeGetter[any, time.Time]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return "2023-04-12", nil
+		},
+	}
+	exprFunc, err := formatTime[any](target, "%Y")
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+
+	_, err = formatTime[any](target, "")
+	assert.Error(t, err)
+
+	_, err = formatTime[any](target, "%Y-%K")
+	assert.Error(t, err)
+}
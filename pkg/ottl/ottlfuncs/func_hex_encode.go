@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type HexEncodeArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewHexEncodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("HexEncode", &HexEncodeArguments[K]{}, createHexEncodeFunction[K])
+}
+
+func createHexEncodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*HexEncodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("HexEncodeFactory args must be of type *HexEncodeArguments[K]")
+	}
+
+	return hexEncode(args.Target), nil
+}
+
+// hexEncode returns the lowercase hex encoding of a string or byte slice.
+func hexEncode[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		b, err := toBytes(val)
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(b), nil
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type HMACArguments[K any] struct {
+	Target  ottl.StringGetter[K] `ottlarg:"0"`
+	KeyName string               `ottlarg:"1"`
+}
+
+// NewHMACFactory returns the factory of the HMAC Converter, which looks up its secret by name in keys.
+// The keys come from the configuration of the component so that they never appear in the statements,
+// which are logged and included in the errors.
+func NewHMACFactory[K any](keys map[string]string) ottl.Factory[K] {
+	return ottl.NewFactory("HMAC", &HMACArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		return createHMACFunction[K](keys, oArgs)
+	})
+}
+
+func createHMACFunction[K any](keys map[string]string, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*HMACArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("HMACFactory args must be of type *HMACArguments[K]")
+	}
+
+	key, ok := keys[args.KeyName]
+	if !ok {
+		return nil, fmt.Errorf("unknown HMAC key %q", args.KeyName)
+	}
+	if key == "" {
+		return nil, fmt.Errorf("HMAC key %q cannot be empty", args.KeyName)
+	}
+
+	return hmacSHA256(args.Target, []byte(key)), nil
+}
+
+// hmacSHA256 returns the hex encoded HMAC-SHA256 of the target string using key as the secret.
+func hmacSHA256[K any](target ottl.StringGetter[K], key []byte) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		mac := hmac.New(sha256.New, key)
+		// hash.Hash.Write never returns an error.
+		_, _ = mac.Write([]byte(val))
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	}
+}
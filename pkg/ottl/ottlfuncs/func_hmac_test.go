@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HMAC(t *testing.T) {
+	result, err := hmacSHA256[any](literalStringGetter("hello world"), []byte("secret"))(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "734cc62f32841568f45715aeb9f4d7891324e6d948e4c6c60c0621cdac48623a", result)
+}
+
+func Test_createHMACFunction(t *testing.T) {
+	keys := map[string]string{
+		"pii":   "secret",
+		"empty": "",
+	}
+	tests := []struct {
+		name          string
+		keyName       string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "known key",
+			keyName:  "pii",
+			expected: "734cc62f32841568f45715aeb9f4d7891324e6d948e4c6c60c0621cdac48623a",
+		},
+		{
+			name:          "unknown key",
+			keyName:       "missing",
+			expectedError: `unknown HMAC key "missing"`,
+		},
+		{
+			name:          "empty key",
+			keyName:       "empty",
+			expectedError: `HMAC key "empty" cannot be empty`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := createHMACFunction[any](keys, &HMACArguments[any]{
+				Target:  literalStringGetter("hello world"),
+				KeyName: tt.keyName,
+			})
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
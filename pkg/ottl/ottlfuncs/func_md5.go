@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"crypto/md5" // #nosec G501 -- MD5 is offered for compatibility with existing join keys, not for security
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type MD5Arguments[K any] struct {
+	Target ottl.StringGetter[K] `ottlarg:"0"`
+}
+
+func NewMD5Factory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("MD5", &MD5Arguments[K]{}, createMD5Function[K])
+}
+
+func createMD5Function[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MD5Arguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("MD5Factory args must be of type *MD5Arguments[K]")
+	}
+
+	return md5Hash(args.Target), nil
+}
+
+// md5Hash returns the hex encoded MD5 digest of the target string.
+func md5Hash[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		sum := md5.Sum([]byte(val))
+		return hex.EncodeToString(sum[:]), nil
+	}
+}
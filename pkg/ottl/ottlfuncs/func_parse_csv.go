@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	parseCSVModeStrict       = "strict"
+	parseCSVModeLazyQuotes   = "lazyQuotes"
+	parseCSVModeIgnoreQuotes = "ignoreQuotes"
+)
+
+type ParseCSVArguments[K any] struct {
+	Target          ottl.StringGetter[K] `ottlarg:"0"`
+	Header          ottl.StringGetter[K] `ottlarg:"1"`
+	Delimiter       string               `ottlarg:"2"`
+	HeaderDelimiter string               `ottlarg:"3"`
+	Mode            string               `ottlarg:"4"`
+}
+
+func NewParseCSVFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseCSV", &ParseCSVArguments[K]{}, createParseCSVFunction[K])
+}
+
+func createParseCSVFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseCSVArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseCSVFactory args must be of type *ParseCSVArguments[K]")
+	}
+
+	return parseCSV(args.Target, args.Header, args.Delimiter, args.HeaderDelimiter, args.Mode)
+}
+
+// parseCSV returns a `pcommon.Map` that maps each header field to the corresponding field of the target row.
+// The parsing rules are the same as the ones of the stanza csv_parser operator.
+func parseCSV[K any](target ottl.StringGetter[K], header ottl.StringGetter[K], delimiter string, headerDelimiter string, mode string) (ottl.ExprFunc[K], error) {
+	if delimiter == "" {
+		delimiter = ","
+	}
+	if headerDelimiter == "" {
+		headerDelimiter = delimiter
+	}
+	if utf8.RuneCountInString(delimiter) != 1 {
+		return nil, fmt.Errorf("invalid delimiter: %q, must be a single character", delimiter)
+	}
+	if utf8.RuneCountInString(headerDelimiter) != 1 {
+		return nil, fmt.Errorf("invalid header delimiter: %q, must be a single character", headerDelimiter)
+	}
+
+	var parseRow func(row string, fieldCount int) ([]string, error)
+	switch mode {
+	case parseCSVModeStrict, "":
+		parseRow = csvRowParser([]rune(delimiter)[0], false)
+	case parseCSVModeLazyQuotes:
+		parseRow = csvRowParser([]rune(delimiter)[0], true)
+	case parseCSVModeIgnoreQuotes:
+		parseRow = func(row string, _ int) ([]string, error) {
+			return strings.Split(row, delimiter), nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid mode: %s, allowed modes are: %s, %s, %s", mode, parseCSVModeStrict, parseCSVModeLazyQuotes, parseCSVModeIgnoreQuotes)
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		headerVal, err := header.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if headerVal == "" {
+			return nil, fmt.Errorf("header cannot be empty")
+		}
+		headers := strings.Split(headerVal, headerDelimiter)
+
+		targetVal, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := parseRow(targetVal, len(headers))
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) != len(headers) {
+			return nil, fmt.Errorf("wrong number of fields: expected %d, found %d", len(headers), len(fields))
+		}
+
+		result := pcommon.NewMap()
+		result.EnsureCapacity(len(headers))
+		for i, field := range fields {
+			result.PutStr(headers[i], field)
+		}
+		return result, nil
+	}, nil
+}
+
+// csvRowParser returns a function that parses a single CSV row with the given delimiter.
+func csvRowParser(delimiter rune, lazyQuotes bool) func(string, int) ([]string, error) {
+	return func(row string, fieldCount int) ([]string, error) {
+		fields, err := parseutils.ReadCSVRow(row, delimiter, lazyQuotes, fieldCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse csv row: %w", err)
+		}
+		return fields, nil
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_ParseCSV(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          string
+		header          string
+		delimiter       string
+		headerDelimiter string
+		mode            string
+		want            map[string]interface{}
+	}{
+		{
+			name:   "default delimiter",
+			target: "stanza,INFO,started",
+			header: "name,sev,msg",
+			want:   map[string]interface{}{"name": "stanza", "sev": "INFO", "msg": "started"},
+		},
+		{
+			name:            "custom delimiters",
+			target:          "stanza\tINFO\tstarted",
+			header:          "name|sev|msg",
+			delimiter:       "\t",
+			headerDelimiter: "|",
+			mode:            "strict",
+			want:            map[string]interface{}{"name": "stanza", "sev": "INFO", "msg": "started"},
+		},
+		{
+			name:   "quoted field",
+			target: `stanza,"INFO, really",started`,
+			header: "name,sev,msg",
+			want:   map[string]interface{}{"name": "stanza", "sev": "INFO, really", "msg": "started"},
+		},
+		{
+			name:   "line break in quoted field",
+			target: "stanza,\"multi\nline\",started",
+			header: "name,sev,msg",
+			want:   map[string]interface{}{"name": "stanza", "sev": "multi\nline", "msg": "started"},
+		},
+		{
+			name:   "lazy quotes",
+			target: `stanza,IN"FO,started`,
+			header: "name,sev,msg",
+			mode:   "lazyQuotes",
+			want:   map[string]interface{}{"name": "stanza", "sev": `IN"FO`, "msg": "started"},
+		},
+		{
+			name:   "ignore quotes",
+			target: `"stanza,INFO",started`,
+			header: "name,sev,msg",
+			mode:   "ignoreQuotes",
+			want:   map[string]interface{}{"name": `"stanza`, "sev": `INFO"`, "msg": "started"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := parseCSV[any](literalStringGetter(tt.target), literalStringGetter(tt.header), tt.delimiter, tt.headerDelimiter, tt.mode)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseCSV_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		header string
+		mode   string
+	}{
+		{
+			name:   "too few fields",
+			target: "stanza,INFO",
+			header: "name,sev,msg",
+		},
+		{
+			name:   "too many fields",
+			target: "stanza,INFO,started,now",
+			header: "name,sev,msg",
+			mode:   "ignoreQuotes",
+		},
+		{
+			name:   "bare quote",
+			target: `stanza,IN"FO,started`,
+			header: "name,sev,msg",
+		},
+		{
+			name:   "empty header",
+			target: "stanza",
+			header: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := parseCSV[any](literalStringGetter(tt.target), literalStringGetter(tt.header), "", "", tt.mode)
+			require.NoError(t, err)
+			_, err = exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_ParseCSV_invalid_args(t *testing.T) {
+	_, err := parseCSV[any](literalStringGetter(""), literalStringGetter(""), ";;", "", "")
+	assert.Error(t, err)
+
+	_, err = parseCSV[any](literalStringGetter(""), literalStringGetter(""), ",", "||", "")
+	assert.Error(t, err)
+
+	_, err = parseCSV[any](literalStringGetter(""), literalStringGetter(""), ",", "", "relaxed")
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseKeyValueArguments[K any] struct {
+	Target        ottl.StringGetter[K] `ottlarg:"0"`
+	Delimiter     string               `ottlarg:"1"`
+	PairDelimiter string               `ottlarg:"2"`
+}
+
+func NewParseKeyValueFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseKeyValue", &ParseKeyValueArguments[K]{}, createParseKeyValueFunction[K])
+}
+
+func createParseKeyValueFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseKeyValueArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseKeyValueFactory args must be of type *ParseKeyValueArguments[K]")
+	}
+
+	return parseKeyValue(args.Target, args.Delimiter, args.PairDelimiter)
+}
+
+// parseKeyValue returns a `pcommon.Map` holding the key value pairs found in the target string.
+// The splitting rules are the same as the ones of the stanza key_value_parser operator.
+func parseKeyValue[K any](target ottl.StringGetter[K], delimiter string, pairDelimiter string) (ottl.ExprFunc[K], error) {
+	if delimiter == "" {
+		return nil, fmt.Errorf("delimiter cannot be empty")
+	}
+	if delimiter == pairDelimiter {
+		return nil, fmt.Errorf("delimiter and pair delimiter cannot be the same: %q", delimiter)
+	}
+
+	splitPairs := parseutils.SplitStringByWhitespace
+	if pairDelimiter != "" {
+		splitPairs = func(input string) []string {
+			return strings.Split(input, pairDelimiter)
+		}
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if source == "" {
+			return nil, fmt.Errorf("cannot parse key value pairs from an empty string")
+		}
+
+		pairs, err := parseutils.ParseKeyValuePairs(splitPairs(source), delimiter)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		result.EnsureCapacity(len(pairs))
+		for key, value := range pairs {
+			result.PutStr(key, value)
+		}
+		return result, nil
+	}, nil
+}
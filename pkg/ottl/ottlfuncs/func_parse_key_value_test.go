@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// literalStringGetter returns a StringGetter that always returns s.
+func literalStringGetter(s string) ottl.StandardTypeGetter[any, string] {
+	return ottl.StandardTypeGetter[any, string]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return s, nil
+		},
+	}
+}
+
+func Test_ParseKeyValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		delimiter     string
+		pairDelimiter string
+		want          map[string]interface{}
+	}{
+		{
+			name:      "whitespace separated pairs",
+			target:    "name=stanza age=2 job=parser",
+			delimiter: "=",
+			want:      map[string]interface{}{"name": "stanza", "age": "2", "job": "parser"},
+		},
+		{
+			name:      "quoted values keep their spaces",
+			target:    `msg="hello world" level='info'`,
+			delimiter: "=",
+			want:      map[string]interface{}{"msg": "hello world", "level": "info"},
+		},
+		{
+			name:          "custom delimiters",
+			target:        "name:stanza|age:2",
+			delimiter:     ":",
+			pairDelimiter: "|",
+			want:          map[string]interface{}{"name": "stanza", "age": "2"},
+		},
+		{
+			name:          "surrounding spaces are trimmed",
+			target:        "name = stanza ; age = 2",
+			delimiter:     "=",
+			pairDelimiter: ";",
+			want:          map[string]interface{}{"name": "stanza", "age": "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := parseKeyValue[any](literalStringGetter(tt.target), tt.delimiter, tt.pairDelimiter)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseKeyValue_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{
+			name:   "empty target",
+			target: "",
+		},
+		{
+			name:   "missing delimiter",
+			target: "name=stanza age",
+		},
+		{
+			name:   "delimiter repeated",
+			target: "name=stanza=parser",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := parseKeyValue[any](literalStringGetter(tt.target), "=", "")
+			require.NoError(t, err)
+			_, err = exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_ParseKeyValue_invalid_args(t *testing.T) {
+	_, err := parseKeyValue[any](literalStringGetter("a=b"), "", "")
+	assert.Error(t, err)
+
+	_, err = parseKeyValue[any](literalStringGetter("a=b"), "=", "=")
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseLogfmtArguments[K any] struct {
+	Target ottl.StringGetter[K] `ottlarg:"0"`
+}
+
+func NewParseLogfmtFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseLogfmt", &ParseLogfmtArguments[K]{}, createParseLogfmtFunction[K])
+}
+
+func createParseLogfmtFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseLogfmtArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseLogfmtFactory args must be of type *ParseLogfmtArguments[K]")
+	}
+
+	return parseLogfmt(args.Target), nil
+}
+
+// parseLogfmt returns a `pcommon.Map` holding the `key=value` pairs of a logfmt formatted string.
+// Values are strings, double quoted values are unquoted and keys without a value are set to true.
+// If a key appears more than once, the last value wins.
+func parseLogfmt[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		targetVal, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		rest := targetVal
+		for {
+			rest = strings.TrimLeft(rest, " \t\r\n")
+			if rest == "" {
+				return result, nil
+			}
+
+			end := strings.IndexAny(rest, "= \t\r\n\"")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("unexpected %q at position %d, expected a key", rest[0], len(targetVal)-len(rest))
+			}
+			key := rest[:end]
+			rest = rest[end:]
+
+			if !strings.HasPrefix(rest, "=") {
+				if strings.HasPrefix(rest, "\"") {
+					return nil, fmt.Errorf("unexpected '\"' in key %q", key)
+				}
+				result.PutBool(key, true)
+				continue
+			}
+			rest = rest[1:]
+
+			var value string
+			if strings.HasPrefix(rest, "\"") {
+				quoted, err := strconv.QuotedPrefix(rest)
+				if err != nil {
+					return nil, fmt.Errorf("invalid quoted value for key %q: %w", key, err)
+				}
+				value, err = strconv.Unquote(quoted)
+				if err != nil {
+					return nil, fmt.Errorf("invalid quoted value for key %q: %w", key, err)
+				}
+				rest = rest[len(quoted):]
+			} else {
+				end = strings.IndexAny(rest, " \t\r\n")
+				if end == -1 {
+					end = len(rest)
+				}
+				value = rest[:end]
+				if strings.ContainsAny(value, "=\"") {
+					return nil, fmt.Errorf("unexpected character in unquoted value %q of key %q", value, key)
+				}
+				rest = rest[end:]
+			}
+			result.PutStr(key, value)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_ParseLogfmt(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   map[string]interface{}
+	}{
+		{
+			name:   "simple pairs",
+			target: "level=info duration=12ms path=/api",
+			want:   map[string]interface{}{"level": "info", "duration": "12ms", "path": "/api"},
+		},
+		{
+			name:   "quoted values",
+			target: `msg="user \"bob\" logged in" empty=""`,
+			want:   map[string]interface{}{"msg": `user "bob" logged in`, "empty": ""},
+		},
+		{
+			name:   "keys without value",
+			target: "  cached  level=debug retry",
+			want:   map[string]interface{}{"cached": true, "level": "debug", "retry": true},
+		},
+		{
+			name:   "key without value after equals",
+			target: "level= msg=ok",
+			want:   map[string]interface{}{"level": "", "msg": "ok"},
+		},
+		{
+			name:   "last value wins",
+			target: "a=1 a=2",
+			want:   map[string]interface{}{"a": "2"},
+		},
+		{
+			name:   "empty string",
+			target: "",
+			want:   map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseLogfmt[any](literalStringGetter(tt.target))
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseLogfmt_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{
+			name:   "missing key",
+			target: "=value",
+		},
+		{
+			name:   "unterminated quote",
+			target: `msg="hello`,
+		},
+		{
+			name:   "quote in key",
+			target: `ms"g=hello`,
+		},
+		{
+			name:   "equals in unquoted value",
+			target: "a=b=c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseLogfmt[any](literalStringGetter(tt.target))
+			_, err := exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
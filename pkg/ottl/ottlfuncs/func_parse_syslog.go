@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3"
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	syslogRFC3164 = "rfc3164"
+	syslogRFC5424 = "rfc5424"
+)
+
+// syslogNow allows tests to override the clock used to complete RFC 3164 timestamps.
+var syslogNow = time.Now
+
+type ParseSyslogArguments[K any] struct {
+	Target   ottl.StringGetter[K] `ottlarg:"0"`
+	Protocol string               `ottlarg:"1"`
+	Location string               `ottlarg:"2"`
+}
+
+func NewParseSyslogFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseSyslog", &ParseSyslogArguments[K]{}, createParseSyslogFunction[K])
+}
+
+func createParseSyslogFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseSyslogArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseSyslogFactory args must be of type *ParseSyslogArguments[K]")
+	}
+
+	return parseSyslog(args.Target, args.Protocol, args.Location)
+}
+
+// parseSyslog returns a `pcommon.Map` holding the fields of an RFC 3164 or RFC 5424 syslog message.
+// The keys are the same as the ones set by the stanza syslog_parser operator, except that the
+// timestamp is stored as nanoseconds since the Unix epoch.
+func parseSyslog[K any](target ottl.StringGetter[K], protocol string, location string) (ottl.ExprFunc[K], error) {
+	if location == "" {
+		location = "UTC"
+	}
+	loc, err := time.LoadLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location %s: %w", location, err)
+	}
+
+	var parse func([]byte) (pcommon.Map, error)
+	switch protocol {
+	case syslogRFC3164:
+		parse = func(input []byte) (pcommon.Map, error) {
+			msg, err := rfc3164.NewMachine(rfc3164.WithLocaleTimezone(loc)).Parse(input)
+			if err != nil {
+				return pcommon.Map{}, err
+			}
+			m := msg.(*rfc3164.SyslogMessage)
+			if m.Timestamp != nil {
+				ts := parseutils.SetTimestampYear(*m.Timestamp, syslogNow())
+				m.Timestamp = &ts
+			}
+			result := pcommon.NewMap()
+			putSyslogBase(result, &m.Base)
+			return result, nil
+		}
+	case syslogRFC5424:
+		parse = func(input []byte) (pcommon.Map, error) {
+			msg, err := rfc5424.NewMachine().Parse(input)
+			if err != nil {
+				return pcommon.Map{}, err
+			}
+			m := msg.(*rfc5424.SyslogMessage)
+			result := pcommon.NewMap()
+			putSyslogBase(result, &m.Base)
+			result.PutInt("version", int64(m.Version))
+			if m.StructuredData != nil {
+				sd := result.PutEmptyMap("structured_data")
+				for id, params := range *m.StructuredData {
+					elem := sd.PutEmptyMap(id)
+					for name, value := range params {
+						elem.PutStr(name, value)
+					}
+				}
+			}
+			return result, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid protocol: %s, allowed protocols are: %s, %s", protocol, syslogRFC3164, syslogRFC5424)
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		targetVal, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return parse([]byte(targetVal))
+	}, nil
+}
+
+// putSyslogBase stores the fields shared by both syslog protocols, leaving out the ones that are not set.
+func putSyslogBase(m pcommon.Map, base *syslog.Base) {
+	if base.Timestamp != nil {
+		m.PutInt("timestamp", base.Timestamp.UnixNano())
+	}
+	putSyslogUint8(m, "priority", base.Priority)
+	putSyslogUint8(m, "facility", base.Facility)
+	putSyslogUint8(m, "severity", base.Severity)
+	putSyslogString(m, "hostname", base.Hostname)
+	putSyslogString(m, "appname", base.Appname)
+	putSyslogString(m, "proc_id", base.ProcID)
+	putSyslogString(m, "msg_id", base.MsgID)
+	putSyslogString(m, "message", base.Message)
+}
+
+func putSyslogUint8(m pcommon.Map, key string, val *uint8) {
+	if val != nil {
+		m.PutInt(key, int64(*val))
+	}
+}
+
+func putSyslogString(m pcommon.Map, key string, val *string) {
+	if val != nil {
+		m.PutStr(key, *val)
+	}
+}
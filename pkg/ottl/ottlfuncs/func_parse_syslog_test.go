@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_ParseSyslog(t *testing.T) {
+	syslogNow = func() time.Time { return time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { syslogNow = time.Now }()
+
+	tests := []struct {
+		name     string
+		target   string
+		protocol string
+		location string
+		want     map[string]interface{}
+	}{
+		{
+			name:     "rfc3164",
+			target:   "<34>Jan 12 06:30:00 1.2.3.4 apache_server: test message",
+			protocol: "rfc3164",
+			location: "America/New_York",
+			want: map[string]interface{}{
+				"timestamp": time.Date(2023, time.January, 12, 11, 30, 0, 0, time.UTC).UnixNano(),
+				"priority":  int64(34),
+				"facility":  int64(4),
+				"severity":  int64(2),
+				"hostname":  "1.2.3.4",
+				"appname":   "apache_server",
+				"message":   "test message",
+			},
+		},
+		{
+			name:     "rfc5424",
+			target:   `<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 UserHostAddress="192.168.2.132" Realm="SecureAuth0"] Found the user for retrieving user's profile`,
+			protocol: "rfc5424",
+			want: map[string]interface{}{
+				"timestamp": time.Date(2015, time.August, 5, 21, 58, 59, 693000000, time.UTC).UnixNano(),
+				"priority":  int64(86),
+				"facility":  int64(10),
+				"severity":  int64(6),
+				"hostname":  "192.168.2.132",
+				"appname":   "SecureAuth0",
+				"proc_id":   "23108",
+				"msg_id":    "ID52020",
+				"message":   "Found the user for retrieving user's profile",
+				"version":   int64(1),
+				"structured_data": map[string]interface{}{
+					"SecureAuth@27389": map[string]interface{}{
+						"UserHostAddress": "192.168.2.132",
+						"Realm":           "SecureAuth0",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := parseSyslog[any](literalStringGetter(tt.target), tt.protocol, tt.location)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseSyslog_error(t *testing.T) {
+	exprFunc, err := parseSyslog[any](literalStringGetter("not a syslog message"), "rfc5424", "")
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func Test_ParseSyslog_invalid_args(t *testing.T) {
+	_, err := parseSyslog[any](literalStringGetter(""), "rfc6587", "")
+	assert.Error(t, err)
+
+	_, err = parseSyslog[any](literalStringGetter(""), "rfc3164", "Nowhere/Special")
+	assert.Error(t, err)
+}
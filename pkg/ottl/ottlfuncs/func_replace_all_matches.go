@@ -25,9 +25,9 @@ import (
 )
 
 type ReplaceAllMatchesArguments[K any] struct {
-	Target      ottl.PMapGetter[K] `ottlarg:"0"`
-	Pattern     string             `ottlarg:"1"`
-	Replacement string             `ottlarg:"2"`
+	Target      ottl.PMapGetter[K]    `ottlarg:"0"`
+	Pattern     string                `ottlarg:"1"`
+	Replacement ottl.ElementGetter[K] `ottlarg:"2"`
 }
 
 func NewReplaceAllMatchesFactory[K any]() ottl.Factory[K] {
@@ -44,7 +44,7 @@ func createReplaceAllMatchesFunction[K any](_ ottl.FunctionContext, oArgs ottl.A
 	return replaceAllMatches(args.Target, args.Pattern, args.Replacement)
 }
 
-func replaceAllMatches[K any](target ottl.PMapGetter[K], pattern string, replacement string) (ottl.ExprFunc[K], error) {
+func replaceAllMatches[K any](target ottl.PMapGetter[K], pattern string, replacement ottl.ElementGetter[K]) (ottl.ExprFunc[K], error) {
 	glob, err := glob.Compile(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("the pattern supplied to replace_match is not a valid pattern: %w", err)
@@ -54,12 +54,23 @@ func replaceAllMatches[K any](target ottl.PMapGetter[K], pattern string, replace
 		if err != nil {
 			return nil, err
 		}
+		var replaceErr error
 		val.Range(func(key string, value pcommon.Value) bool {
 			if glob.Match(value.Str()) {
-				value.SetStr(replacement)
+				replacementVal, err := replacement.Get(ottl.WithElement(ctx, key, value.Str()), tCtx)
+				if err != nil {
+					replaceErr = err
+					return false
+				}
+				replacementStr, ok := replacementVal.(string)
+				if !ok {
+					replaceErr = fmt.Errorf("replacement must be a string but got %T", replacementVal)
+					return false
+				}
+				value.SetStr(replacementStr)
 			}
 			return true
 		})
-		return nil, nil
+		return nil, replaceErr
 	}, nil
 }
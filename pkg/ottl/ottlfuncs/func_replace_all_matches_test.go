@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -71,10 +72,10 @@ func Test_replaceAllMatches(t *testing.T) {
 			scenarioMap := pcommon.NewMap()
 			input.CopyTo(scenarioMap)
 
-			exprFunc, err := replaceAllMatches(tt.target, tt.pattern, tt.replacement)
+			exprFunc, err := replaceAllMatches(tt.target, tt.pattern, literalElementGetter[pcommon.Map](tt.replacement))
 			assert.NoError(t, err)
 
-			result, err := exprFunc(nil, scenarioMap)
+			result, err := exprFunc(context.Background(), scenarioMap)
 			assert.NoError(t, err)
 			assert.Nil(t, result)
 
@@ -94,9 +95,9 @@ func Test_replaceAllMatches_bad_input(t *testing.T) {
 		},
 	}
 
-	exprFunc, err := replaceAllMatches[interface{}](target, "*", "{replacement}")
+	exprFunc, err := replaceAllMatches[interface{}](target, "*", literalElementGetter[interface{}]("{replacement}"))
 	assert.NoError(t, err)
-	_, err = exprFunc(nil, input)
+	_, err = exprFunc(context.Background(), input)
 	assert.Error(t, err)
 }
 
@@ -107,8 +108,22 @@ func Test_replaceAllMatches_get_nil(t *testing.T) {
 		},
 	}
 
-	exprFunc, err := replaceAllMatches[interface{}](target, "*", "{anything}")
+	exprFunc, err := replaceAllMatches[interface{}](target, "*", literalElementGetter[interface{}]("{anything}"))
 	assert.NoError(t, err)
-	_, err = exprFunc(nil, nil)
+	_, err = exprFunc(context.Background(), nil)
 	assert.Error(t, err)
 }
+
+func Test_replaceAllMatches_element(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("email", "alice@example.com")
+	input.PutStr("other", "unchanged")
+
+	_, _, err := parseElementStatement(t, `replace_all_matches(target, "*@example.com", Concat([$key, SHA256($value)], ":"))`).Execute(context.Background(), input)
+	require.NoError(t, err)
+
+	expected := pcommon.NewMap()
+	expected.PutStr("email", "email:ff8d9819fc0e12bf0d24892e45987e249a28dce836a85cad60e28eaaa8c6d976")
+	expected.PutStr("other", "unchanged")
+	assert.Equal(t, expected, input)
+}
@@ -30,10 +30,10 @@ const (
 )
 
 type ReplaceAllPatternsArguments[K any] struct {
-	Target       ottl.PMapGetter[K] `ottlarg:"0"`
-	Mode         string             `ottlarg:"1"`
-	RegexPattern string             `ottlarg:"2"`
-	Replacement  string             `ottlarg:"3"`
+	Target       ottl.PMapGetter[K]    `ottlarg:"0"`
+	Mode         string                `ottlarg:"1"`
+	RegexPattern string                `ottlarg:"2"`
+	Replacement  ottl.ElementGetter[K] `ottlarg:"3"`
 }
 
 func NewReplaceAllPatternsFactory[K any]() ottl.Factory[K] {
@@ -50,7 +50,7 @@ func createReplaceAllPatternsFunction[K any](_ ottl.FunctionContext, oArgs ottl.
 	return replaceAllPatterns(args.Target, args.Mode, args.RegexPattern, args.Replacement)
 }
 
-func replaceAllPatterns[K any](target ottl.PMapGetter[K], mode string, regexPattern string, replacement string) (ottl.ExprFunc[K], error) {
+func replaceAllPatterns[K any](target ottl.PMapGetter[K], mode string, regexPattern string, replacement ottl.ElementGetter[K]) (ottl.ExprFunc[K], error) {
 	compiledPattern, err := regexp.Compile(regexPattern)
 	if err != nil {
 		return nil, fmt.Errorf("the regex pattern supplied to replace_all_patterns is not a valid pattern: %w", err)
@@ -58,26 +58,36 @@ func replaceAllPatterns[K any](target ottl.PMapGetter[K], mode string, regexPatt
 	if mode != modeValue && mode != modeKey {
 		return nil, fmt.Errorf("invalid mode %v, must be either 'key' or 'value'", mode)
 	}
+	expand := ottl.IsLiteral[K](replacement)
 
 	return func(ctx context.Context, tCtx K) (interface{}, error) {
 		val, err := target.Get(ctx, tCtx)
 		if err != nil {
 			return nil, err
 		}
+		var replaceErr error
 		updated := pcommon.NewMap()
 		updated.EnsureCapacity(val.Len())
 		val.Range(func(key string, originalValue pcommon.Value) bool {
 			switch mode {
 			case modeValue:
 				if compiledPattern.MatchString(originalValue.Str()) {
-					updatedString := compiledPattern.ReplaceAllString(originalValue.Str(), replacement)
+					updatedString, err := replaceAllSubmatches(ctx, tCtx, compiledPattern, originalValue.Str(), key, replacement, expand)
+					if err != nil {
+						replaceErr = err
+						return false
+					}
 					updated.PutStr(key, updatedString)
 				} else {
 					originalValue.CopyTo(updated.PutEmpty(key))
 				}
 			case modeKey:
 				if compiledPattern.MatchString(key) {
-					updatedKey := compiledPattern.ReplaceAllLiteralString(key, replacement)
+					updatedKey, err := replaceAllSubmatches(ctx, tCtx, compiledPattern, key, key, replacement, false)
+					if err != nil {
+						replaceErr = err
+						return false
+					}
 					originalValue.CopyTo(updated.PutEmpty(updatedKey))
 				} else {
 					originalValue.CopyTo(updated.PutEmpty(key))
@@ -85,6 +95,9 @@ func replaceAllPatterns[K any](target ottl.PMapGetter[K], mode string, regexPatt
 			}
 			return true
 		})
+		if replaceErr != nil {
+			return nil, replaceErr
+		}
 		updated.CopyTo(val)
 		return nil, nil
 	}, nil
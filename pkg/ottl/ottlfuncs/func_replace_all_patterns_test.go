@@ -179,10 +179,10 @@ func Test_replaceAllPatterns(t *testing.T) {
 			scenarioMap := pcommon.NewMap()
 			input.CopyTo(scenarioMap)
 
-			exprFunc, err := replaceAllPatterns[pcommon.Map](tt.target, tt.mode, tt.pattern, tt.replacement)
+			exprFunc, err := replaceAllPatterns[pcommon.Map](tt.target, tt.mode, tt.pattern, literalElementGetter[pcommon.Map](tt.replacement))
 			assert.NoError(t, err)
 
-			_, err = exprFunc(nil, scenarioMap)
+			_, err = exprFunc(context.Background(), scenarioMap)
 			assert.Nil(t, err)
 
 			expected := pcommon.NewMap()
@@ -202,10 +202,10 @@ func Test_replaceAllPatterns_bad_input(t *testing.T) {
 		},
 	}
 
-	exprFunc, err := replaceAllPatterns[interface{}](target, modeValue, "regexpattern", "{replacement}")
+	exprFunc, err := replaceAllPatterns[interface{}](target, modeValue, "regexpattern", literalElementGetter[interface{}]("{replacement}"))
 	assert.Nil(t, err)
 
-	_, err = exprFunc(nil, input)
+	_, err = exprFunc(context.Background(), input)
 	assert.Error(t, err)
 }
 
@@ -216,10 +216,10 @@ func Test_replaceAllPatterns_get_nil(t *testing.T) {
 		},
 	}
 
-	exprFunc, err := replaceAllPatterns[interface{}](target, modeValue, "regexp", "{anything}")
+	exprFunc, err := replaceAllPatterns[interface{}](target, modeValue, "regexp", literalElementGetter[interface{}]("{anything}"))
 	assert.NoError(t, err)
 
-	_, err = exprFunc(nil, nil)
+	_, err = exprFunc(context.Background(), nil)
 	assert.Error(t, err)
 }
 
@@ -232,7 +232,7 @@ func Test_replaceAllPatterns_invalid_pattern(t *testing.T) {
 	}
 
 	invalidRegexPattern := "*"
-	exprFunc, err := replaceAllPatterns[interface{}](target, modeValue, invalidRegexPattern, "{anything}")
+	exprFunc, err := replaceAllPatterns[interface{}](target, modeValue, invalidRegexPattern, literalElementGetter[interface{}]("{anything}"))
 	require.Error(t, err)
 	assert.ErrorContains(t, err, "error parsing regexp:")
 	assert.Nil(t, exprFunc)
@@ -247,7 +247,46 @@ func Test_replaceAllPatterns_invalid_model(t *testing.T) {
 	}
 
 	invalidMode := "invalid"
-	exprFunc, err := replaceAllPatterns[interface{}](target, invalidMode, "regex", "{anything}")
+	exprFunc, err := replaceAllPatterns[interface{}](target, invalidMode, "regex", literalElementGetter[interface{}]("{anything}"))
 	assert.Nil(t, exprFunc)
 	assert.Contains(t, err.Error(), "invalid mode")
 }
+
+func Test_replaceAllPatterns_element(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      func(pcommon.Map)
+	}{
+		{
+			name:      "value mode",
+			statement: `replace_all_patterns(target, "value", "^user=(\\w+)$", Concat([$key, SHA256($value[1])], ":"))`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("owner", "owner:2bd806c97f0e00af1a1fc3328fa763a9269723c8db8fac4f93af71db186d6e90")
+				expectedMap.PutStr("other", "unchanged")
+			},
+		},
+		{
+			name:      "key mode",
+			statement: `replace_all_patterns(target, "key", "^ow(ner)$", Concat(["$1", $value[1]], "-"))`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("$1-ner", "user=alice")
+				expectedMap.PutStr("other", "unchanged")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := pcommon.NewMap()
+			input.PutStr("owner", "user=alice")
+			input.PutStr("other", "unchanged")
+
+			_, _, err := parseElementStatement(t, tt.statement).Execute(context.Background(), input)
+			require.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+			assert.Equal(t, expected, input)
+		})
+	}
+}
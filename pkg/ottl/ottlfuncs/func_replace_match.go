@@ -24,9 +24,9 @@ import (
 )
 
 type ReplaceMatchArguments[K any] struct {
-	Target      ottl.GetSetter[K] `ottlarg:"0"`
-	Pattern     string            `ottlarg:"1"`
-	Replacement string            `ottlarg:"2"`
+	Target      ottl.GetSetter[K]     `ottlarg:"0"`
+	Pattern     string                `ottlarg:"1"`
+	Replacement ottl.ElementGetter[K] `ottlarg:"2"`
 }
 
 func NewReplaceMatchFactory[K any]() ottl.Factory[K] {
@@ -43,7 +43,7 @@ func createReplaceMatchFunction[K any](_ ottl.FunctionContext, oArgs ottl.Argume
 	return replaceMatch(args.Target, args.Pattern, args.Replacement)
 }
 
-func replaceMatch[K any](target ottl.GetSetter[K], pattern string, replacement string) (ottl.ExprFunc[K], error) {
+func replaceMatch[K any](target ottl.GetSetter[K], pattern string, replacement ottl.ElementGetter[K]) (ottl.ExprFunc[K], error) {
 	glob, err := glob.Compile(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("the pattern supplied to replace_match is not a valid pattern: %w", err)
@@ -58,7 +58,11 @@ func replaceMatch[K any](target ottl.GetSetter[K], pattern string, replacement s
 		}
 		if valStr, ok := val.(string); ok {
 			if glob.Match(valStr) {
-				err = target.Set(ctx, tCtx, replacement)
+				replacementVal, err := replacement.Get(ottl.WithElement(ctx, nil, valStr), tCtx)
+				if err != nil {
+					return nil, err
+				}
+				err = target.Set(ctx, tCtx, replacementVal)
 				if err != nil {
 					return nil, err
 				}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -67,9 +68,9 @@ func Test_replaceMatch(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			scenarioValue := pcommon.NewValueStr(input.Str())
 
-			exprFunc, err := replaceMatch(tt.target, tt.pattern, tt.replacement)
+			exprFunc, err := replaceMatch(tt.target, tt.pattern, literalElementGetter[pcommon.Value](tt.replacement))
 			assert.NoError(t, err)
-			result, err := exprFunc(nil, scenarioValue)
+			result, err := exprFunc(context.Background(), scenarioValue)
 			assert.NoError(t, err)
 			assert.Nil(t, result)
 
@@ -93,10 +94,10 @@ func Test_replaceMatch_bad_input(t *testing.T) {
 		},
 	}
 
-	exprFunc, err := replaceMatch[interface{}](target, "*", "{replacement}")
+	exprFunc, err := replaceMatch[interface{}](target, "*", literalElementGetter[interface{}]("{replacement}"))
 	assert.NoError(t, err)
 
-	result, err := exprFunc(nil, input)
+	result, err := exprFunc(context.Background(), input)
 	assert.NoError(t, err)
 	assert.Nil(t, result)
 
@@ -114,10 +115,17 @@ func Test_replaceMatch_get_nil(t *testing.T) {
 		},
 	}
 
-	exprFunc, err := replaceMatch[interface{}](target, "*", "{anything}")
+	exprFunc, err := replaceMatch[interface{}](target, "*", literalElementGetter[interface{}]("{anything}"))
 	assert.NoError(t, err)
 
-	result, err := exprFunc(nil, nil)
+	result, err := exprFunc(context.Background(), nil)
 	assert.NoError(t, err)
 	assert.Nil(t, result)
 }
+
+func Test_replaceMatch_element(t *testing.T) {
+	input := pcommon.NewValueStr("alice@example.com")
+	_, _, err := parseElementStatement(t, `replace_match(target, "*@example.com", SHA256($value))`).Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "ff8d9819fc0e12bf0d24892e45987e249a28dce836a85cad60e28eaaa8c6d976", input.Str())
+}
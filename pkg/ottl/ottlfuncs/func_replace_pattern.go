@@ -18,14 +18,15 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 type ReplacePatternArguments[K any] struct {
-	Target       ottl.GetSetter[K] `ottlarg:"0"`
-	RegexPattern string            `ottlarg:"1"`
-	Replacement  string            `ottlarg:"2"`
+	Target       ottl.GetSetter[K]     `ottlarg:"0"`
+	RegexPattern string                `ottlarg:"1"`
+	Replacement  ottl.ElementGetter[K] `ottlarg:"2"`
 }
 
 func NewReplacePatternFactory[K any]() ottl.Factory[K] {
@@ -42,11 +43,12 @@ func createReplacePatternFunction[K any](_ ottl.FunctionContext, oArgs ottl.Argu
 	return replacePattern(args.Target, args.RegexPattern, args.Replacement)
 }
 
-func replacePattern[K any](target ottl.GetSetter[K], regexPattern string, replacement string) (ottl.ExprFunc[K], error) {
+func replacePattern[K any](target ottl.GetSetter[K], regexPattern string, replacement ottl.ElementGetter[K]) (ottl.ExprFunc[K], error) {
 	compiledPattern, err := regexp.Compile(regexPattern)
 	if err != nil {
 		return nil, fmt.Errorf("the regex pattern supplied to replace_pattern is not a valid pattern: %w", err)
 	}
+	expand := ottl.IsLiteral[K](replacement)
 	return func(ctx context.Context, tCtx K) (interface{}, error) {
 		originalVal, err := target.Get(ctx, tCtx)
 		if err != nil {
@@ -57,7 +59,10 @@ func replacePattern[K any](target ottl.GetSetter[K], regexPattern string, replac
 		}
 		if originalValStr, ok := originalVal.(string); ok {
 			if compiledPattern.MatchString(originalValStr) {
-				updatedStr := compiledPattern.ReplaceAllString(originalValStr, replacement)
+				updatedStr, err := replaceAllSubmatches(ctx, tCtx, compiledPattern, originalValStr, nil, replacement, expand)
+				if err != nil {
+					return nil, err
+				}
 				err = target.Set(ctx, tCtx, updatedStr)
 				if err != nil {
 					return nil, err
@@ -67,3 +72,46 @@ func replacePattern[K any](target ottl.GetSetter[K], regexPattern string, replac
 		return nil, nil
 	}, nil
 }
+
+// replaceAllSubmatches replaces every match of pattern in src with the result of evaluating replacement.
+// The replacement is evaluated once per match with `$key` bound to key and `$value` bound to a list
+// holding the full match followed by each capturing group, see submatches.
+// When expand is true the resulting string is expanded with regexp.Expand syntax, so `$1` keeps
+// referring to the first group; otherwise it is inserted literally. Only the literal replacements are
+// expanded, as the values computed from the telemetry may hold a `$` of their own.
+func replaceAllSubmatches[K any](ctx context.Context, tCtx K, pattern *regexp.Regexp, src string, key interface{}, replacement ottl.ElementGetter[K], expand bool) (string, error) {
+	var sb strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(src, -1) {
+		val, err := replacement.Get(ottl.WithElement(ctx, key, submatches(src, match)), tCtx)
+		if err != nil {
+			return "", err
+		}
+		str, ok := val.(string)
+		if !ok {
+			return "", fmt.Errorf("replacement must be a string but got %T", val)
+		}
+		sb.WriteString(src[last:match[0]])
+		if expand {
+			sb.Write(pattern.ExpandString(nil, str, src, match))
+		} else {
+			sb.WriteString(str)
+		}
+		last = match[1]
+	}
+	sb.WriteString(src[last:])
+	return sb.String(), nil
+}
+
+// submatches returns the full match followed by each capturing group of match, which must be
+// a result of FindStringSubmatchIndex on src. Groups that did not participate in the match are "".
+func submatches(src string, match []int) []interface{} {
+	groups := make([]interface{}, len(match)/2)
+	for i := range groups {
+		groups[i] = ""
+		if match[2*i] >= 0 {
+			groups[i] = src[match[2*i]:match[2*i+1]]
+		}
+	}
+	return groups
+}
@@ -16,15 +16,44 @@ package ottlfuncs
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
+type captureArguments[K any] struct {
+	Replacement ottl.ElementGetter[K] `ottlarg:"0"`
+}
+
+// literalElementGetter returns the getter the parser builds for the string literal val, as the replacements are
+// only expanded when they are literals.
+func literalElementGetter[K any](val string) ottl.ElementGetter[K] {
+	var getter ottl.ElementGetter[K]
+	capture := ottl.NewFactory("capture", &captureArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		getter = oArgs.(*captureArguments[K]).Replacement
+		return func(context.Context, K) (interface{}, error) { return nil, nil }, nil
+	})
+	p, err := ottl.NewParser[K](
+		ottl.CreateFactoryMap(capture),
+		func(path *ottl.Path) (ottl.GetSetter[K], error) { return nil, fmt.Errorf("unexpected path") },
+		componenttest.NewNopTelemetrySettings(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if _, err = p.ParseStatement("capture(" + strconv.Quote(val) + ")"); err != nil {
+		panic(err)
+	}
+	return getter
+}
+
 func Test_replacePattern(t *testing.T) {
 	input := pcommon.NewValueStr("application passwd=sensitivedtata otherarg=notsensitive key1 key2")
 
@@ -95,10 +124,10 @@ func Test_replacePattern(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			scenarioValue := pcommon.NewValueStr(input.Str())
 
-			exprFunc, err := replacePattern(tt.target, tt.pattern, tt.replacement)
+			exprFunc, err := replacePattern(tt.target, tt.pattern, literalElementGetter[pcommon.Value](tt.replacement))
 			assert.NoError(t, err)
 
-			result, err := exprFunc(nil, scenarioValue)
+			result, err := exprFunc(context.Background(), scenarioValue)
 			assert.NoError(t, err)
 			assert.Nil(t, result)
 
@@ -122,10 +151,10 @@ func Test_replacePattern_bad_input(t *testing.T) {
 		},
 	}
 
-	exprFunc, err := replacePattern[interface{}](target, "regexp", "{replacement}")
+	exprFunc, err := replacePattern[interface{}](target, "regexp", literalElementGetter[interface{}]("{replacement}"))
 	assert.NoError(t, err)
 
-	result, err := exprFunc(nil, input)
+	result, err := exprFunc(context.Background(), input)
 	assert.NoError(t, err)
 	assert.Nil(t, result)
 	assert.Equal(t, pcommon.NewValueInt(1), input)
@@ -142,10 +171,10 @@ func Test_replacePattern_get_nil(t *testing.T) {
 		},
 	}
 
-	exprFunc, err := replacePattern[interface{}](target, `nomatch\=[^\s]*(\s?)`, "{anything}")
+	exprFunc, err := replacePattern[interface{}](target, `nomatch\=[^\s]*(\s?)`, literalElementGetter[interface{}]("{anything}"))
 	assert.NoError(t, err)
 
-	result, err := exprFunc(nil, nil)
+	result, err := exprFunc(context.Background(), nil)
 	assert.NoError(t, err)
 	assert.Nil(t, result)
 }
@@ -163,7 +192,53 @@ func Test_replacePatterns_invalid_pattern(t *testing.T) {
 	}
 
 	invalidRegexPattern := "*"
-	_, err := replacePattern[interface{}](target, invalidRegexPattern, "{anything}")
+	_, err := replacePattern[interface{}](target, invalidRegexPattern, literalElementGetter[interface{}]("{anything}"))
 	require.Error(t, err)
 	assert.ErrorContains(t, err, "error parsing regexp:")
 }
+
+func Test_replacePattern_element(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      string
+	}{
+		{
+			name:      "hash matched group",
+			statement: `replace_pattern(target, "(\\w+)@(\\w+)", Concat([SHA256($value[1]), $value[2]], "@"))`,
+			want:      "from 2bd806c97f0e00af1a1fc3328fa763a9269723c8db8fac4f93af71db186d6e90@example to bob",
+		},
+		{
+			name:      "full match",
+			statement: `replace_pattern(target, "\\w+@\\w+", Concat(["<", $value[0], ">"], ""))`,
+			want:      "from <alice@example> to bob",
+		},
+		{
+			name:      "computed replacement is not expanded",
+			statement: `replace_pattern(target, "(\\w+)@(\\w+)", Concat(["$2", $value[1]], "/"))`,
+			want:      "from $2/alice to bob",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := pcommon.NewValueStr("from alice@example to bob")
+			_, _, err := parseElementStatement(t, tt.statement).Execute(context.Background(), input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, input.Str())
+		})
+	}
+}
+
+func Test_replacePattern_replacement_with_dollar(t *testing.T) {
+	// the `$1` of the value is inserted as it is rather than expanded to the first group
+	input := pcommon.NewValueStr("costs $1 at alice@example")
+	_, _, err := parseElementStatement(t, `replace_pattern(target, "(\\w+)@(\\w+)", target)`).Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "costs $1 at costs $1 at alice@example", input.Str())
+}
+
+func Test_replacePattern_non_string_replacement(t *testing.T) {
+	input := pcommon.NewValueStr("from alice@example to bob")
+	_, _, err := parseElementStatement(t, `replace_pattern(target, "(\\w+)@(\\w+)", $value)`).Execute(context.Background(), input)
+	assert.ErrorContains(t, err, "replacement must be a string but got []interface {}")
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"crypto/sha1" // #nosec G505 -- SHA-1 is offered for compatibility with existing join keys, not for security
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type SHA1Arguments[K any] struct {
+	Target ottl.StringGetter[K] `ottlarg:"0"`
+}
+
+func NewSHA1Factory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("SHA1", &SHA1Arguments[K]{}, createSHA1Function[K])
+}
+
+func createSHA1Function[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*SHA1Arguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("SHA1Factory args must be of type *SHA1Arguments[K]")
+	}
+
+	return sha1Hash(args.Target), nil
+}
+
+// sha1Hash returns the hex encoded SHA-1 digest of the target string.
+func sha1Hash[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha1.Sum([]byte(val))
+		return hex.EncodeToString(sum[:]), nil
+	}
+}
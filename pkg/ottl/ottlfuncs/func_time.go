@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	strptime "github.com/observiq/ctimefmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type TimeArguments[K any] struct {
+	Time   ottl.StringGetter[K] `ottlarg:"0"`
+	Format string               `ottlarg:"1"`
+}
+
+func NewTimeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Time", &TimeArguments[K]{}, createTimeFunction[K])
+}
+
+func createTimeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*TimeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("TimeFactory args must be of type *TimeArguments[K]")
+	}
+
+	return parseTime(args.Time, args.Format)
+}
+
+// parseTime parses the target string into a time.Time using a strptime-like format.
+// Timestamps that do not carry a time zone are interpreted as UTC.
+func parseTime[K any](inputTime ottl.StringGetter[K], format string) (ottl.ExprFunc[K], error) {
+	if format == "" {
+		return nil, fmt.Errorf("format cannot be empty")
+	}
+	layout, err := strptime.ToNative(format)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		t, err := inputTime.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if t == "" {
+			return nil, fmt.Errorf("time cannot be empty")
+		}
+		return time.Parse(layout, t)
+	}, nil
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Time(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     string
+		format   string
+		expected time.Time
+	}{
+		{
+			name:     "date and time",
+			time:     "2023-04-12 15:04:05",
+			format:   "%Y-%m-%d %H:%M:%S",
+			expected: time.Date(2023, 4, 12, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:     "month name and milliseconds",
+			time:     "Apr 12 2023 15:04:05.123",
+			format:   "%b %d %Y %H:%M:%S.%L",
+			expected: time.Date(2023, 4, 12, 15, 4, 5, 123000000, time.UTC),
+		},
+		{
+			name:     "time zone offset",
+			time:     "2023-04-12T15:04:05+0200",
+			format:   "%Y-%m-%dT%H:%M:%S%z",
+			expected: time.Date(2023, 4, 12, 13, 4, 5, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := parseTime[any](literalStringGetter(tt.time), tt.format)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			resultTime, ok := result.(time.Time)
+			require.True(t, ok)
+			assert.True(t, tt.expected.Equal(resultTime), "expected %v, got %v", tt.expected, resultTime)
+		})
+	}
+}
+
+func Test_Time_error(t *testing.T) {
+	exprFunc, err := parseTime[any](literalStringGetter("12/04/2023"), "%Y-%m-%d")
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+
+	exprFunc, err = parseTime[any](literalStringGetter(""), "%Y-%m-%d")
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func Test_Time_invalid_format(t *testing.T) {
+	_, err := parseTime[any](literalStringGetter("2023"), "")
+	assert.Error(t, err)
+
+	_, err = parseTime[any](literalStringGetter("2023"), "%Y-%K")
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type TransformKeysArguments[K any] struct {
+	Target     ottl.PMapGetter[K]    `ottlarg:"0"`
+	Expression ottl.ElementGetter[K] `ottlarg:"1"`
+}
+
+func NewTransformKeysFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("transform_keys", &TransformKeysArguments[K]{}, createTransformKeysFunction[K])
+}
+
+func createTransformKeysFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*TransformKeysArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("TransformKeysFactory args must be of type *TransformKeysArguments[K]")
+	}
+
+	return transformKeys(args.Target, args.Expression), nil
+}
+
+// transformKeys replaces every key of the target map with the result of evaluating expression,
+// with `$key` and `$value` bound to the entry being visited.
+func transformKeys[K any](target ottl.PMapGetter[K], expression ottl.ElementGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		updated := pcommon.NewMap()
+		updated.EnsureCapacity(val.Len())
+		val.Range(func(key string, originalValue pcommon.Value) bool {
+			var newKey interface{}
+			newKey, err = expression.Get(ottl.WithElement(ctx, key, ottlcommon.GetValue(originalValue)), tCtx)
+			if err != nil {
+				return false
+			}
+			str, ok := newKey.(string)
+			if !ok {
+				err = fmt.Errorf("expected key %q to be transformed into a string but got %T", key, newKey)
+				return false
+			}
+			originalValue.CopyTo(updated.PutEmpty(str))
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		updated.CopyTo(val)
+		return nil, nil
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// parseElementStatement parses a statement whose only path, `target`, refers to the map or slice passed as the
+// TransformContext. Element getters can only be built by the parser, so the per-element functions are tested this way.
+func parseElementStatement(t *testing.T, statement string) *ottl.Statement[any] {
+	p, err := ottl.NewParser[any](
+		ottl.CreateFactoryMap(
+			NewTransformKeysFactory[any](),
+			NewTransformValuesFactory[any](),
+			NewConvertCaseFactory[any](),
+			NewConcatFactory[any](),
+			NewReplacePatternFactory[any](),
+			NewReplaceAllPatternsFactory[any](),
+			NewReplaceMatchFactory[any](),
+			NewReplaceAllMatchesFactory[any](),
+			NewSHA256Factory[any](),
+		),
+		func(path *ottl.Path) (ottl.GetSetter[any], error) {
+			return ottl.StandardGetSetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					if v, ok := tCtx.(pcommon.Value); ok {
+						return v.Str(), nil
+					}
+					return tCtx, nil
+				},
+				Setter: func(ctx context.Context, tCtx any, val interface{}) error {
+					tCtx.(pcommon.Value).SetStr(val.(string))
+					return nil
+				},
+			}, nil
+		},
+		componenttest.NewNopTelemetrySettings(),
+	)
+	require.NoError(t, err)
+	stmt, err := p.ParseStatement(statement)
+	require.NoError(t, err)
+	return stmt
+}
+
+func Test_transformKeys(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("Test", "hello world")
+	input.PutInt("test2", 3)
+	input.PutEmptyMap("TEST3").PutStr("name", "nested")
+
+	tests := []struct {
+		name      string
+		statement string
+		want      func(pcommon.Map)
+	}{
+		{
+			name:      "lowercase keys",
+			statement: `transform_keys(target, ConvertCase($key, "lower"))`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("test", "hello world")
+				expectedMap.PutInt("test2", 3)
+				expectedMap.PutEmptyMap("test3").PutStr("name", "nested")
+			},
+		},
+		{
+			name:      "prefix keys",
+			statement: `transform_keys(target, Concat(["app", $key], "."))`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("app.Test", "hello world")
+				expectedMap.PutInt("app.test2", 3)
+				expectedMap.PutEmptyMap("app.TEST3").PutStr("name", "nested")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			input.CopyTo(scenarioMap)
+
+			_, _, err := parseElementStatement(t, tt.statement).Execute(context.Background(), scenarioMap)
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected, scenarioMap)
+		})
+	}
+}
+
+func Test_transformKeys_non_string_key(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutInt("test", 1)
+
+	_, _, err := parseElementStatement(t, `transform_keys(target, $value)`).Execute(context.Background(), input)
+	assert.Error(t, err)
+
+	expected := pcommon.NewMap()
+	expected.PutInt("test", 1)
+	assert.Equal(t, expected, input)
+}
+
+func Test_transformKeys_bad_input(t *testing.T) {
+	_, _, err := parseElementStatement(t, `transform_keys(target, $key)`).Execute(context.Background(), "not a map")
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type TransformValuesArguments[K any] struct {
+	Target     ottl.Getter[K]        `ottlarg:"0"`
+	Expression ottl.ElementGetter[K] `ottlarg:"1"`
+}
+
+func NewTransformValuesFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("transform_values", &TransformValuesArguments[K]{}, createTransformValuesFunction[K])
+}
+
+func createTransformValuesFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*TransformValuesArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("TransformValuesFactory args must be of type *TransformValuesArguments[K]")
+	}
+
+	return transformValues(args.Target, args.Expression), nil
+}
+
+// transformValues replaces every value of the target map or slice with the result of evaluating expression,
+// with `$key` and `$value` bound to the element being visited. Elements for which expression returns nil are removed.
+func transformValues[K any](target ottl.Getter[K], expression ottl.ElementGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case pcommon.Map:
+			updated := pcommon.NewMap()
+			updated.EnsureCapacity(v.Len())
+			v.Range(func(key string, originalValue pcommon.Value) bool {
+				var newValue interface{}
+				newValue, err = expression.Get(ottl.WithElement(ctx, key, ottlcommon.GetValue(originalValue)), tCtx)
+				if err != nil {
+					return false
+				}
+				if newValue == nil {
+					return true
+				}
+				err = ottlcommon.SetValue(updated.PutEmpty(key), newValue)
+				return err == nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			updated.CopyTo(v)
+		case pcommon.Slice:
+			updated := pcommon.NewSlice()
+			updated.EnsureCapacity(v.Len())
+			for i := 0; i < v.Len(); i++ {
+				newValue, err := expression.Get(ottl.WithElement(ctx, int64(i), ottlcommon.GetValue(v.At(i))), tCtx)
+				if err != nil {
+					return nil, err
+				}
+				if newValue == nil {
+					continue
+				}
+				err = ottlcommon.SetValue(updated.AppendEmpty(), newValue)
+				if err != nil {
+					return nil, err
+				}
+			}
+			updated.CopyTo(v)
+		default:
+			return nil, fmt.Errorf("transform_values can only be applied to a map or a slice, but got %T", val)
+		}
+		return nil, nil
+	}
+}
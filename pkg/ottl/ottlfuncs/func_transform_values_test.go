@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_transformValues_map(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("test", "Hello World")
+	input.PutStr("test2", "HELLO")
+	input.PutEmptyMap("test3").PutStr("name", "Nested")
+
+	tests := []struct {
+		name      string
+		statement string
+		want      func(pcommon.Map)
+	}{
+		{
+			name:      "prefix values",
+			statement: `transform_values(target, Concat([$key, $value], "="))`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("test", "test=Hello World")
+				expectedMap.PutStr("test2", "test2=HELLO")
+				expectedMap.PutStr("test3", `test3={"name":"Nested"}`)
+			},
+		},
+		{
+			name:      "remove all values",
+			statement: `transform_values(target, nil)`,
+			want:      func(expectedMap pcommon.Map) {},
+		},
+		{
+			name:      "map literal values",
+			statement: `transform_values(target, {"key": $key})`,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutEmptyMap("test").PutStr("key", "test")
+				expectedMap.PutEmptyMap("test2").PutStr("key", "test2")
+				expectedMap.PutEmptyMap("test3").PutStr("key", "test3")
+			},
+		},
+		{
+			name:      "indexed values",
+			statement: `transform_values(target, $value["name"])`,
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			input.CopyTo(scenarioMap)
+
+			_, _, err := parseElementStatement(t, tt.statement).Execute(context.Background(), scenarioMap)
+			if tt.want == nil {
+				assert.Error(t, err)
+				assert.Equal(t, input, scenarioMap)
+				return
+			}
+			assert.NoError(t, err)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected.AsRaw(), scenarioMap.AsRaw())
+		})
+	}
+}
+
+func Test_transformValues_slice(t *testing.T) {
+	input := pcommon.NewSlice()
+	input.AppendEmpty().SetStr("a")
+	input.AppendEmpty().SetStr("B")
+
+	_, _, err := parseElementStatement(t, `transform_values(target, ConvertCase($value, "upper"))`).Execute(context.Background(), input)
+	assert.NoError(t, err)
+
+	expected := pcommon.NewSlice()
+	expected.AppendEmpty().SetStr("A")
+	expected.AppendEmpty().SetStr("B")
+	assert.Equal(t, expected, input)
+}
+
+func Test_transformValues_bad_input(t *testing.T) {
+	_, _, err := parseElementStatement(t, `transform_values(target, $value)`).Execute(context.Background(), "not a map")
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type UnixNanoArguments[K any] struct {
+	Target ottl.Getter[K] `ottlarg:"0"`
+}
+
+func NewUnixNanoFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("UnixNano", &UnixNanoArguments[K]{}, createUnixNanoFunction[K])
+}
+
+func createUnixNanoFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*UnixNanoArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("UnixNanoFactory args must be of type *UnixNanoArguments[K]")
+	}
+
+	return unixNano(args.Target), nil
+}
+
+// unixNano returns the number of nanoseconds elapsed since the Unix epoch for a time.Time,
+// or the number of nanoseconds in a time.Duration.
+func unixNano[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case time.Time:
+			return v.UnixNano(), nil
+		case time.Duration:
+			return v.Nanoseconds(), nil
+		default:
+			return nil, fmt.Errorf("expected time.Time or time.Duration but got %T", val)
+		}
+	}
+}
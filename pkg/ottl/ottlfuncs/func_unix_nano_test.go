@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_UnixNano(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected int64
+	}{
+		{
+			name:     "time",
+			value:    time.Date(2023, 4, 12, 0, 0, 0, 1, time.UTC),
+			expected: 1681257600000000001,
+		},
+		{
+			name:     "duration",
+			value:    1500 * time.Millisecond,
+			expected: 1500000000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}
+			result, err := unixNano[any](target)(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_UnixNano_error(t *testing.T) {
+	target := ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return int64(1), nil
+		},
+	}
+	_, err := unixNano[any](target)(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -47,33 +47,94 @@ type Parser[K any] struct {
 	pathParser        PathExpressionParser[K]
 	enumParser        EnumParser
 	telemetrySettings component.TelemetrySettings
+	// elementScope counts the ElementGetter arguments currently being built,
+	// element references are only valid while it is positive.
+	elementScope int
 }
 
 // Statement holds a top level Statement for processing telemetry data. A Statement is a combination of a function
 // invocation and the boolean expression to match telemetry for invoking the function.
+// A Statement can also be a conditional block holding other statements, or a `stop` statement
+// that ends the execution of the Statements it is part of.
 type Statement[K any] struct {
 	function  Expr[K]
 	condition BoolExpr[K]
 	origText  string
+	// branches holds the `if`, `else if` and `else` branches of a conditional block, in order.
+	branches []branch[K]
+	stop     bool
+}
+
+// branch is a list of statements that is executed when its condition is met.
+type branch[K any] struct {
+	condition  BoolExpr[K]
+	statements []*Statement[K]
 }
 
 // Execute is a function that will execute the statement's function if the statement's condition is met.
 // Returns true if the function was run, returns false otherwise.
 // If the statement contains no condition, the function will run and true will be returned.
 // In addition, the functions return value is always returned.
+// For a conditional block, the statements of the first branch whose condition is met are executed and true is returned,
+// the first error returned by one of those statements stops the execution of the block.
 func (s *Statement[K]) Execute(ctx context.Context, tCtx K) (any, bool, error) {
+	result, condition, _, err := s.execute(ctx, tCtx, func(_ *Statement[K], err error) error {
+		return err
+	})
+	return result, condition, err
+}
+
+// execute runs the statement and additionally reports whether a `stop` statement was reached.
+// onError is called with the errors of the statements nested in a conditional block, and with the error
+// of the block's own conditions. Execution continues with the next statement if it returns nil.
+func (s *Statement[K]) execute(ctx context.Context, tCtx K, onError func(*Statement[K], error) error) (any, bool, bool, error) {
+	if s.branches != nil {
+		for _, b := range s.branches {
+			match, err := b.condition.Eval(ctx, tCtx)
+			if err != nil {
+				return nil, false, false, onError(s, err)
+			}
+			if match {
+				stopped, err := executeStatements(ctx, tCtx, b.statements, onError)
+				return nil, true, stopped, err
+			}
+		}
+		return nil, false, false, nil
+	}
+
 	condition, err := s.condition.Eval(ctx, tCtx)
 	if err != nil {
-		return nil, false, err
+		return nil, false, false, err
+	}
+	if !condition {
+		return nil, false, false, nil
+	}
+	if s.stop {
+		return nil, true, true, nil
+	}
+	result, err := s.function.Eval(ctx, tCtx)
+	if err != nil {
+		return nil, true, false, err
 	}
-	var result any
-	if condition {
-		result, err = s.function.Eval(ctx, tCtx)
+	return result, true, false, nil
+}
+
+// executeStatements runs statements in order until one of them reaches a `stop` statement, which is reported.
+func executeStatements[K any](ctx context.Context, tCtx K, statements []*Statement[K], onError func(*Statement[K], error) error) (bool, error) {
+	for _, statement := range statements {
+		_, _, stopped, err := statement.execute(ctx, tCtx, onError)
+		if err != nil && statement.branches == nil {
+			// Conditional blocks have already passed their errors to onError.
+			err = onError(statement, err)
+		}
 		if err != nil {
-			return nil, true, err
+			return false, err
+		}
+		if stopped {
+			return true, nil
 		}
 	}
-	return result, condition, nil
+	return false, nil
 }
 
 func NewParser[K any](
@@ -107,14 +168,79 @@ func WithEnumParser[K any](parser EnumParser) Option[K] {
 	}
 }
 
+// ParseStatements parses a list of statements. Besides regular statements, the list may hold conditional blocks
+// that start with `if <condition>`, may continue with any number of `else if <condition>` and a final `else`,
+// and end with `end`.
 func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error) {
 	var parsedStatements []*Statement[K]
+	// open holds the conditional blocks that have not been closed yet, innermost last.
+	var open []*Statement[K]
+	var hasElse []bool
+	add := func(statement *Statement[K]) {
+		if len(open) == 0 {
+			parsedStatements = append(parsedStatements, statement)
+			return
+		}
+		b := &open[len(open)-1].branches[len(open[len(open)-1].branches)-1]
+		b.statements = append(b.statements, statement)
+	}
+
 	for _, statement := range statements {
-		ps, err := p.ParseStatement(statement)
+		parsed, err := parseStatement(statement)
 		if err != nil {
 			return nil, err
 		}
-		parsedStatements = append(parsedStatements, ps)
+		if parsed.Control == nil || parsed.Control.Keyword == keywordStop {
+			ps, err := p.newStatement(parsed, statement)
+			if err != nil {
+				return nil, err
+			}
+			add(ps)
+			continue
+		}
+
+		switch parsed.Control.Keyword {
+		case keywordIf:
+			condition, err := p.newBoolExpr(parsed.Control.Condition)
+			if err != nil {
+				return nil, err
+			}
+			block := &Statement[K]{
+				branches: []branch[K]{{condition: condition}},
+				origText: statement,
+			}
+			add(block)
+			open = append(open, block)
+			hasElse = append(hasElse, false)
+		case keywordElse:
+			if len(open) == 0 {
+				return nil, fmt.Errorf("statement %q has no matching 'if'", statement)
+			}
+			if hasElse[len(hasElse)-1] {
+				return nil, fmt.Errorf("statement %q follows the 'else' branch of its block", statement)
+			}
+			var condition BoolExpr[K]
+			if parsed.Control.ElseIf {
+				condition, err = p.newBoolExpr(parsed.Control.Condition)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				condition = BoolExpr[K]{alwaysTrue[K]}
+				hasElse[len(hasElse)-1] = true
+			}
+			block := open[len(open)-1]
+			block.branches = append(block.branches, branch[K]{condition: condition})
+		case keywordEnd:
+			if len(open) == 0 {
+				return nil, fmt.Errorf("statement %q has no matching 'if'", statement)
+			}
+			open = open[:len(open)-1]
+			hasElse = hasElse[:len(hasElse)-1]
+		}
+	}
+	if len(open) > 0 {
+		return nil, fmt.Errorf("the block started by statement %q is missing its 'end'", open[len(open)-1].origText)
 	}
 	return parsedStatements, nil
 }
@@ -124,11 +250,25 @@ func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
 	if err != nil {
 		return nil, err
 	}
-	function, err := p.newFunctionCall(parsed.Invocation)
+	if parsed.Control != nil && parsed.Control.Keyword != keywordStop {
+		return nil, fmt.Errorf("'%v' can only be used in a list of statements", parsed.Control.Keyword)
+	}
+	return p.newStatement(parsed, statement)
+}
+
+func (p *Parser[K]) newStatement(parsed *parsedStatement, statement string) (*Statement[K], error) {
+	expression, err := p.newBoolExpr(parsed.WhereClause)
 	if err != nil {
 		return nil, err
 	}
-	expression, err := p.newBoolExpr(parsed.WhereClause)
+	if parsed.Control != nil {
+		return &Statement[K]{
+			condition: expression,
+			origText:  statement,
+			stop:      true,
+		}, nil
+	}
+	function, err := p.newFunctionCall(parsed.Invocation)
 	if err != nil {
 		return nil, err
 	}
@@ -198,17 +338,19 @@ func NewStatements[K any](statements []*Statement[K], telemetrySettings componen
 }
 
 // Execute is a function that will execute all the statements in the Statements list.
+// Execution ends early if a `stop` statement's condition is met.
 func (s *Statements[K]) Execute(ctx context.Context, tCtx K) error {
-	for _, statement := range s.statements {
-		_, _, err := statement.Execute(ctx, tCtx)
-		if err != nil {
-			if s.errorMode == PropagateError {
-				err = fmt.Errorf("failed to execute statement: %v, %w", statement.origText, err)
-				return err
-			}
-			s.telemetrySettings.Logger.Warn("failed to execute statement", zap.Error(err), zap.String("statement", statement.origText))
-		}
+	_, err := executeStatements(ctx, tCtx, s.statements, s.handleError)
+	return err
+}
+
+// handleError returns err annotated with the statement that caused it when errorMode is `propagate`,
+// and logs it and returns nil otherwise.
+func (s *Statements[K]) handleError(statement *Statement[K], err error) error {
+	if s.errorMode == PropagateError {
+		return fmt.Errorf("failed to execute statement: %v, %w", statement.origText, err)
 	}
+	s.telemetrySettings.Logger.Warn("failed to execute statement", zap.Error(err), zap.String("statement", statement.origText))
 	return nil
 }
 
@@ -216,8 +358,12 @@ func (s *Statements[K]) Execute(ctx context.Context, tCtx K) error {
 // Does not execute the statement's function.
 // When errorMode is `propagate`, errors cause the evaluation to be false and an error is returned.
 // When errorMode is `ignore`, errors cause evaluation to continue to the next statement.
+// Conditional blocks and `stop` statements are skipped.
 func (s *Statements[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
 	for _, statement := range s.statements {
+		if statement.branches != nil || statement.stop {
+			continue
+		}
 		match, err := statement.condition.Eval(ctx, tCtx)
 		if err != nil {
 			if s.errorMode == PropagateError {
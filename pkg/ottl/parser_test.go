@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
@@ -125,6 +126,111 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:      "map literal",
+			statement: `set(attributes, {"foo": "bar", "nested": {"list": [1]}})`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "set",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "attributes",
+										},
+									},
+								},
+							},
+						},
+						{
+							Map: &mapValue{
+								Values: []mapItem{
+									{
+										Key: ottltest.Strp("foo"),
+										Value: &value{
+											String: ottltest.Strp("bar"),
+										},
+									},
+									{
+										Key: ottltest.Strp("nested"),
+										Value: &value{
+											Map: &mapValue{
+												Values: []mapItem{
+													{
+														Key: ottltest.Strp("list"),
+														Value: &value{
+															List: &list{
+																Values: []value{
+																	{
+																		Literal: &mathExprLiteral{
+																			Int: ottltest.Intp(1),
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "element reference",
+			statement: `transform_keys(attributes, ConvertCase($value["x"], "lower"))`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "transform_keys",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "attributes",
+										},
+									},
+								},
+							},
+						},
+						{
+							Literal: &mathExprLiteral{
+								Converter: &converter{
+									Function: "ConvertCase",
+									Arguments: []value{
+										{
+											Literal: &mathExprLiteral{
+												Element: &elementRef{
+													Name: "$value",
+													Keys: []Key{
+														{
+															String: ottltest.Strp("x"),
+														},
+													},
+												},
+											},
+										},
+										{
+											String: ottltest.Strp("lower"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 		{
 			name:      "complex path",
 			statement: `set(foo.attributes["bar"].cat, "dog")`,
@@ -1433,6 +1539,31 @@ func Test_parseStatement(t *testing.T) {
 		{`test() where one() == 1`, true},
 		{`test(fail())`, true},
 		{`Test()`, true},
+		{`set(attributes, {"foo": "bar", "baz": [1, 2]})`, false},
+		{`set(attributes, {})`, false},
+		{`set(attributes, {"foo": {"bar": attributes["baz"]}})`, false},
+		{`set(attributes, {"foo": "bar", "foo": "baz"})`, true},
+		{`set(attributes, {foo: "bar"})`, true},
+		{`set(attributes, {"foo": int()})`, true},
+		{`transform_keys(attributes, ConvertCase($key, "lower"))`, false},
+		{`transform_values(attributes, $value["nested"])`, false},
+		{`transform_values(attributes, $element)`, true},
+		{`if name == "fido"`, false},
+		{`if (name == "fido" or name == "rex") and true`, false},
+		{`else if name == "fido"`, false},
+		{`else`, false},
+		{`end`, false},
+		{`stop`, false},
+		{`stop where name == "fido"`, false},
+		{`if`, true},
+		{`else if`, true},
+		{`if if name == "fido"`, true},
+		{`if name == "fido" where name == "rex"`, true},
+		{`else name == "fido"`, true},
+		{`end name == "fido"`, true},
+		{`end where name == "fido"`, true},
+		{`stop name == "fido"`, true},
+		{`if one() == 1`, true},
 	}
 	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
 	for _, tt := range tests {
@@ -1687,3 +1818,211 @@ func Test_Statements_Eval_Error(t *testing.T) {
 		})
 	}
 }
+
+// blockTestContext is the transform context used to test conditional blocks and stop statements.
+// Every path resolves to name, and the record function appends its argument to executed.
+type blockTestContext struct {
+	name     string
+	executed []string
+}
+
+type recordArguments struct {
+	Value string `ottlarg:"0"`
+}
+
+func newBlockTestParser(t *testing.T) Parser[*blockTestContext] {
+	p, err := NewParser[*blockTestContext](
+		CreateFactoryMap[*blockTestContext](
+			NewFactory("record", &recordArguments{}, func(_ FunctionContext, args Arguments) (ExprFunc[*blockTestContext], error) {
+				value := args.(*recordArguments).Value
+				return func(_ context.Context, tCtx *blockTestContext) (interface{}, error) {
+					tCtx.executed = append(tCtx.executed, value)
+					return nil, nil
+				}, nil
+			}),
+			NewFactory("fail", nil, func(FunctionContext, Arguments) (ExprFunc[*blockTestContext], error) {
+				return func(context.Context, *blockTestContext) (interface{}, error) {
+					return nil, fmt.Errorf("failed")
+				}, nil
+			}),
+		),
+		func(*Path) (GetSetter[*blockTestContext], error) {
+			return StandardGetSetter[*blockTestContext]{
+				Getter: func(_ context.Context, tCtx *blockTestContext) (interface{}, error) {
+					return tCtx.name, nil
+				},
+			}, nil
+		},
+		componenttest.NewNopTelemetrySettings(),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_Statements_Execute_blocks(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []string
+		errorMode  ErrorMode
+		expected   map[string][]string
+	}{
+		{
+			name: "if else",
+			statements: []string{
+				`record("before")`,
+				`if name == "a"`,
+				`record("a")`,
+				`else if name == "b"`,
+				`record("b")`,
+				`else`,
+				`record("other")`,
+				`end`,
+				`record("after")`,
+			},
+			expected: map[string][]string{
+				"a": {"before", "a", "after"},
+				"b": {"before", "b", "after"},
+				"c": {"before", "other", "after"},
+			},
+		},
+		{
+			name: "nested blocks",
+			statements: []string{
+				`if name != "c"`,
+				`if name == "a"`,
+				`record("a")`,
+				`end`,
+				`record("not c")`,
+				`end`,
+			},
+			expected: map[string][]string{
+				"a": {"a", "not c"},
+				"b": {"not c"},
+				"c": nil,
+			},
+		},
+		{
+			name: "stop",
+			statements: []string{
+				`record("first")`,
+				`stop where name == "a"`,
+				`record("second")`,
+			},
+			expected: map[string][]string{
+				"a": {"first"},
+				"b": {"first", "second"},
+			},
+		},
+		{
+			name: "stop in block",
+			statements: []string{
+				`if name == "a"`,
+				`record("a")`,
+				`stop`,
+				`record("unreachable")`,
+				`end`,
+				`record("after")`,
+			},
+			expected: map[string][]string{
+				"a": {"a"},
+				"b": {"after"},
+			},
+		},
+		{
+			name: "errors are ignored in blocks",
+			statements: []string{
+				`if name == "a"`,
+				`fail()`,
+				`record("a")`,
+				`end`,
+			},
+			errorMode: IgnoreError,
+			expected: map[string][]string{
+				"a": {"a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newBlockTestParser(t)
+			parsed, err := p.ParseStatements(tt.statements)
+			require.NoError(t, err)
+			errorMode := tt.errorMode
+			if errorMode == "" {
+				errorMode = PropagateError
+			}
+			statements := NewStatements(parsed, componenttest.NewNopTelemetrySettings(), WithErrorMode[*blockTestContext](errorMode))
+
+			for name, expected := range tt.expected {
+				tCtx := &blockTestContext{name: name}
+				require.NoError(t, statements.Execute(context.Background(), tCtx))
+				assert.Equal(t, expected, tCtx.executed, name)
+			}
+		})
+	}
+}
+
+func Test_Statements_Execute_blocks_propagate_error(t *testing.T) {
+	p := newBlockTestParser(t)
+	parsed, err := p.ParseStatements([]string{
+		`if name == "a"`,
+		`fail()`,
+		`end`,
+		`record("after")`,
+	})
+	require.NoError(t, err)
+	statements := NewStatements(parsed, componenttest.NewNopTelemetrySettings(), WithErrorMode[*blockTestContext](PropagateError))
+
+	tCtx := &blockTestContext{name: "a"}
+	err = statements.Execute(context.Background(), tCtx)
+	assert.EqualError(t, err, "failed to execute statement: fail(), failed")
+	assert.Nil(t, tCtx.executed)
+}
+
+func Test_ParseStatements_blocks_error(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []string
+		err        string
+	}{
+		{
+			name:       "else without if",
+			statements: []string{`record("a")`, `else`},
+			err:        `statement "else" has no matching 'if'`,
+		},
+		{
+			name:       "end without if",
+			statements: []string{`end`},
+			err:        `statement "end" has no matching 'if'`,
+		},
+		{
+			name:       "missing end",
+			statements: []string{`if name == "a"`, `record("a")`},
+			err:        `the block started by statement "if name == \"a\"" is missing its 'end'`,
+		},
+		{
+			name:       "else after else",
+			statements: []string{`if name == "a"`, `else`, `else if name == "b"`, `end`},
+			err:        `statement "else if name == \"b\"" follows the 'else' branch of its block`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newBlockTestParser(t)
+			_, err := p.ParseStatements(tt.statements)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func Test_ParseStatement_block(t *testing.T) {
+	p := newBlockTestParser(t)
+	_, err := p.ParseStatement(`if name == "a"`)
+	assert.EqualError(t, err, "'if' can only be used in a list of statements")
+
+	statement, err := p.ParseStatement(`stop where name == "a"`)
+	require.NoError(t, err)
+	_, condition, err := statement.Execute(context.Background(), &blockTestContext{name: "a"})
+	assert.NoError(t, err)
+	assert.True(t, condition)
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/cespare/xxhash/v2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// MaxPValue is the largest p-value, representing a zero sampling probability: spans with this
+	// p-value were sampled for reasons other than their probability and have a zero adjusted count.
+	MaxPValue = 63
+	// MaxRValue is the largest r-value.
+	MaxRValue = 62
+)
+
+// ErrInvalidProbability is returned when the sampling probability isn't between 0 and 1.
+var ErrInvalidProbability = errors.New("sampling probability must be between 0 and 1")
+
+// ConsistentSampler implements the OpenTelemetry consistent probability sampling, in which a
+// trace is sampled with a probability of 2^-p when its r-value is at least p. As the r-value is
+// shared by all the spans of the trace, samplers at different stages of the pipeline agree on the
+// decisions for the same trace, and the p-value recorded in the tracestate allows the sampled
+// spans to be counted as 2^p spans of the original population.
+//
+// Probabilities that aren't powers of two are approximated by choosing, for each trace, between
+// the two closest p-values, so that the expected sampling probability is the configured one.
+type ConsistentSampler struct {
+	pLow  int
+	pHigh int
+	// lowProbability is the probability with which pLow is chosen over pHigh.
+	lowProbability float64
+}
+
+// NewConsistentSampler returns a sampler for the given probability, between 0 and 1.
+func NewConsistentSampler(probability float64) (*ConsistentSampler, error) {
+	if math.IsNaN(probability) || probability < 0 || probability > 1 {
+		return nil, ErrInvalidProbability
+	}
+
+	if probability == 0 {
+		return &ConsistentSampler{pLow: MaxPValue, pHigh: MaxPValue, lowProbability: 1}, nil
+	}
+
+	// 2^-pLow >= probability > 2^-pHigh
+	pLow := int(math.Floor(-math.Log2(probability)))
+	if pLow >= MaxRValue {
+		return &ConsistentSampler{pLow: MaxRValue, pHigh: MaxRValue, lowProbability: 1}, nil
+	}
+	pHigh := pLow + 1
+
+	return &ConsistentSampler{
+		pLow:  pLow,
+		pHigh: pHigh,
+		// solves lowProbability*2^-pLow + (1-lowProbability)*2^-pHigh = probability
+		lowProbability: math.Ldexp(probability, pHigh) - 1,
+	}, nil
+}
+
+// PValue returns the p-value used for the trace with the given ID.
+func (s *ConsistentSampler) PValue(traceID pcommon.TraceID) int {
+	if s.lowProbability >= 1 {
+		return s.pLow
+	}
+	if uniformFromTraceID(traceID) < s.lowProbability {
+		return s.pLow
+	}
+	return s.pHigh
+}
+
+// Decide returns whether the trace with the given ID is sampled, updating its OpenTelemetry
+// tracestate accordingly:
+//   - when the r-value is missing, it is derived from the trace ID, so that all the spans of the
+//     trace get the same one, and any p-value is removed as it can't have been used consistently;
+//   - when the trace was already sampled upstream with a probability lower than this sampler's,
+//     the upstream decision and p-value are kept;
+//   - otherwise, the trace is sampled when its r-value is at least this sampler's p-value, which
+//     is then recorded in the tracestate.
+//
+// Traces with the MaxPValue were sampled for reasons other than their probability: they are
+// sampled by this sampler like any other trace, but keep their zero adjusted count.
+func (s *ConsistentSampler) Decide(traceID pcommon.TraceID, ts *OTelTraceState) bool {
+	r, hasR := ts.RValue()
+	if !hasR {
+		r = RValueFromTraceID(traceID)
+		ts.rValue, ts.hasR = r, true
+		ts.UnsetPValue()
+	}
+
+	p := s.PValue(traceID)
+	if upstream, ok := ts.PValue(); ok {
+		switch {
+		case upstream == MaxPValue:
+			return r >= p
+		case upstream > r:
+			// the trace couldn't have been sampled with this p-value, which is therefore unreliable
+			ts.UnsetPValue()
+		case upstream >= p:
+			return true
+		}
+	}
+
+	if r < p {
+		return false
+	}
+	ts.pValue, ts.hasP = p, true
+	return true
+}
+
+// RValueFromTraceID derives an r-value from the trace ID, for traces without one in their
+// tracestate. The trace ID is hashed first, as trace IDs aren't necessarily random: the
+// r-value is the number of leading zeros of the hash, which follows the expected geometric
+// distribution.
+func RValueFromTraceID(traceID pcommon.TraceID) int {
+	r := bits.LeadingZeros64(hashTraceID(traceID, 'r'))
+	if r > MaxRValue {
+		return MaxRValue
+	}
+	return r
+}
+
+// uniformFromTraceID returns a number in [0, 1) derived from the trace ID, independent from
+// its r-value.
+func uniformFromTraceID(traceID pcommon.TraceID) float64 {
+	return float64(hashTraceID(traceID, 'p')>>11) / (1 << 53)
+}
+
+// hashTraceID creates a hash of the trace ID using the xxHash algorithm, whose bits are all well
+// distributed even for trace IDs differing in a few bytes only.
+func hashTraceID(traceID pcommon.TraceID, salt byte) uint64 {
+	var b [17]byte
+	b[0] = salt
+	copy(b[1:], traceID[:])
+	return xxhash.Sum64(b[:])
+}
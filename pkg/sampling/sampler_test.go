@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestNewConsistentSampler(t *testing.T) {
+	for _, tt := range []struct {
+		probability    float64
+		pLow, pHigh    int
+		lowProbability float64
+	}{
+		{probability: 1, pLow: 0, pHigh: 1, lowProbability: 1},
+		{probability: 0.25, pLow: 2, pHigh: 3, lowProbability: 1},
+		{probability: 0.1, pLow: 3, pHigh: 4, lowProbability: 0.6},
+		{probability: 0, pLow: MaxPValue, pHigh: MaxPValue, lowProbability: 1},
+		{probability: math.Ldexp(1, -70), pLow: MaxRValue, pHigh: MaxRValue, lowProbability: 1},
+	} {
+		s, err := NewConsistentSampler(tt.probability)
+		require.NoError(t, err)
+		assert.Equal(t, tt.pLow, s.pLow)
+		assert.Equal(t, tt.pHigh, s.pHigh)
+		assert.InDelta(t, tt.lowProbability, s.lowProbability, 1e-9)
+	}
+
+	for _, probability := range []float64{-0.1, 1.1, math.NaN()} {
+		_, err := NewConsistentSampler(probability)
+		assert.ErrorIs(t, err, ErrInvalidProbability)
+	}
+}
+
+func TestConsistentSamplerRate(t *testing.T) {
+	for _, probability := range []float64{1, 0.5, 0.1, 0.01, 0} {
+		s, err := NewConsistentSampler(probability)
+		require.NoError(t, err)
+
+		const total = 100000
+		sampled := 0
+		adjusted := 0.0
+		for i := 0; i < total; i++ {
+			var ts OTelTraceState
+			if s.Decide(traceIDFromInt(i), &ts) {
+				sampled++
+				count, ok := ts.AdjustedCount()
+				require.True(t, ok)
+				adjusted += count
+			}
+		}
+
+		assert.InDelta(t, probability, float64(sampled)/total, 0.005, "probability %v", probability)
+		if probability > 0 {
+			// the adjusted counts estimate the size of the original population
+			assert.InDelta(t, total, adjusted, 0.05*total, "probability %v", probability)
+		}
+	}
+}
+
+func TestConsistentSamplerDecide(t *testing.T) {
+	traceID := traceIDFromInt(42)
+
+	for _, tt := range []struct {
+		desc        string
+		probability float64
+		input       string
+		sampled     bool
+		expected    string
+	}{
+		{
+			desc:        "r-value is derived from the trace ID",
+			probability: 1,
+			input:       "",
+			sampled:     true,
+			expected:    "p:0;r:" + itoa(RValueFromTraceID(traceID)),
+		},
+		{
+			desc:        "p-value without r-value is discarded",
+			probability: 1,
+			input:       "p:5",
+			sampled:     true,
+			expected:    "p:0;r:" + itoa(RValueFromTraceID(traceID)),
+		},
+		{
+			desc:        "sampled with a lower probability",
+			probability: 0.5,
+			input:       "p:0;r:3",
+			sampled:     true,
+			expected:    "p:1;r:3",
+		},
+		{
+			desc:        "not sampled with a lower probability",
+			probability: 0.0625,
+			input:       "p:0;r:3",
+			sampled:     false,
+			expected:    "p:0;r:3",
+		},
+		{
+			desc:        "upstream decision with a lower probability is kept",
+			probability: 0.5,
+			input:       "p:3;r:3",
+			sampled:     true,
+			expected:    "p:3;r:3",
+		},
+		{
+			desc:        "inconsistent upstream p-value is replaced",
+			probability: 0.5,
+			input:       "p:5;r:3",
+			sampled:     true,
+			expected:    "p:1;r:3",
+		},
+		{
+			desc:        "zero adjusted count is kept",
+			probability: 0.5,
+			input:       "p:63;r:3",
+			sampled:     true,
+			expected:    "p:63;r:3",
+		},
+		{
+			desc:        "zero probability",
+			probability: 0,
+			input:       "r:62",
+			sampled:     false,
+			expected:    "r:62",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, err := NewConsistentSampler(tt.probability)
+			require.NoError(t, err)
+			ts, err := ParseOTelTraceState(tt.input)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.sampled, s.Decide(traceID, &ts))
+			assert.Equal(t, tt.expected, ts.String())
+		})
+	}
+}
+
+func TestConsistentSamplersAgree(t *testing.T) {
+	// a trace sampled at 1/8 is always sampled at 1/2, as their r-value is the same
+	low, err := NewConsistentSampler(0.125)
+	require.NoError(t, err)
+	high, err := NewConsistentSampler(0.5)
+	require.NoError(t, err)
+
+	for i := 0; i < 10000; i++ {
+		var lowTS, highTS OTelTraceState
+		traceID := traceIDFromInt(i)
+		if low.Decide(traceID, &lowTS) {
+			assert.True(t, high.Decide(traceID, &highTS))
+		}
+	}
+}
+
+func traceIDFromInt(i int) pcommon.TraceID {
+	var traceID pcommon.TraceID
+	binary.BigEndian.PutUint64(traceID[8:], uint64(i))
+	return traceID
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	// OTelVendorKey is the key of the OpenTelemetry entry in the W3C tracestate.
+	OTelVendorKey = "ot"
+
+	pValueKey = "p"
+	rValueKey = "r"
+)
+
+// ErrInvalidTraceState is returned when a tracestate, or its OpenTelemetry entry, can't be parsed.
+var ErrInvalidTraceState = errors.New("invalid tracestate")
+
+// W3CTraceState is a parsed W3C tracestate, in which the OpenTelemetry entry is made
+// available for inspection and modification. The entries of other vendors are kept as-is.
+type W3CTraceState struct {
+	otel   OTelTraceState
+	others []string
+}
+
+// ParseW3CTraceState parses the given W3C tracestate. When the OpenTelemetry entry contains
+// invalid values, an error is returned together with the tracestate, from which the invalid
+// values have been removed.
+func ParseW3CTraceState(input string) (W3CTraceState, error) {
+	var ts W3CTraceState
+	var errs error
+	for _, member := range strings.Split(input, ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(member, "=")
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("%w: list member without a value: %q", ErrInvalidTraceState, member))
+			continue
+		}
+
+		if key != OTelVendorKey {
+			ts.others = append(ts.others, member)
+			continue
+		}
+
+		otel, err := ParseOTelTraceState(value)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+		}
+		ts.otel = otel
+	}
+	return ts, errs
+}
+
+// OTel returns the OpenTelemetry entry of the tracestate.
+func (ts *W3CTraceState) OTel() *OTelTraceState {
+	return &ts.otel
+}
+
+// String returns the W3C tracestate encoding of the tracestate. As required by the W3C
+// specification for modified entries, the OpenTelemetry entry comes first.
+func (ts *W3CTraceState) String() string {
+	members := make([]string, 0, len(ts.others)+1)
+	if !ts.otel.Empty() {
+		members = append(members, OTelVendorKey+"="+ts.otel.String())
+	}
+	members = append(members, ts.others...)
+	return strings.Join(members, ",")
+}
+
+// OTelTraceState is the OpenTelemetry entry of the W3C tracestate, holding the p-value and
+// the r-value used for consistent probability sampling. Other fields are kept as-is.
+type OTelTraceState struct {
+	pValue int
+	rValue int
+	hasP   bool
+	hasR   bool
+	extra  []string
+}
+
+// ParseOTelTraceState parses the value of the OpenTelemetry entry of the tracestate, like
+// "p:2;r:10". Invalid p-values or r-values are left out of the result and reported as an error.
+func ParseOTelTraceState(input string) (OTelTraceState, error) {
+	var ts OTelTraceState
+	var errs error
+	for _, field := range strings.Split(input, ";") {
+		if field == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(field, ":")
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("%w: field without a value: %q", ErrInvalidTraceState, field))
+			continue
+		}
+
+		switch key {
+		case pValueKey:
+			p, err := parseValue(value, MaxPValue)
+			if err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("%w: p-value: %v", ErrInvalidTraceState, err))
+				continue
+			}
+			ts.pValue, ts.hasP = p, true
+		case rValueKey:
+			r, err := parseValue(value, MaxRValue)
+			if err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("%w: r-value: %v", ErrInvalidTraceState, err))
+				continue
+			}
+			ts.rValue, ts.hasR = r, true
+		default:
+			ts.extra = append(ts.extra, field)
+		}
+	}
+	return ts, errs
+}
+
+func parseValue(value string, maxValue int) (int, error) {
+	v, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, err
+	}
+	if int(v) > maxValue {
+		return 0, fmt.Errorf("%d is out of range [0, %d]", v, maxValue)
+	}
+	return int(v), nil
+}
+
+// PValue returns the p-value, and whether it is set.
+func (ts *OTelTraceState) PValue() (int, bool) {
+	return ts.pValue, ts.hasP
+}
+
+// SetPValue sets the p-value, which must be between 0 and MaxPValue.
+func (ts *OTelTraceState) SetPValue(p int) error {
+	if p < 0 || p > MaxPValue {
+		return fmt.Errorf("p-value %d is out of range [0, %d]", p, MaxPValue)
+	}
+	ts.pValue, ts.hasP = p, true
+	return nil
+}
+
+// UnsetPValue removes the p-value.
+func (ts *OTelTraceState) UnsetPValue() {
+	ts.pValue, ts.hasP = 0, false
+}
+
+// RValue returns the r-value, and whether it is set.
+func (ts *OTelTraceState) RValue() (int, bool) {
+	return ts.rValue, ts.hasR
+}
+
+// SetRValue sets the r-value, which must be between 0 and MaxRValue.
+func (ts *OTelTraceState) SetRValue(r int) error {
+	if r < 0 || r > MaxRValue {
+		return fmt.Errorf("r-value %d is out of range [0, %d]", r, MaxRValue)
+	}
+	ts.rValue, ts.hasR = r, true
+	return nil
+}
+
+// AdjustedCount returns the number of spans in the population represented by a span with
+// this tracestate, and whether it is known. It is zero for spans that weren't sampled based
+// on their probability.
+func (ts *OTelTraceState) AdjustedCount() (float64, bool) {
+	if !ts.hasP {
+		return 0, false
+	}
+	if ts.pValue == MaxPValue {
+		return 0, true
+	}
+	return float64(uint64(1) << ts.pValue), true
+}
+
+// Empty returns whether the entry has no fields at all.
+func (ts *OTelTraceState) Empty() bool {
+	return !ts.hasP && !ts.hasR && len(ts.extra) == 0
+}
+
+// String returns the encoding of the entry, to be used as the value of the OpenTelemetry
+// entry of the tracestate.
+func (ts *OTelTraceState) String() string {
+	fields := make([]string, 0, len(ts.extra)+2)
+	if ts.hasP {
+		fields = append(fields, pValueKey+":"+strconv.Itoa(ts.pValue))
+	}
+	if ts.hasR {
+		fields = append(fields, rValueKey+":"+strconv.Itoa(ts.rValue))
+	}
+	fields = append(fields, ts.extra...)
+	return strings.Join(fields, ";")
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseW3CTraceState(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		input    string
+		p, r     int
+		hasP     bool
+		hasR     bool
+		expected string
+		err      string
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc:     "p and r values",
+			input:    "ot=p:2;r:10",
+			p:        2,
+			r:        10,
+			hasP:     true,
+			hasR:     true,
+			expected: "ot=p:2;r:10",
+		},
+		{
+			desc:     "other vendors and fields are kept",
+			input:    "vendor1=value1, ot=r:3;x:y ,vendor2=value2",
+			r:        3,
+			hasR:     true,
+			expected: "ot=r:3;x:y,vendor1=value1,vendor2=value2",
+		},
+		{
+			desc:     "p-value out of range",
+			input:    "ot=p:64;r:10",
+			r:        10,
+			hasR:     true,
+			expected: "ot=r:10",
+			err:      "invalid tracestate: p-value: 64 is out of range [0, 63]",
+		},
+		{
+			desc:     "invalid r-value",
+			input:    "ot=p:2;r:abc",
+			p:        2,
+			hasP:     true,
+			expected: "ot=p:2",
+			err:      `invalid tracestate: r-value: strconv.ParseUint: parsing "abc": invalid syntax`,
+		},
+		{
+			desc:     "invalid list member",
+			input:    "vendor1,ot=r:1",
+			r:        1,
+			hasR:     true,
+			expected: "ot=r:1",
+			err:      `invalid tracestate: list member without a value: "vendor1"`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ts, err := ParseW3CTraceState(tt.input)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			p, hasP := ts.OTel().PValue()
+			assert.Equal(t, tt.hasP, hasP)
+			assert.Equal(t, tt.p, p)
+			r, hasR := ts.OTel().RValue()
+			assert.Equal(t, tt.hasR, hasR)
+			assert.Equal(t, tt.r, r)
+			assert.Equal(t, tt.expected, ts.String())
+		})
+	}
+}
+
+func TestOTelTraceStateSetters(t *testing.T) {
+	var ts OTelTraceState
+	assert.True(t, ts.Empty())
+
+	assert.Error(t, ts.SetPValue(MaxPValue+1))
+	assert.Error(t, ts.SetRValue(MaxRValue+1))
+	assert.True(t, ts.Empty())
+
+	require.NoError(t, ts.SetPValue(3))
+	require.NoError(t, ts.SetRValue(5))
+	assert.Equal(t, "p:3;r:5", ts.String())
+
+	ts.UnsetPValue()
+	assert.Equal(t, "r:5", ts.String())
+}
+
+func TestAdjustedCount(t *testing.T) {
+	var ts OTelTraceState
+	_, ok := ts.AdjustedCount()
+	assert.False(t, ok)
+
+	require.NoError(t, ts.SetPValue(0))
+	count, ok := ts.AdjustedCount()
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, count)
+
+	require.NoError(t, ts.SetPValue(4))
+	count, _ = ts.AdjustedCount()
+	assert.Equal(t, 16.0, count)
+
+	require.NoError(t, ts.SetPValue(MaxPValue))
+	count, ok = ts.AdjustedCount()
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, count)
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionNone = ""
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+	compressionAuto = "auto"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// detectCompression resolves the compression of a file.
+// When configured as "auto", the format is detected from the file's magic bytes.
+func detectCompression(file *os.File, compression string) (string, error) {
+	if compression != compressionAuto {
+		return compression, nil
+	}
+
+	buf := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading magic bytes: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(buf[:n], gzipMagic):
+		return compressionGzip, nil
+	case bytes.HasPrefix(buf[:n], zstdMagic):
+		return compressionZstd, nil
+	default:
+		return compressionNone, nil
+	}
+}
+
+// decompressedReader reads the decompressed stream of a file.
+// A stream that ends unexpectedly is treated as an archive that is still
+// being written, so it is reported as io.EOF and flagged as truncated.
+type decompressedReader struct {
+	io.ReadCloser
+	truncated bool
+}
+
+func newDecompressedReader(src io.Reader, compression string) (*decompressedReader, error) {
+	switch compression {
+	case compressionGzip:
+		zr, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		return &decompressedReader{ReadCloser: zr}, nil
+	case compressionZstd:
+		zr, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		return &decompressedReader{ReadCloser: zr.IOReadCloser()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+func (d *decompressedReader) Read(dst []byte) (int, error) {
+	n, err := d.ReadCloser.Read(dst)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		d.truncated = true
+		err = io.EOF
+	}
+	return n, err
+}
+
+// newDecompressedFingerprint creates a fingerprint from the first N bytes of
+// the decompressed stream, so that a compressed file matches the fingerprint
+// of the plain file it was archived from
+func newDecompressedFingerprint(file *os.File, compression string, size int) (*Fingerprint, error) {
+	dr, err := newDecompressedReader(io.NewSectionReader(file, 0, math.MaxInt64), compression)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// The header has not been fully written yet
+		return &Fingerprint{FirstBytes: []byte{}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+	defer dr.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(dr, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	return &Fingerprint{FirstBytes: buf[:n]}, nil
+}
@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newCompressor(t testing.TB, w io.Writer, compression string) io.WriteCloser {
+	switch compression {
+	case compressionGzip:
+		return gzip.NewWriter(w)
+	case compressionZstd:
+		zw, err := zstd.NewWriter(w)
+		require.NoError(t, err)
+		return zw
+	}
+	require.FailNow(t, "unsupported compression", compression)
+	return nil
+}
+
+// writeCompressed appends s to the file as a complete compressed stream
+func writeCompressed(t testing.TB, file *os.File, compression, s string) {
+	cw := newCompressor(t, file, compression)
+	_, err := cw.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, cw.Close())
+}
+
+func TestDetectCompression(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+
+	plain := openTemp(t, tempDir)
+	writeString(t, plain, "testlog1\n")
+	gz := openTemp(t, tempDir)
+	writeCompressed(t, gz, compressionGzip, "testlog1\n")
+	zst := openTemp(t, tempDir)
+	writeCompressed(t, zst, compressionZstd, "testlog1\n")
+	empty := openTemp(t, tempDir)
+
+	testCases := []struct {
+		name       string
+		file       *os.File
+		configured string
+		expected   string
+	}{
+		{"auto_plain", plain, compressionAuto, compressionNone},
+		{"auto_gzip", gz, compressionAuto, compressionGzip},
+		{"auto_zstd", zst, compressionAuto, compressionZstd},
+		{"auto_empty", empty, compressionAuto, compressionNone},
+		{"explicit", plain, compressionGzip, compressionGzip},
+		{"none", gz, compressionNone, compressionNone},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			compression, err := detectCompression(tc.file, tc.configured)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, compression)
+		})
+	}
+}
+
+func TestDecompressedFingerprint(t *testing.T) {
+	t.Parallel()
+
+	for _, compression := range []string{compressionGzip, compressionZstd} {
+		compression := compression
+		t.Run(compression, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			plain := openTemp(t, tempDir)
+			writeString(t, plain, "testlog1\ntestlog2\n")
+			archive := openTemp(t, tempDir)
+			writeCompressed(t, archive, compression, "testlog1\ntestlog2\ntestlog3\n")
+
+			plainFp, err := NewFingerprint(plain, DefaultFingerprintSize)
+			require.NoError(t, err)
+			archiveFp, err := newDecompressedFingerprint(archive, compression, DefaultFingerprintSize)
+			require.NoError(t, err)
+
+			require.Equal(t, []byte("testlog1\ntestlog2\ntestlog3\n"), archiveFp.FirstBytes)
+			require.True(t, archiveFp.StartsWith(plainFp))
+		})
+	}
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		compression string
+		configured  string
+	}{
+		{"gzip", compressionGzip, compressionGzip},
+		{"gzip_auto", compressionGzip, compressionAuto},
+		{"zstd", compressionZstd, compressionZstd},
+		{"zstd_auto", compressionZstd, compressionAuto},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.configured
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			archive := openTemp(t, tempDir)
+			writeCompressed(t, archive, tc.compression, "testlog1\ntestlog2\n")
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForToken(t, emitCalls, []byte("testlog1"))
+			waitForToken(t, emitCalls, []byte("testlog2"))
+			expectNoTokens(t, emitCalls)
+		})
+	}
+}
+
+func TestReadCompressedAndPlainFiles(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	plain := openTemp(t, tempDir)
+	writeString(t, plain, "plain1\n")
+	archive := openTemp(t, tempDir)
+	writeCompressed(t, archive, compressionGzip, "archive1\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{[]byte("plain1"), []byte("archive1")})
+}
+
+// TestRotateToCompressedFile checks that a file archived by compression
+// is matched to the reader of the original file, so only the lines that
+// were not yet read from the original file are emitted
+func TestRotateToCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	plainPath := filepath.Join(tempDir, "app.log")
+	plain := openFile(t, plainPath)
+	writeString(t, plain, "testlog1\ntestlog2\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	// The last line is written just before the file is archived
+	archive := openFile(t, filepath.Join(tempDir, "app.log.1.gz"))
+	writeCompressed(t, archive, compressionGzip, "testlog1\ntestlog2\ntestlog3\n")
+	require.NoError(t, plain.Close())
+	require.NoError(t, os.Remove(plainPath))
+
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestCompressedRestartOffsets(t *testing.T) {
+	t.Parallel()
+
+	for _, compression := range []string{compressionGzip, compressionZstd} {
+		compression := compression
+		t.Run(compression, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = compressionAuto
+
+			persister := testutil.NewMockPersister("test")
+
+			archive := openTemp(t, tempDir)
+			writeCompressed(t, archive, compression, "testlog1\ntestlog2\n")
+
+			operatorOne, emitCallsOne := buildTestManager(t, cfg)
+			require.NoError(t, operatorOne.Start(persister))
+			waitForToken(t, emitCallsOne, []byte("testlog1"))
+			waitForToken(t, emitCallsOne, []byte("testlog2"))
+			expectNoTokens(t, emitCallsOne)
+			require.NoError(t, operatorOne.Stop())
+
+			// Both formats allow concatenated streams
+			writeCompressed(t, archive, compression, "testlog3\n")
+
+			operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+			require.NoError(t, operatorTwo.Start(persister))
+			waitForToken(t, emitCallsTwo, []byte("testlog3"))
+			expectNoTokens(t, emitCallsTwo)
+			require.NoError(t, operatorTwo.Stop())
+		})
+	}
+}
+
+func TestCompressedFileNotReadAgain(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionGzip
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	archive := openTemp(t, tempDir)
+	writeCompressed(t, archive, compressionGzip, "testlog1\n")
+	info, err := archive.Stat()
+	require.NoError(t, err)
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	expectNoTokens(t, emitCalls)
+	require.NoError(t, operator.Stop())
+
+	reader, err := operator.readerFactory.copy(&Reader{
+		Fingerprint:    &Fingerprint{FirstBytes: []byte("testlog1\n")},
+		Offset:         int64(len("testlog1\n")),
+		ArchiveSize:    info.Size(),
+		FileAttributes: &FileAttributes{},
+	}, openFile(t, archive.Name()))
+	require.NoError(t, err)
+	defer reader.Close()
+
+	reader.ReadToEnd(context.Background())
+	require.True(t, reader.eof)
+	require.Nil(t, reader.decompressor, "an unchanged archive should not be decompressed")
+}
+
+func TestReadTruncatedArchive(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionGzip
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	archive := openTemp(t, tempDir)
+	gw := gzip.NewWriter(archive)
+	_, err := gw.Write([]byte("testlog1\n"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Flush())
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1"))
+	expectNoTokens(t, emitCalls)
+
+	_, err = gw.Write([]byte("testlog2\n"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	expectNoTokens(t, emitCalls)
+}
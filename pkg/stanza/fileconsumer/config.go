@@ -30,6 +30,7 @@ import (
 const (
 	defaultMaxLogSize         = 1024 * 1024
 	defaultMaxConcurrentFiles = 1024
+	defaultWatchFallback      = time.Minute
 )
 
 var allowFileDeletion = featuregate.GlobalRegistry().MustRegister(
@@ -54,6 +55,8 @@ func NewConfig() *Config {
 		IncludeFileNameResolved: false,
 		IncludeFilePathResolved: false,
 		PollInterval:            200 * time.Millisecond,
+		WatchMode:               watchModePoll,
+		WatchFallbackInterval:   defaultWatchFallback,
 		Splitter:                helper.NewSplitterConfig(),
 		StartAt:                 "end",
 		FingerprintSize:         DefaultFingerprintSize,
@@ -71,12 +74,15 @@ type Config struct {
 	IncludeFileNameResolved bool                  `mapstructure:"include_file_name_resolved,omitempty"`
 	IncludeFilePathResolved bool                  `mapstructure:"include_file_path_resolved,omitempty"`
 	PollInterval            time.Duration         `mapstructure:"poll_interval,omitempty"`
+	WatchMode               string                `mapstructure:"watch_mode,omitempty"`
+	WatchFallbackInterval   time.Duration         `mapstructure:"watch_fallback_interval,omitempty"`
 	StartAt                 string                `mapstructure:"start_at,omitempty"`
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
 	MaxBatches              int                   `mapstructure:"max_batches,omitempty"`
 	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
 	Header                  *HeaderConfig         `mapstructure:"header,omitempty"`
 }
@@ -151,6 +157,11 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 		}
 	}
 
+	orderer, err := c.OrderingCriteria.build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ordering criteria: %w", err)
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
@@ -159,6 +170,7 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 			readerConfig: &readerConfig{
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
+				compression:     c.Compression,
 				emit:            emit,
 			},
 			fromBeginning:   startAtBeginning,
@@ -167,8 +179,11 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 			headerSettings:  hs,
 		},
 		finder:          c.Finder,
+		orderer:         orderer,
 		roller:          newRoller(),
 		pollInterval:    c.PollInterval,
+		watchMode:       c.WatchMode,
+		watchFallback:   c.WatchFallbackInterval,
 		maxBatchFiles:   c.MaxConcurrentFiles / 2,
 		maxBatches:      c.MaxBatches,
 		deleteAfterRead: c.DeleteAfterRead,
@@ -222,6 +237,22 @@ func (c Config) validate() error {
 		return errors.New("`max_batches` must not be negative")
 	}
 
+	switch c.WatchMode {
+	case "", watchModePoll:
+	case watchModeFsnotify:
+		if c.WatchFallbackInterval <= 0 {
+			return errors.New("`watch_fallback_interval` must be positive")
+		}
+	default:
+		return fmt.Errorf("invalid `watch_mode` '%s'", c.WatchMode)
+	}
+
+	switch c.Compression {
+	case compressionNone, compressionGzip, compressionZstd, compressionAuto:
+	default:
+		return fmt.Errorf("invalid `compression` '%s'", c.Compression)
+	}
+
 	_, err := c.Splitter.EncodingConfig.Build()
 	if err != nil {
 		return err
@@ -233,5 +264,9 @@ func (c Config) validate() error {
 		}
 	}
 
+	if _, err := c.OrderingCriteria.build(); err != nil {
+		return fmt.Errorf("invalid config for `ordering_criteria`: %w", err)
+	}
+
 	return nil
 }
@@ -363,6 +363,43 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "watch_mode",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.WatchMode = "fsnotify"
+					cfg.WatchFallbackInterval = 30 * time.Second
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "compression_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "auto"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "ordering_criteria",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.OrderingCriteria = OrderingCriteria{
+						Regex:   `^(?P<dir>[^/]+)/app-(?P<ts>\d{8})\.log$`,
+						GroupBy: "dir",
+						TopN:    2,
+						SortBy: []SortRule{
+							{
+								SortType: "timestamp",
+								RegexKey: "ts",
+								Layout:   "%Y%m%d",
+								Location: "UTC",
+							},
+						},
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "header_config",
 				Expect: func() *mockOperatorConfig {
@@ -537,6 +574,63 @@ func TestBuild(t *testing.T) {
 				require.Equal(t, 6, m.maxBatches)
 			},
 		},
+		{
+			"InvalidWatchMode",
+			func(f *Config) {
+				f.WatchMode = "inotify"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidWatchFallbackInterval",
+			func(f *Config) {
+				f.WatchMode = "fsnotify"
+				f.WatchFallbackInterval = 0
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ValidWatchMode",
+			func(f *Config) {
+				f.WatchMode = "fsnotify"
+				f.WatchFallbackInterval = 10 * time.Second
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "fsnotify", m.watchMode)
+				require.Equal(t, 10*time.Second, m.watchFallback)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "bzip2"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ValidCompression",
+			func(f *Config) {
+				f.Compression = "zstd"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "zstd", m.readerFactory.readerConfig.compression)
+			},
+		},
+		{
+			"InvalidOrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					SortBy: []SortRule{{SortType: "numeric", RegexKey: "num"}},
+				}
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"HeaderConfigNoFlag",
 			func(f *Config) {
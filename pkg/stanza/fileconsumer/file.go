@@ -18,11 +18,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sync"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -37,10 +39,14 @@ type Manager struct {
 
 	readerFactory readerFactory
 	finder        Finder
+	orderer       *fileOrderer
 	roller        roller
 	persister     operator.Persister
 
 	pollInterval    time.Duration
+	watchMode       string
+	watchFallback   time.Duration
+	watcher         *dirWatcher
 	maxBatches      int
 	maxBatchFiles   int
 	deleteAfterRead bool
@@ -65,6 +71,16 @@ func (m *Manager) Start(persister operator.Persister) error {
 			"exclude", m.finder.Exclude)
 	}
 
+	if m.watchMode == watchModeFsnotify {
+		watcher, err := newDirWatcher(m.SugaredLogger, m.finder)
+		if err == nil {
+			m.watcher = watcher
+			m.startWatcher(ctx)
+			return nil
+		}
+		m.Warnw("Failed to create file system watcher, falling back to polling", zap.Error(err))
+	}
+
 	// Start polling goroutine
 	m.startPoller(ctx)
 
@@ -75,6 +91,7 @@ func (m *Manager) Start(persister operator.Persister) error {
 func (m *Manager) Stop() error {
 	m.cancel()
 	m.wg.Wait()
+	m.watcher = nil
 	m.roller.cleanup()
 	for _, reader := range m.knownFiles {
 		reader.Close()
@@ -105,6 +122,58 @@ func (m *Manager) startPoller(ctx context.Context) {
 	}()
 }
 
+// startWatcher kicks off a goroutine that polls the filesystem when it is notified
+// of changes to the matched files. Polls are at least pollInterval apart, and the
+// filesystem is still polled every watchFallback in case events were missed.
+func (m *Manager) startWatcher(ctx context.Context) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer m.watcher.close()
+		globTicker := time.NewTicker(m.pollInterval)
+		defer globTicker.Stop()
+
+		var lastPoll time.Time
+		pending := true
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-m.watcher.watcher.Events:
+				if !ok {
+					return
+				}
+				if !m.watcher.relevant(event) {
+					continue
+				}
+				pending = true
+				if time.Since(lastPoll) < m.pollInterval {
+					continue
+				}
+			case err, ok := <-m.watcher.watcher.Errors:
+				if !ok {
+					return
+				}
+				if errors.Is(err, fsnotify.ErrEventOverflow) {
+					m.Warnw("File system events were dropped, polling all files", zap.Error(err))
+				} else {
+					m.Errorw("File system watcher failed", zap.Error(err))
+				}
+				pending = true
+				continue
+			case <-globTicker.C:
+				if !pending && time.Since(lastPoll) < m.watchFallback {
+					continue
+				}
+			}
+
+			pending = false
+			lastPoll = time.Now()
+			m.poll(ctx)
+		}
+	}()
+}
+
 // poll checks all the watched paths for new entries
 func (m *Manager) poll(ctx context.Context) {
 	// Increment the generation on all known readers
@@ -118,6 +187,15 @@ func (m *Manager) poll(ctx context.Context) {
 
 	// Get the list of paths on disk
 	matches := m.finder.FindFiles()
+	if m.watcher != nil {
+		m.watcher.update(matches)
+	}
+	if m.orderer != nil {
+		var err error
+		if matches, err = m.orderer.apply(matches); err != nil {
+			m.Debugw("Skipping files that cannot be ordered", zap.Error(err))
+		}
+	}
 	for len(matches) > m.maxBatchFiles {
 		m.consume(ctx, matches[:m.maxBatchFiles])
 
@@ -19,8 +19,9 @@ import (
 )
 
 type Finder struct {
-	Include []string `mapstructure:"include,omitempty"`
-	Exclude []string `mapstructure:"exclude,omitempty"`
+	Include          []string         `mapstructure:"include,omitempty"`
+	Exclude          []string         `mapstructure:"exclude,omitempty"`
+	OrderingCriteria OrderingCriteria `mapstructure:"ordering_criteria,omitempty"`
 }
 
 // FindFiles gets a list of paths given an array of glob patterns to include and exclude
@@ -48,3 +49,18 @@ func (f Finder) FindFiles() []string {
 
 	return all
 }
+
+// matches returns true if the path is included and not excluded
+func (f Finder) matches(path string) bool {
+	for _, exclude := range f.Exclude {
+		if itMatches, _ := doublestar.PathMatch(exclude, path); itMatches {
+			return false
+		}
+	}
+	for _, include := range f.Include {
+		if itMatches, _ := doublestar.PathMatch(include, path); itMatches {
+			return true
+		}
+	}
+	return false
+}
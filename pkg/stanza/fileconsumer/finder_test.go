@@ -149,7 +149,7 @@ func TestFinder(t *testing.T) {
 				require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0000))
 			}
 
-			finder := Finder{include, exclude}
+			finder := Finder{Include: include, Exclude: exclude}
 			require.ElementsMatch(t, finder.FindFiles(), expected)
 		})
 	}
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	strptime "github.com/observiq/ctimefmt"
+	"go.uber.org/multierr"
+)
+
+const (
+	sortTypeNumeric      = "numeric"
+	sortTypeTimestamp    = "timestamp"
+	sortTypeAlphabetical = "alphabetical"
+	sortTypeMtime        = "mtime"
+
+	defaultOrderingTopN = 1
+)
+
+// OrderingCriteria selects the most relevant of the files matched by the Finder.
+// Files are sorted by each rule of SortBy in turn, and only the first TopN files
+// of each group are consumed.
+type OrderingCriteria struct {
+	Regex   string     `mapstructure:"regex,omitempty"`
+	GroupBy string     `mapstructure:"group_by,omitempty"`
+	TopN    int        `mapstructure:"top_n,omitempty"`
+	SortBy  []SortRule `mapstructure:"sort_by,omitempty"`
+}
+
+// SortRule defines how files are compared.
+// Unless Ascending is set, files with the highest value are considered the most relevant.
+type SortRule struct {
+	SortType  string `mapstructure:"sort_type,omitempty"`
+	RegexKey  string `mapstructure:"regex_key,omitempty"`
+	Ascending bool   `mapstructure:"ascending,omitempty"`
+	Layout    string `mapstructure:"layout,omitempty"`
+	Location  string `mapstructure:"location,omitempty"`
+}
+
+type fileOrderer struct {
+	regex   *regexp.Regexp
+	groupBy string
+	topN    int
+	rules   []sortRule
+}
+
+type sortRule struct {
+	SortRule
+	layout   string
+	location *time.Location
+}
+
+// orderedFile holds the values a file is sorted and grouped by
+type orderedFile struct {
+	path   string
+	group  string
+	values []any
+}
+
+// build returns nil if no ordering is configured
+func (c OrderingCriteria) build() (*fileOrderer, error) {
+	if c.Regex == "" && c.GroupBy == "" && len(c.SortBy) == 0 {
+		return nil, nil
+	}
+
+	if c.TopN < 0 {
+		return nil, errors.New("`top_n` must not be negative")
+	}
+
+	o := &fileOrderer{
+		groupBy: c.GroupBy,
+		topN:    c.TopN,
+		rules:   make([]sortRule, 0, len(c.SortBy)),
+	}
+	if o.topN == 0 {
+		o.topN = defaultOrderingTopN
+	}
+
+	if c.Regex != "" {
+		regex, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("compile `regex`: %w", err)
+		}
+		o.regex = regex
+	}
+
+	if c.GroupBy != "" {
+		if err := o.checkRegexKey(c.GroupBy); err != nil {
+			return nil, fmt.Errorf("`group_by`: %w", err)
+		}
+	}
+
+	for i, rule := range c.SortBy {
+		built, err := o.buildSortRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("`sort_by[%d]`: %w", i, err)
+		}
+		o.rules = append(o.rules, built)
+	}
+
+	return o, nil
+}
+
+func (o *fileOrderer) checkRegexKey(key string) error {
+	if o.regex == nil {
+		return fmt.Errorf("capture group '%s' requires `regex`", key)
+	}
+	if o.regex.SubexpIndex(key) < 0 {
+		return fmt.Errorf("`regex` has no capture group named '%s'", key)
+	}
+	return nil
+}
+
+func (o *fileOrderer) buildSortRule(rule SortRule) (sortRule, error) {
+	built := sortRule{SortRule: rule}
+	switch rule.SortType {
+	case sortTypeMtime:
+		return built, nil
+	case sortTypeNumeric, sortTypeAlphabetical:
+	case sortTypeTimestamp:
+		if rule.Layout == "" {
+			return built, errors.New("`layout` is required for sort type 'timestamp'")
+		}
+		layout, err := strptime.ToNative(rule.Layout)
+		if err != nil {
+			return built, fmt.Errorf("parse strptime layout: %w", err)
+		}
+		built.layout = layout
+
+		built.location = time.UTC
+		if rule.Location != "" {
+			if built.location, err = time.LoadLocation(rule.Location); err != nil {
+				return built, fmt.Errorf("failed to load location %s: %w", rule.Location, err)
+			}
+		}
+	default:
+		return built, fmt.Errorf("invalid sort type '%s'", rule.SortType)
+	}
+
+	if err := o.checkRegexKey(rule.RegexKey); err != nil {
+		return built, fmt.Errorf("`regex_key`: %w", err)
+	}
+	return built, nil
+}
+
+// apply returns the most relevant files of each group, most relevant first.
+// Files that do not match the regex, or whose values cannot be parsed, are
+// left out and reported in the returned error.
+func (o *fileOrderer) apply(paths []string) ([]string, error) {
+	var errs error
+	files := make([]orderedFile, 0, len(paths))
+	for _, path := range paths {
+		file, err := o.newOrderedFile(path)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		files = append(files, file)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].group != files[j].group {
+			return files[i].group < files[j].group
+		}
+		for k, rule := range o.rules {
+			if c := compareValues(files[i].values[k], files[j].values[k]); c != 0 {
+				return (c < 0) == rule.Ascending
+			}
+		}
+		return false
+	})
+
+	selected := make([]string, 0, len(files))
+	for i, inGroup := 0, 0; i < len(files); i++ {
+		if i > 0 && files[i].group != files[i-1].group {
+			inGroup = 0
+		}
+		if inGroup < o.topN {
+			selected = append(selected, files[i].path)
+		}
+		inGroup++
+	}
+	return selected, errs
+}
+
+func (o *fileOrderer) newOrderedFile(path string) (orderedFile, error) {
+	file := orderedFile{path: path, values: make([]any, 0, len(o.rules))}
+
+	var matches []string
+	if o.regex != nil {
+		if matches = o.regex.FindStringSubmatch(path); matches == nil {
+			return file, fmt.Errorf("file '%s' does not match `regex`", path)
+		}
+	}
+	if o.groupBy != "" {
+		file.group = matches[o.regex.SubexpIndex(o.groupBy)]
+	}
+
+	for _, rule := range o.rules {
+		if rule.SortType == sortTypeMtime {
+			info, err := os.Stat(path)
+			if err != nil {
+				return file, fmt.Errorf("stat: %w", err)
+			}
+			file.values = append(file.values, info.ModTime())
+			continue
+		}
+
+		value := matches[o.regex.SubexpIndex(rule.RegexKey)]
+		switch rule.SortType {
+		case sortTypeNumeric:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return file, fmt.Errorf("parse '%s' of file '%s' as number: %w", value, path, err)
+			}
+			file.values = append(file.values, n)
+		case sortTypeTimestamp:
+			ts, err := time.ParseInLocation(rule.layout, value, rule.location)
+			if err != nil {
+				return file, fmt.Errorf("parse '%s' of file '%s' as timestamp: %w", value, path, err)
+			}
+			file.values = append(file.values, ts)
+		default:
+			file.values = append(file.values, value)
+		}
+	}
+	return file, nil
+}
+
+// compareValues compares two values produced by the same sort rule
+func compareValues(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case time.Time:
+		b := b.(time.Time)
+		switch {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+	case string:
+		switch b := b.(string); {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	}
+	return 0
+}
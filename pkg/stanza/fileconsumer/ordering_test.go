@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestOrderingCriteriaBuild(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		criteria    OrderingCriteria
+		expectedErr string
+	}{
+		{
+			name:     "Empty",
+			criteria: OrderingCriteria{},
+		},
+		{
+			name: "Numeric",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)\.log`,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "num"}},
+			},
+		},
+		{
+			name: "Mtime",
+			criteria: OrderingCriteria{
+				SortBy: []SortRule{{SortType: "mtime"}},
+			},
+		},
+		{
+			name: "NegativeTopN",
+			criteria: OrderingCriteria{
+				TopN:   -1,
+				SortBy: []SortRule{{SortType: "mtime"}},
+			},
+			expectedErr: "`top_n` must not be negative",
+		},
+		{
+			name: "BadRegex",
+			criteria: OrderingCriteria{
+				Regex: `(`,
+			},
+			expectedErr: "compile `regex`",
+		},
+		{
+			name: "GroupByWithoutRegex",
+			criteria: OrderingCriteria{
+				GroupBy: "dir",
+			},
+			expectedErr: "`group_by`: capture group 'dir' requires `regex`",
+		},
+		{
+			name: "MissingCaptureGroup",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<num>\d+)\.log`,
+				SortBy: []SortRule{{SortType: "alphabetical", RegexKey: "name"}},
+			},
+			expectedErr: "`sort_by[0]`: `regex_key`: `regex` has no capture group named 'name'",
+		},
+		{
+			name: "InvalidSortType",
+			criteria: OrderingCriteria{
+				SortBy: []SortRule{{SortType: "size"}},
+			},
+			expectedErr: "`sort_by[0]`: invalid sort type 'size'",
+		},
+		{
+			name: "TimestampWithoutLayout",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<ts>\d{8})\.log`,
+				SortBy: []SortRule{{SortType: "timestamp", RegexKey: "ts"}},
+			},
+			expectedErr: "`layout` is required for sort type 'timestamp'",
+		},
+		{
+			name: "TimestampBadLocation",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<ts>\d{8})\.log`,
+				SortBy: []SortRule{{SortType: "timestamp", RegexKey: "ts", Layout: "%Y%m%d", Location: "Not/AZone"}},
+			},
+			expectedErr: "failed to load location",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tc.criteria.build()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestOrderingCriteriaApply(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		criteria    OrderingCriteria
+		files       []string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name: "NumericDescending",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<num>\d+)\.log`,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "num"}},
+			},
+			files:    []string{"app.2.log", "app.10.log", "app.1.log"},
+			expected: []string{"app.10.log"},
+		},
+		{
+			name: "NumericAscendingTopN",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<num>\d+)\.log`,
+				TopN:   2,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "num", Ascending: true}},
+			},
+			files:    []string{"app.2.log", "app.10.log", "app.1.log"},
+			expected: []string{"app.1.log", "app.2.log"},
+		},
+		{
+			name: "Alphabetical",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<name>[a-z]+)\.log`,
+				TopN:   3,
+				SortBy: []SortRule{{SortType: "alphabetical", RegexKey: "name"}},
+			},
+			files:    []string{"b.log", "c.log", "a.log"},
+			expected: []string{"c.log", "b.log", "a.log"},
+		},
+		{
+			name: "Timestamp",
+			criteria: OrderingCriteria{
+				Regex:  `app-(?P<ts>\d{10})\.log`,
+				SortBy: []SortRule{{SortType: "timestamp", RegexKey: "ts", Layout: "%Y%m%d%H"}},
+			},
+			files:    []string{"app-2023010112.log", "app-2023010201.log", "app-2022123123.log"},
+			expected: []string{"app-2023010201.log"},
+		},
+		{
+			name: "GroupBy",
+			criteria: OrderingCriteria{
+				Regex:   `(?P<dir>[a-z]+)/app\.(?P<num>\d+)\.log`,
+				GroupBy: "dir",
+				SortBy:  []SortRule{{SortType: "numeric", RegexKey: "num"}},
+			},
+			files:    []string{"b/app.1.log", "a/app.1.log", "a/app.3.log", "b/app.2.log", "a/app.2.log"},
+			expected: []string{"a/app.3.log", "b/app.2.log"},
+		},
+		{
+			name: "TieBreak",
+			criteria: OrderingCriteria{
+				Regex: `app-(?P<day>\d+)-(?P<seq>\d+)\.log`,
+				SortBy: []SortRule{
+					{SortType: "numeric", RegexKey: "day"},
+					{SortType: "numeric", RegexKey: "seq"},
+				},
+			},
+			files:    []string{"app-2-1.log", "app-1-9.log", "app-2-3.log"},
+			expected: []string{"app-2-3.log"},
+		},
+		{
+			name: "NoMatch",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<num>\d+)\.log`,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "num"}},
+			},
+			files:       []string{"app.1.log", "other.log"},
+			expected:    []string{"app.1.log"},
+			expectedErr: true,
+		},
+		{
+			name: "NotANumber",
+			criteria: OrderingCriteria{
+				Regex:  `app\.(?P<num>\w+)\.log`,
+				SortBy: []SortRule{{SortType: "numeric", RegexKey: "num"}},
+			},
+			files:       []string{"app.1.log", "app.x.log"},
+			expected:    []string{"app.1.log"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			orderer, err := tc.criteria.build()
+			require.NoError(t, err)
+
+			selected, err := orderer.apply(tc.files)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expected, selected)
+		})
+	}
+}
+
+func TestOrderingCriteriaMtime(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	now := time.Now()
+	files := []string{"a.log", "b.log", "c.log"}
+	for i, name := range files {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(name), 0600))
+		mtime := now.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+
+	orderer, err := OrderingCriteria{
+		TopN:   2,
+		SortBy: []SortRule{{SortType: "mtime"}},
+	}.build()
+	require.NoError(t, err)
+
+	selected, err := orderer.apply(absPath(tempDir, files))
+	require.NoError(t, err)
+	require.Equal(t, absPath(tempDir, []string{"c.log", "b.log"}), selected)
+}
+
+func TestReadOrderedFiles(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig()
+	cfg.Include = []string{filepath.Join(tempDir, "*", "*.log")}
+	cfg.StartAt = "beginning"
+	cfg.OrderingCriteria = OrderingCriteria{
+		Regex:   `(?P<dir>[a-z]+)[\\/]app\.(?P<num>\d+)\.log$`,
+		GroupBy: "dir",
+		SortBy:  []SortRule{{SortType: "numeric", RegexKey: "num"}},
+	}
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	for _, name := range []string{"a/app.1.log", "a/app.2.log", "b/app.1.log"} {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(name+"\n"), 0600))
+	}
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{[]byte("a/app.2.log"), []byte("b/app.1.log")})
+}
@@ -18,6 +18,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -30,6 +31,7 @@ import (
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
+	compression     string
 	emit            EmitFunc
 }
 
@@ -49,6 +51,13 @@ type Reader struct {
 	FileAttributes *FileAttributes
 	eof            bool
 
+	// ArchiveSize is the size of a compressed file when it was last read in full.
+	// Offset is always expressed in decompressed bytes.
+	ArchiveSize  int64
+	compression  string
+	source       io.Reader
+	decompressor *decompressedReader
+
 	HeaderFinalized bool
 	recreateScanner bool
 
@@ -63,13 +72,73 @@ func (r *Reader) offsetToEnd() error {
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
 	}
-	r.Offset = info.Size()
+	if r.compression == compressionNone {
+		r.Offset = info.Size()
+		return nil
+	}
+
+	// The decompressed size is only known once the whole stream has been read
+	r.Offset = 0
+	if err = r.seekToOffset(); err != nil {
+		return err
+	}
+	n, err := io.Copy(io.Discard, r.source)
+	if err != nil {
+		return fmt.Errorf("decompress: %w", err)
+	}
+	r.Offset = n
+	if !r.decompressor.truncated {
+		r.ArchiveSize = info.Size()
+	}
+	return nil
+}
+
+// seekToOffset positions the source of the reader at the current offset.
+// Compressed streams cannot seek, so they are decompressed from the start
+// of the file and the bytes before the offset are discarded.
+func (r *Reader) seekToOffset() error {
+	if r.compression == compressionNone {
+		if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			return err
+		}
+		r.source = r.file
+		return nil
+	}
+
+	r.closeDecompressor()
+	if _, err := r.file.Seek(0, 0); err != nil {
+		return err
+	}
+	dr, err := newDecompressedReader(r.file, r.compression)
+	if err != nil {
+		return err
+	}
+	r.decompressor = dr
+	r.source = dr
+	if _, err := io.CopyN(io.Discard, dr, r.Offset); err != nil {
+		return fmt.Errorf("skip to offset: %w", err)
+	}
 	return nil
 }
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
+	var archiveSize int64
+	if r.compression != compressionNone {
+		info, err := r.file.Stat()
+		if err != nil {
+			r.Errorw("Failed to stat", zap.Error(err))
+			return
+		}
+		if info.Size() == r.ArchiveSize {
+			// The archive has not changed since it was last read in full
+			r.eof = true
+			return
+		}
+		archiveSize = info.Size()
+	}
+
+	if err := r.seekToOffset(); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
@@ -91,6 +160,13 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 				// If Scan returned an error then we are not guaranteed to be at the end of the file
 				r.eof = false
 				r.Errorw("Failed during scan", zap.Error(err))
+			} else if r.decompressor != nil {
+				if r.decompressor.truncated {
+					// The archive is still being written
+					r.eof = false
+				} else {
+					r.ArchiveSize = archiveSize
+				}
 			}
 			break
 		}
@@ -108,7 +184,7 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 			// We do not use the updated offset from the scanner,
 			// as the log line we just read could be multiline, and would be
 			// split differently with the new splitter.
-			if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			if err := r.seekToOffset(); err != nil {
 				r.Errorw("Failed to seek post-header", zap.Error(err))
 				return
 			}
@@ -167,6 +243,7 @@ func (r *Reader) consumeHeaderLine(ctx context.Context, attrs *FileAttributes, t
 
 // Close will close the file
 func (r *Reader) Close() {
+	r.closeDecompressor()
 	if r.file != nil {
 		if err := r.file.Close(); err != nil {
 			r.Debugw("Problem closing reader", zap.Error(err))
@@ -180,14 +257,23 @@ func (r *Reader) Close() {
 	}
 }
 
+func (r *Reader) closeDecompressor() {
+	if r.decompressor != nil {
+		if err := r.decompressor.Close(); err != nil {
+			r.Debugw("Problem closing decompressor", zap.Error(err))
+		}
+		r.decompressor = nil
+	}
+}
+
 // Read from the file and update the fingerprint if necessary
 func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.source.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.source.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
@@ -48,6 +48,7 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFile(newFile).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
+		withArchiveSize(old.ArchiveSize).
 		withSplitterFunc(old.lineSplitFunc).
 		withHeaderAttributes(mapCopy(old.FileAttributes.HeaderAttributes)).
 		withHeaderFinalized(old.HeaderFinalized).
@@ -59,6 +60,13 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	compression, err := detectCompression(file, f.readerConfig.compression)
+	if err != nil {
+		return nil, err
+	}
+	if compression != compressionNone {
+		return newDecompressedFingerprint(file, compression, f.readerConfig.fingerprintSize)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
@@ -67,6 +75,7 @@ type readerBuilder struct {
 	file             *os.File
 	fp               *Fingerprint
 	offset           int64
+	archiveSize      int64
 	splitFunc        bufio.SplitFunc
 	headerFinalized  bool
 	headerAttributes map[string]any
@@ -96,6 +105,11 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withArchiveSize(size int64) *readerBuilder {
+	b.archiveSize = size
+	return b
+}
+
 func (b *readerBuilder) withHeaderFinalized(finalized bool) *readerBuilder {
 	b.headerFinalized = finalized
 	return b
@@ -110,6 +124,7 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
 		readerConfig:    b.readerConfig,
 		Offset:          b.offset,
+		ArchiveSize:     b.archiveSize,
 		headerSettings:  b.headerSettings,
 		HeaderFinalized: b.headerFinalized,
 	}
@@ -138,12 +153,18 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 
 	if b.file != nil {
 		r.file = b.file
+		r.source = b.file
 		r.SugaredLogger = b.SugaredLogger.With("path", b.file.Name())
 		r.FileAttributes, err = resolveFileAttributes(b.file.Name())
 		if err != nil {
 			b.Errorf("resolve attributes: %w", err)
 		}
 
+		r.compression, err = detectCompression(b.file, b.readerConfig.compression)
+		if err != nil {
+			return nil, err
+		}
+
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
 			if err := r.offsetToEnd(); err != nil {
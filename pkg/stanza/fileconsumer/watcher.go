@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+const (
+	watchModePoll     = "poll"
+	watchModeFsnotify = "fsnotify"
+)
+
+// dirWatcher receives file system notifications for the directories that
+// contain matched files, so that files are only polled when they may have changed
+type dirWatcher struct {
+	*zap.SugaredLogger
+	finder  Finder
+	watcher *fsnotify.Watcher
+	dirs    map[string]struct{}
+}
+
+func newDirWatcher(logger *zap.SugaredLogger, finder Finder) (*dirWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	return &dirWatcher{
+		SugaredLogger: logger,
+		finder:        finder,
+		watcher:       watcher,
+		dirs:          make(map[string]struct{}),
+	}, nil
+}
+
+// update watches the base directories of the include patterns and the
+// directories of the matched files that are not watched yet
+func (w *dirWatcher) update(matches []string) {
+	for _, include := range w.finder.Include {
+		base, _ := doublestar.SplitPattern(filepath.ToSlash(include))
+		w.add(filepath.FromSlash(base))
+	}
+	for _, match := range matches {
+		w.add(filepath.Dir(match))
+	}
+}
+
+func (w *dirWatcher) add(dir string) {
+	if _, ok := w.dirs[dir]; ok {
+		return
+	}
+	if err := w.watcher.Add(dir); err != nil {
+		w.Debugw("Failed to watch directory", "dir", dir, zap.Error(err))
+		return
+	}
+	w.dirs[dir] = struct{}{}
+}
+
+// relevant returns true if the event may require the matched files to be polled
+func (w *dirWatcher) relevant(event fsnotify.Event) bool {
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		// The watch of a removed directory is dropped by the watcher itself
+		delete(w.dirs, event.Name)
+	}
+
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			// Files may have been created in the directory before it was watched
+			w.add(event.Name)
+			return true
+		}
+	}
+
+	if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) &&
+		!event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+	return w.finder.matches(event.Name)
+}
+
+func (w *dirWatcher) close() {
+	if err := w.watcher.Close(); err != nil {
+		w.Debugw("Problem closing watcher", zap.Error(err))
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestDirWatcherUpdate(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "a", "b"), 0700))
+
+	finder := Finder{Include: []string{filepath.Join(tempDir, "**", "*.log")}}
+	w, err := newDirWatcher(testutil.Logger(t), finder)
+	require.NoError(t, err)
+	defer w.close()
+
+	w.update([]string{filepath.Join(tempDir, "a", "b", "1.log")})
+	require.Equal(t, map[string]struct{}{
+		tempDir:                          {},
+		filepath.Join(tempDir, "a", "b"): {},
+	}, w.dirs)
+
+	// Directories that cannot be watched are retried on the next update
+	w.update([]string{filepath.Join(tempDir, "missing", "1.log")})
+	require.NotContains(t, w.dirs, filepath.Join(tempDir, "missing"))
+}
+
+func TestDirWatcherRelevant(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	finder := Finder{
+		Include: []string{filepath.Join(tempDir, "**", "*.log")},
+		Exclude: []string{filepath.Join(tempDir, "**", "exclude.log")},
+	}
+	w, err := newDirWatcher(testutil.Logger(t), finder)
+	require.NoError(t, err)
+	defer w.close()
+
+	subDir := filepath.Join(tempDir, "sub")
+	require.NoError(t, os.Mkdir(subDir, 0700))
+
+	cases := []struct {
+		name     string
+		event    fsnotify.Event
+		expected bool
+	}{
+		{"write", fsnotify.Event{Name: filepath.Join(tempDir, "a.log"), Op: fsnotify.Write}, true},
+		{"create", fsnotify.Event{Name: filepath.Join(tempDir, "a.log"), Op: fsnotify.Create}, true},
+		{"rename", fsnotify.Event{Name: filepath.Join(tempDir, "a.log"), Op: fsnotify.Rename}, true},
+		{"remove", fsnotify.Event{Name: filepath.Join(tempDir, "a.log"), Op: fsnotify.Remove}, true},
+		{"chmod", fsnotify.Event{Name: filepath.Join(tempDir, "a.log"), Op: fsnotify.Chmod}, false},
+		{"not_included", fsnotify.Event{Name: filepath.Join(tempDir, "a.txt"), Op: fsnotify.Write}, false},
+		{"excluded", fsnotify.Event{Name: filepath.Join(tempDir, "exclude.log"), Op: fsnotify.Write}, false},
+		{"new_directory", fsnotify.Event{Name: subDir, Op: fsnotify.Create}, true},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, w.relevant(tc.event), tc.name)
+	}
+	require.Contains(t, w.dirs, subDir)
+}
+
+func watchConfig(tempDir string) *Config {
+	cfg := NewConfig()
+	cfg.Include = []string{filepath.Join(tempDir, "**", "*.log")}
+	cfg.StartAt = "beginning"
+	cfg.WatchMode = watchModeFsnotify
+	cfg.PollInterval = 10 * time.Millisecond
+	// Make sure that logs can only be read as a result of events
+	cfg.WatchFallbackInterval = time.Hour
+	return cfg
+}
+
+func TestWatchNewLogs(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	operator, emitCalls := buildTestManager(t, watchConfig(tempDir))
+
+	temp := openFile(t, filepath.Join(tempDir, "a.log"))
+	writeString(t, temp, "testlog1\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	writeString(t, temp, "testlog2\n")
+	waitForToken(t, emitCalls, []byte("testlog2"))
+
+	// A file in a new directory
+	subDir := filepath.Join(tempDir, "sub")
+	require.NoError(t, os.Mkdir(subDir, 0700))
+	other := openFile(t, filepath.Join(subDir, "b.log"))
+	writeString(t, other, "testlog3\n")
+	waitForToken(t, emitCalls, []byte("testlog3"))
+
+	writeString(t, other, "testlog4\n")
+	waitForToken(t, emitCalls, []byte("testlog4"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestWatchRotation(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	operator, emitCalls := buildTestManager(t, watchConfig(tempDir))
+
+	path := filepath.Join(tempDir, "a.log")
+	temp := openFile(t, path)
+	writeString(t, temp, "testlog1\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	writeString(t, temp, "testlog2\n")
+	require.NoError(t, temp.Close())
+	require.NoError(t, os.Rename(path, filepath.Join(tempDir, "a.1.log")))
+
+	rotated := openFile(t, path)
+	writeString(t, rotated, "testlog3\n")
+
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog2"), []byte("testlog3")})
+}
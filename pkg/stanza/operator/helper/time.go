@@ -23,6 +23,7 @@ import (
 	strptime "github.com/observiq/ctimefmt"
 	"go.opentelemetry.io/collector/confmap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
 )
@@ -290,17 +291,7 @@ var subsecToNs = map[string]int64{"s.ms": 1e6, "s.us": 1e3, "s.ns": 1}
 // setTimestampYear sets the year of a timestamp to the current year.
 // This is needed because year is missing from some time formats, such as rfc3164.
 func setTimestampYear(t time.Time) time.Time {
-	if t.Year() > 0 {
-		return t
-	}
-	n := now()
-	d := time.Date(n.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
-	// If the timestamp would be more than 7 days in the future using this year,
-	// assume it's from last year.
-	if d.After(n.AddDate(0, 0, 7)) {
-		d = d.AddDate(-1, 0, 0)
-	}
-	return d
+	return parseutils.SetTimestampYear(t, now())
 }
 
 // Allows tests to override with deterministic value
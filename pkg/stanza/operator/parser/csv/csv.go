@@ -15,14 +15,13 @@ package csv // import "github.com/open-telemetry/opentelemetry-collector-contrib
 
 import (
 	"context"
-	csvparser "encoding/csv"
 	"errors"
 	"fmt"
-	"io"
 	"strings"
 
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
@@ -169,49 +168,9 @@ func generateCSVParseFunc(headers []string, fieldDelimiter rune, lazyQuotes bool
 			return nil, err
 		}
 
-		reader := csvparser.NewReader(strings.NewReader(csvLine))
-		reader.Comma = fieldDelimiter
-		reader.FieldsPerRecord = len(headers)
-		reader.LazyQuotes = lazyQuotes
-
-		// Typically only need one
-		lines := make([][]string, 0, 1)
-		for {
-			line, err := reader.Read()
-			if errors.Is(err, io.EOF) {
-				break
-			}
-
-			if err != nil && len(line) == 0 {
-				return nil, errors.New("failed to parse entry")
-			}
-
-			lines = append(lines, line)
-		}
-
-		/*
-			This parser is parsing a single value, which came from a single log entry.
-			Therefore, if there are multiple lines here, it should be assumed that each
-			subsequent line contains a continuation of the last field in the previous line.
-
-			Given a file w/ headers "A,B,C,D,E" and contents "aa,b\nb,cc,d\nd,ee",
-			expect reader.Read() to return bodies:
-			- ["aa","b"]
-			- ["b","cc","d"]
-			- ["d","ee"]
-		*/
-
-		joinedLine := lines[0]
-		for i := 1; i < len(lines); i++ {
-			nextLine := lines[i]
-
-			// The first element of the next line is a continuation of the previous line's last element
-			joinedLine[len(joinedLine)-1] += "\n" + nextLine[0]
-
-			// The remainder are separate elements
-			for n := 1; n < len(nextLine); n++ {
-				joinedLine = append(joinedLine, nextLine[n])
-			}
+		joinedLine, err := parseutils.ReadCSVRow(csvLine, fieldDelimiter, lazyQuotes, len(headers))
+		if err != nil {
+			return nil, errors.New("failed to parse entry")
 		}
 
 		return headersMap(headers, joinedLine)
@@ -20,9 +20,9 @@ import (
 	"fmt"
 	"strings"
 
-	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
@@ -72,7 +72,7 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 
 	// split on whitespace by default, if pair delimiter is set, use
 	// strings.Split()
-	pairSplitFunc := splitStringByWhitespace
+	pairSplitFunc := parseutils.SplitStringByWhitespace
 	if c.PairDelimiter != "" {
 		pairSplitFunc = func(input string) []string {
 			return strings.Split(input, c.PairDelimiter)
@@ -113,34 +113,11 @@ func (kv *Parser) parser(input string, delimiter string) (map[string]interface{}
 		return nil, fmt.Errorf("parse from field %s is empty", kv.ParseFrom.String())
 	}
 
-	parsed := make(map[string]interface{})
-
-	var err error
-	for _, raw := range kv.pairSplitFunc(input) {
-		m := strings.Split(raw, delimiter)
-		if len(m) != 2 {
-			e := fmt.Errorf("expected '%s' to split by '%s' into two items, got %d", raw, delimiter, len(m))
-			err = multierr.Append(err, e)
-			continue
-		}
-
-		key := strings.TrimSpace(strings.Trim(m[0], "\"'"))
-		value := strings.TrimSpace(strings.Trim(m[1], "\"'"))
+	pairs, err := parseutils.ParseKeyValuePairs(kv.pairSplitFunc(input), delimiter)
 
+	parsed := make(map[string]interface{}, len(pairs))
+	for key, value := range pairs {
 		parsed[key] = value
 	}
-
 	return parsed, err
 }
-
-// split on whitespace and preserve quoted text
-func splitStringByWhitespace(input string) []string {
-	quoted := false
-	raw := strings.FieldsFunc(input, func(r rune) bool {
-		if r == '"' || r == '\'' {
-			quoted = !quoted
-		}
-		return !quoted && r == ' '
-	})
-	return raw
-}
@@ -580,28 +580,3 @@ key=value`,
 		})
 	}
 }
-
-func TestSplitStringByWhitespace(t *testing.T) {
-	cases := []struct {
-		name   string
-		intput string
-		output []string
-	}{
-		{
-			"simple",
-			"k=v a=b x=\" y \" job=\"software engineering\"",
-			[]string{
-				"k=v",
-				"a=b",
-				"x=\" y \"",
-				"job=\"software engineering\"",
-			},
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			require.Equal(t, tc.output, splitStringByWhitespace(tc.intput))
-		})
-	}
-}
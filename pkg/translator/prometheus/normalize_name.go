@@ -82,6 +82,47 @@ var perUnitMap = map[string]string{
 	"y":  "year",
 }
 
+// The maps translating the Prometheus units back to the OpenTelemetry units
+// Example: bytes => By, per second => /s
+var promUnitMap = map[string]string{
+	"days":         "d",
+	"hours":        "h",
+	"minutes":      "min",
+	"seconds":      "s",
+	"milliseconds": "ms",
+	"microseconds": "us",
+	"nanoseconds":  "ns",
+	"bytes":        "By",
+	"kibibytes":    "KiBy",
+	"mebibytes":    "MiBy",
+	"gibibytes":    "GiBy",
+	"tibibytes":    "TiBy",
+	"kilobytes":    "KBy",
+	"megabytes":    "MBy",
+	"gigabytes":    "GBy",
+	"terabytes":    "TBy",
+	"meters":       "m",
+	"volts":        "V",
+	"amperes":      "A",
+	"joules":       "J",
+	"watts":        "W",
+	"grams":        "g",
+	"celsius":      "Cel",
+	"hertz":        "Hz",
+	"percent":      "%",
+	"dollars":      "$",
+}
+
+var promPerUnitMap = map[string]string{
+	"second": "s",
+	"minute": "m",
+	"hour":   "h",
+	"day":    "d",
+	"week":   "w",
+	"month":  "mo",
+	"year":   "y",
+}
+
 var normalizeNameGate = featuregate.GlobalRegistry().MustRegister(
 	"pkg.translator.prometheus.NormalizeName",
 	featuregate.StageBeta,
@@ -130,32 +171,17 @@ func normalizeName(metric pmetric.Metric, namespace string) string {
 		func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) },
 	)
 
-	// Split unit at the '/' if any
-	unitTokens := strings.SplitN(metric.Unit(), "/", 2)
-
 	// Main unit
 	// Append if not blank, doesn't contain '{}', and is not present in metric name already
-	if len(unitTokens) > 0 {
-		mainUnitOtel := strings.TrimSpace(unitTokens[0])
-		if mainUnitOtel != "" && !strings.ContainsAny(mainUnitOtel, "{}") {
-			mainUnitProm := CleanUpString(unitMapGetOrDefault(mainUnitOtel))
-			if mainUnitProm != "" && !contains(nameTokens, mainUnitProm) {
-				nameTokens = append(nameTokens, mainUnitProm)
-			}
-		}
-
-		// Per unit
-		// Append if not blank, doesn't contain '{}', and is not present in metric name already
-		if len(unitTokens) > 1 && unitTokens[1] != "" {
-			perUnitOtel := strings.TrimSpace(unitTokens[1])
-			if perUnitOtel != "" && !strings.ContainsAny(perUnitOtel, "{}") {
-				perUnitProm := CleanUpString(perUnitMapGetOrDefault(perUnitOtel))
-				if perUnitProm != "" && !contains(nameTokens, perUnitProm) {
-					nameTokens = append(append(nameTokens, "per"), perUnitProm)
-				}
-			}
-		}
+	mainUnitProm, perUnitProm := buildPromUnitTokens(metric.Unit())
+	if mainUnitProm != "" && !contains(nameTokens, mainUnitProm) {
+		nameTokens = append(nameTokens, mainUnitProm)
+	}
 
+	// Per unit
+	// Append if not blank, doesn't contain '{}', and is not present in metric name already
+	if perUnitProm != "" && !contains(nameTokens, perUnitProm) {
+		nameTokens = append(append(nameTokens, "per"), perUnitProm)
 	}
 
 	// Append _total for Counters
@@ -188,6 +214,47 @@ func normalizeName(metric pmetric.Metric, namespace string) string {
 	return normalizedName
 }
 
+// Build the Prometheus unit of the specified metric, as the unit suffix BuildPromCompliantName adds to its name,
+// e.g. `bytes_per_second` for `By/s`. The units containing '{}' are dropped. The unit is empty when the names are
+// not normalized, as BuildPromCompliantName adds no unit suffix then.
+func BuildPromUnit(metric pmetric.Metric) string {
+	if !normalizeNameGate.IsEnabled() {
+		return ""
+	}
+
+	if metric.Unit() == "1" && metric.Type() == pmetric.MetricTypeGauge {
+		return "ratio"
+	}
+
+	mainUnitProm, perUnitProm := buildPromUnitTokens(metric.Unit())
+	if perUnitProm == "" {
+		return mainUnitProm
+	}
+	if mainUnitProm == "" {
+		return "per_" + perUnitProm
+	}
+	return mainUnitProm + "_per_" + perUnitProm
+}
+
+// Split the OpenTelemetry unit at the '/' if any, and translate the main and per units to their Prometheus names
+func buildPromUnitTokens(unit string) (mainUnitProm string, perUnitProm string) {
+	unitTokens := strings.SplitN(unit, "/", 2)
+
+	mainUnitOtel := strings.TrimSpace(unitTokens[0])
+	if mainUnitOtel != "" && !strings.ContainsAny(mainUnitOtel, "{}") {
+		mainUnitProm = CleanUpString(unitMapGetOrDefault(mainUnitOtel))
+	}
+
+	if len(unitTokens) > 1 {
+		perUnitOtel := strings.TrimSpace(unitTokens[1])
+		if perUnitOtel != "" && !strings.ContainsAny(perUnitOtel, "{}") {
+			perUnitProm = CleanUpString(perUnitMapGetOrDefault(perUnitOtel))
+		}
+	}
+
+	return mainUnitProm, perUnitProm
+}
+
 type Normalizer struct {
 	gate *featuregate.Gate
 }
@@ -229,6 +296,69 @@ func (n *Normalizer) TrimPromSuffixes(promName string, metricType pmetric.Metric
 	return strings.Join(nameTokens, "_")
 }
 
+// PromUnitSuffix returns the Prometheus unit BuildPromCompliantName appended to a metric name, e.g. `bytes_per_second`
+// for `network_io_bytes_per_second`. Only the known units are recognized, an empty unit is returned otherwise.
+// The unit can be trimmed with TrimPromSuffixes and converted back to the OpenTelemetry unit with BuildOTelUnit.
+func (n *Normalizer) PromUnitSuffix(promName string, metricType pmetric.MetricType) string {
+	if !n.gate.IsEnabled() {
+		return ""
+	}
+
+	nameTokens := removeTypeSuffixes(strings.Split(promName, "_"), metricType)
+
+	if metricType == pmetric.MetricTypeGauge && len(nameTokens) > 1 && nameTokens[len(nameTokens)-1] == "ratio" {
+		return "ratio"
+	}
+
+	var unitTokens []string
+	if l := len(nameTokens); l > 2 && nameTokens[l-2] == "per" {
+		if _, ok := promPerUnitMap[nameTokens[l-1]]; ok {
+			unitTokens = nameTokens[l-2:]
+			nameTokens = nameTokens[:l-2]
+		}
+	}
+	if l := len(nameTokens); l > 1 {
+		if _, ok := promUnitMap[nameTokens[l-1]]; ok {
+			unitTokens = append([]string{nameTokens[l-1]}, unitTokens...)
+		}
+	}
+
+	return strings.Join(unitTokens, "_")
+}
+
+// BuildOTelUnit converts a Prometheus unit back to the OpenTelemetry unit BuildPromCompliantName converted it from,
+// e.g. `By/s` for `bytes_per_second`. The unknown units are kept as is.
+func BuildOTelUnit(promUnit string) string {
+	if promUnit == "" {
+		return ""
+	}
+	if promUnit == "ratio" {
+		return "1"
+	}
+
+	mainUnit, perUnit := promUnit, ""
+	if strings.HasPrefix(promUnit, "per_") {
+		mainUnit, perUnit = "", strings.TrimPrefix(promUnit, "per_")
+	} else if i := strings.Index(promUnit, "_per_"); i >= 0 {
+		mainUnit, perUnit = promUnit[:i], promUnit[i+len("_per_"):]
+	}
+
+	if otelUnit, ok := promUnitMap[mainUnit]; ok {
+		mainUnit = otelUnit
+	}
+	if perUnit == "" {
+		return mainUnit
+	}
+
+	if mainUnit == "" {
+		mainUnit = "1"
+	}
+	if otelPerUnit, ok := promPerUnitMap[perUnit]; ok {
+		perUnit = otelPerUnit
+	}
+	return mainUnit + "/" + perUnit
+}
+
 func removeTypeSuffixes(tokens []string, metricType pmetric.MetricType) []string {
 	switch metricType {
 	case pmetric.MetricTypeSum:
@@ -192,6 +192,63 @@ func TestTrimPromSuffixesWithFeatureGateDisabled(t *testing.T) {
 	assert.Equal(t, "apache_requests_total", normalizer.TrimPromSuffixes("apache_requests_total", pmetric.MetricTypeSum, "1"))
 }
 
+func TestPromUnitSuffix(t *testing.T) {
+	normalizer := NewNormalizer(featuregate.NewRegistry())
+
+	assert.Equal(t, "bytes", normalizer.PromUnitSuffix("active_directory_ds_replication_network_io_bytes_total", pmetric.MetricTypeSum))
+	assert.Equal(t, "milliseconds", normalizer.PromUnitSuffix("active_directory_ds_ldap_bind_last_successful_time_milliseconds", pmetric.MetricTypeGauge))
+	assert.Equal(t, "ratio", normalizer.PromUnitSuffix("system_cpu_utilization_ratio", pmetric.MetricTypeGauge))
+	assert.Equal(t, "bytes_per_second", normalizer.PromUnitSuffix("mongodbatlas_process_network_io_bytes_per_second", pmetric.MetricTypeGauge))
+	assert.Equal(t, "per_second", normalizer.PromUnitSuffix("mongodbatlas_process_asserts_per_second", pmetric.MetricTypeGauge))
+	assert.Equal(t, "seconds", normalizer.PromUnitSuffix("http_server_duration_seconds", pmetric.MetricTypeHistogram))
+	assert.Equal(t, "", normalizer.PromUnitSuffix("apache_requests_total", pmetric.MetricTypeSum))
+	assert.Equal(t, "", normalizer.PromUnitSuffix("nginx_connections_accepted", pmetric.MetricTypeGauge))
+	assert.Equal(t, "", normalizer.PromUnitSuffix("seconds", pmetric.MetricTypeGauge))
+	assert.Equal(t, "", normalizer.PromUnitSuffix("system_cpu_ratio_total", pmetric.MetricTypeSum))
+
+	registry := featuregate.NewRegistry()
+	_, err := registry.Register(normalizeNameGate.ID(), featuregate.StageAlpha)
+	require.NoError(t, err)
+	assert.Equal(t, "", NewNormalizer(registry).PromUnitSuffix("http_server_duration_seconds", pmetric.MetricTypeHistogram))
+}
+
+func TestBuildOTelUnit(t *testing.T) {
+	assert.Equal(t, "", BuildOTelUnit(""))
+	assert.Equal(t, "By", BuildOTelUnit("bytes"))
+	assert.Equal(t, "s", BuildOTelUnit("seconds"))
+	assert.Equal(t, "1", BuildOTelUnit("ratio"))
+	assert.Equal(t, "%", BuildOTelUnit("percent"))
+	assert.Equal(t, "By/s", BuildOTelUnit("bytes_per_second"))
+	assert.Equal(t, "GiBy/h", BuildOTelUnit("gibibytes_per_hour"))
+	assert.Equal(t, "1/s", BuildOTelUnit("per_second"))
+	assert.Equal(t, "connections", BuildOTelUnit("connections"))
+	assert.Equal(t, "packets/fortnight", BuildOTelUnit("packets_per_fortnight"))
+
+	// the units converted back are converted to the same Prometheus units
+	for _, unit := range []string{"bytes", "seconds", "celsius", "bytes_per_second", "meters_per_hour"} {
+		counter := createCounter("metric", BuildOTelUnit(unit))
+		assert.Equal(t, "metric_"+unit+"_total", normalizeName(counter, ""))
+	}
+}
+
+func TestBuildPromUnit(t *testing.T) {
+	assert.Equal(t, "", BuildPromUnit(createGauge("metric", "")))
+	assert.Equal(t, "bytes", BuildPromUnit(createCounter("metric", "By")))
+	assert.Equal(t, "ratio", BuildPromUnit(createGauge("metric", "1")))
+	assert.Equal(t, "", BuildPromUnit(createCounter("metric", "1")))
+	assert.Equal(t, "bytes_per_second", BuildPromUnit(createGauge("metric", "By/s")))
+	assert.Equal(t, "per_second", BuildPromUnit(createGauge("metric", "1/s")))
+	assert.Equal(t, "per_second", BuildPromUnit(createGauge("metric", "{packets}/s")))
+	assert.Equal(t, "", BuildPromUnit(createCounter("metric", "{packets}")))
+}
+
+func TestBuildPromUnitWithoutNormalization(t *testing.T) {
+	defer testutil.SetFeatureGateForTest(t, normalizeNameGate, false)()
+	assert.Equal(t, "", BuildPromUnit(createCounter("metric", "By")))
+	assert.Equal(t, "", BuildPromUnit(createGauge("metric", "1")))
+	assert.Equal(t, "", BuildPromUnit(createGauge("metric", "By/s")))
+}
+
 func TestNamespace(t *testing.T) {
 	require.Equal(t, "space_test", normalizeName(createGauge("test", ""), "space"))
 	require.Equal(t, "space_test", normalizeName(createGauge("#test", ""), "space"))
@@ -25,7 +25,12 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
-const defaultZeroThreshold = 1e-128
+const (
+	defaultZeroThreshold = 1e-128
+
+	// maxNativeHistogramSchema is the highest resolution supported by the Prometheus native histograms.
+	maxNativeHistogramSchema = 8
+)
 
 func addSingleExponentialHistogramDataPoint(
 	metric string,
@@ -69,15 +74,22 @@ func addSingleExponentialHistogramDataPoint(
 // to Prometheus Native Histogram.
 func exponentialToNativeHistogram(p pmetric.ExponentialHistogramDataPoint) (prompb.Histogram, error) {
 	scale := p.Scale()
-	if scale < -4 || scale > 8 {
+	if scale < -4 {
 		return prompb.Histogram{},
 			fmt.Errorf("cannot convert exponential to native histogram."+
-				" Scale must be <= 8 and >= -4, was %d", scale)
-		// TODO: downscale to 8 if scale > 8
+				" Scale must be >= -4, was %d", scale)
+	}
+
+	// The buckets of the scales higher than the highest native histogram schema are merged into the buckets of
+	// that schema.
+	var scaleDown int32
+	if scale > maxNativeHistogramSchema {
+		scaleDown = scale - maxNativeHistogramSchema
+		scale = maxNativeHistogramSchema
 	}
 
-	pSpans, pDeltas := convertBucketsLayout(p.Positive())
-	nSpans, nDeltas := convertBucketsLayout(p.Negative())
+	pSpans, pDeltas := convertBucketsLayout(p.Positive(), scaleDown)
+	nSpans, nDeltas := convertBucketsLayout(p.Negative(), scaleDown)
 
 	h := prompb.Histogram{
 		Schema: scale,
@@ -115,11 +127,14 @@ func exponentialToNativeHistogram(p pmetric.ExponentialHistogramDataPoint) (prom
 // The bucket indexes conversion was adjusted, since OTel exp. histogram bucket
 // index 0 corresponds to the range (1, base] while Prometheus bucket index 0
 // to the range (base 1].
-func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets) ([]prompb.BucketSpan, []int64) {
-	bucketCounts := buckets.BucketCounts()
-	if bucketCounts.Len() == 0 {
+//
+// The buckets are merged by groups of 2^scaleDown first, reducing the scale of the
+// histogram by scaleDown.
+func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]prompb.BucketSpan, []int64) {
+	if buckets.BucketCounts().Len() == 0 {
 		return nil, nil
 	}
+	bucketCounts, offset := downscaleBuckets(buckets, scaleDown)
 
 	var (
 		spans         []prompb.BucketSpan
@@ -134,14 +149,14 @@ func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets)
 		prevCount = count
 	}
 
-	for i := 0; i < bucketCounts.Len(); i++ {
-		count := int64(bucketCounts.At(i))
+	for i, bucketCount := range bucketCounts {
+		count := int64(bucketCount)
 		if count == 0 {
 			continue
 		}
 
 		// The offset is adjusted by 1 as described above.
-		bucketIdx := int32(i) + buckets.Offset() + 1
+		bucketIdx := int32(i) + offset + 1
 		delta := bucketIdx - nextBucketIdx
 		if i == 0 || delta > 2 {
 			// We have to create a new span, either because we are
@@ -165,3 +180,21 @@ func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets)
 
 	return spans, deltas
 }
+
+// downscaleBuckets returns the bucket counts and offset of the buckets at a scale reduced by scaleDown:
+// the bucket index i becomes the index i >> scaleDown.
+func downscaleBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]uint64, int32) {
+	bucketCounts := buckets.BucketCounts()
+	if scaleDown == 0 {
+		return bucketCounts.AsRaw(), buckets.Offset()
+	}
+
+	offset := buckets.Offset() >> scaleDown
+	lastIdx := (buckets.Offset() + int32(bucketCounts.Len()) - 1) >> scaleDown
+	counts := make([]uint64, lastIdx-offset+1)
+	for i := 0; i < bucketCounts.Len(); i++ {
+		idx := (buckets.Offset() + int32(i)) >> scaleDown
+		counts[idx-offset] += bucketCounts.At(i)
+	}
+	return counts, offset
+}
@@ -32,6 +32,7 @@ func TestConvertBucketsLayout(t *testing.T) {
 	tests := []struct {
 		name       string
 		buckets    func() pmetric.ExponentialHistogramDataPointBuckets
+		scaleDown  int32
 		wantSpans  []prompb.BucketSpan
 		wantDeltas []int64
 	}{
@@ -129,10 +130,48 @@ func TestConvertBucketsLayout(t *testing.T) {
 			wantSpans:  nil,
 			wantDeltas: nil,
 		},
+		{
+			name: "downscale by 1",
+			buckets: func() pmetric.ExponentialHistogramDataPointBuckets {
+				b := pmetric.NewExponentialHistogramDataPointBuckets()
+				b.SetOffset(-3)
+				b.BucketCounts().FromRaw([]uint64{1, 2, 3, 4, 5})
+				return b
+			},
+			scaleDown: 1,
+			wantSpans: []prompb.BucketSpan{
+				{
+					Offset: -1,
+					Length: 3,
+				},
+			},
+			wantDeltas: []int64{1, 4, 4},
+		},
+		{
+			name: "downscale by 2 with gaps",
+			buckets: func() pmetric.ExponentialHistogramDataPointBuckets {
+				b := pmetric.NewExponentialHistogramDataPointBuckets()
+				b.SetOffset(0)
+				b.BucketCounts().FromRaw([]uint64{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2})
+				return b
+			},
+			scaleDown: 2,
+			wantSpans: []prompb.BucketSpan{
+				{
+					Offset: 1,
+					Length: 1,
+				},
+				{
+					Offset: 3,
+					Length: 1,
+				},
+			},
+			wantDeltas: []int64{1, 1},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotSpans, gotDeltas := convertBucketsLayout(tt.buckets())
+			gotSpans, gotDeltas := convertBucketsLayout(tt.buckets(), tt.scaleDown)
 			assert.Equal(t, tt.wantSpans, gotSpans)
 			assert.Equal(t, tt.wantDeltas, gotDeltas)
 		})
@@ -213,6 +252,38 @@ func TestExponentialToNativeHistogram(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "convert exp. to native histogram with downscaling",
+			exponentialHist: func() pmetric.ExponentialHistogramDataPoint {
+				pt := pmetric.NewExponentialHistogramDataPoint()
+				pt.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(500)))
+				pt.SetCount(6)
+				pt.SetSum(10.1)
+				pt.SetScale(10)
+
+				pt.Positive().BucketCounts().FromRaw([]uint64{1, 1, 1, 1})
+				pt.Positive().SetOffset(0)
+
+				pt.Negative().BucketCounts().FromRaw([]uint64{2})
+				pt.Negative().SetOffset(-1)
+
+				return pt
+			},
+			wantNativeHist: func() prompb.Histogram {
+				return prompb.Histogram{
+					Count:          &prompb.Histogram_CountInt{CountInt: 6},
+					Sum:            10.1,
+					Schema:         8,
+					ZeroThreshold:  defaultZeroThreshold,
+					ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 0},
+					NegativeSpans:  []prompb.BucketSpan{{Offset: 0, Length: 1}},
+					NegativeDeltas: []int64{2},
+					PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+					PositiveDeltas: []int64{4},
+					Timestamp:      500,
+				}
+			},
+		},
 		{
 			name: "invalid scale",
 			exponentialHist: func() pmetric.ExponentialHistogramDataPoint {
@@ -221,7 +292,7 @@ func TestExponentialToNativeHistogram(t *testing.T) {
 				return pt
 			},
 			wantErrMessage: "cannot convert exponential to native histogram." +
-				" Scale must be <= 8 and >= -4, was -10",
+				" Scale must be >= -4, was -10",
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// otelMetricTypeToPromMetricType returns the Prometheus metric type of the series FromMetrics converts the metric to.
+func otelMetricTypeToPromMetricType(metric pmetric.Metric) prompb.MetricMetadata_MetricType {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return prompb.MetricMetadata_GAUGE
+	case pmetric.MetricTypeSum:
+		if metric.Sum().IsMonotonic() {
+			return prompb.MetricMetadata_COUNTER
+		}
+		return prompb.MetricMetadata_GAUGE
+	case pmetric.MetricTypeHistogram, pmetric.MetricTypeExponentialHistogram:
+		return prompb.MetricMetadata_HISTOGRAM
+	case pmetric.MetricTypeSummary:
+		return prompb.MetricMetadata_SUMMARY
+	}
+	return prompb.MetricMetadata_UNKNOWN
+}
+
+// OtelMetricsToMetadata returns the metadata (type, help and unit) of the metric families FromMetrics converts the
+// metrics to, once per family name. The metrics FromMetrics drops are skipped.
+func OtelMetricsToMetadata(md pmetric.Metrics, settings Settings) []*prompb.MetricMetadata {
+	var metadata []*prompb.MetricMetadata
+	seen := make(map[string]bool)
+
+	resourceMetricsSlice := md.ResourceMetrics()
+	for i := 0; i < resourceMetricsSlice.Len(); i++ {
+		scopeMetricsSlice := resourceMetricsSlice.At(i).ScopeMetrics()
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			metricSlice := scopeMetricsSlice.At(j).Metrics()
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+				if !isValidAggregationTemporality(metric) {
+					continue
+				}
+
+				name := prometheustranslator.BuildPromCompliantName(metric, settings.Namespace)
+				if seen[name] {
+					continue
+				}
+				seen[name] = true
+
+				metadata = append(metadata, &prompb.MetricMetadata{
+					Type:             otelMetricTypeToPromMetricType(metric),
+					MetricFamilyName: name,
+					Help:             metric.Description(),
+					Unit:             prometheustranslator.BuildPromUnit(metric),
+				})
+			}
+		}
+	}
+	return metadata
+}
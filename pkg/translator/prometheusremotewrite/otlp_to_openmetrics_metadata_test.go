@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestOtelMetricsToMetadata(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for i := 0; i < 2; i++ {
+		metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+		counter := metrics.AppendEmpty()
+		counter.SetName("http.server.requests")
+		counter.SetDescription("The number of requests.")
+		sum := counter.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		gauge := metrics.AppendEmpty()
+		gauge.SetName("memory.usage")
+		gauge.SetUnit("By")
+		gauge.SetEmptyGauge()
+
+		upDownCounter := metrics.AppendEmpty()
+		upDownCounter.SetName("active.requests")
+		upDownCounter.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		histogram := metrics.AppendEmpty()
+		histogram.SetName("http.server.duration")
+		histogram.SetUnit("s")
+		histogram.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		summary := metrics.AppendEmpty()
+		summary.SetName("rpc.duration")
+		summary.SetEmptySummary()
+
+		delta := metrics.AppendEmpty()
+		delta.SetName("delta.requests")
+		delta.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	}
+
+	assert.Equal(t, []*prompb.MetricMetadata{
+		{
+			Type:             prompb.MetricMetadata_COUNTER,
+			MetricFamilyName: "ns_http_server_requests_total",
+			Help:             "The number of requests.",
+		},
+		{
+			Type:             prompb.MetricMetadata_GAUGE,
+			MetricFamilyName: "ns_memory_usage_bytes",
+			Unit:             "bytes",
+		},
+		{
+			Type:             prompb.MetricMetadata_GAUGE,
+			MetricFamilyName: "ns_active_requests",
+		},
+		{
+			Type:             prompb.MetricMetadata_HISTOGRAM,
+			MetricFamilyName: "ns_http_server_duration_seconds",
+			Unit:             "seconds",
+		},
+		{
+			Type:             prompb.MetricMetadata_SUMMARY,
+			MetricFamilyName: "ns_rpc_duration",
+		},
+	}, OtelMetricsToMetadata(md, Settings{Namespace: "ns"}))
+}
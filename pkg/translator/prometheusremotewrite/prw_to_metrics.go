@@ -0,0 +1,659 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// seriesRole is the part of a metric a time series holds
+type seriesRole int
+
+const (
+	roleValue seriesRole = iota
+	roleBucket
+	roleQuantile
+	roleSum
+	roleCount
+	roleCreated
+	roleNative
+)
+
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+// familyKey identifies a metric family of a resource, the native histograms are kept apart from the classic series
+// of the same name, since Prometheus sends both when scraping the classic histograms along with the native ones.
+type familyKey struct {
+	name   string
+	native bool
+}
+
+type promResource struct {
+	key      resourceKey
+	info     []prompb.Label
+	families map[familyKey]*promFamily
+	order    []familyKey
+}
+
+type promFamily struct {
+	name      string
+	mtype     pmetric.MetricType
+	monotonic bool
+	points    map[string]*promPoint
+	order     []string
+	created   map[string]int64
+}
+
+type promPoint struct {
+	labels    []prompb.Label
+	timestamp int64
+	stale     bool
+
+	value     float64
+	sum       float64
+	hasSum    bool
+	count     float64
+	hasCount  bool
+	buckets   map[float64]float64
+	quantiles map[float64]float64
+	native    *prompb.Histogram
+	exemplars []prompb.Exemplar
+}
+
+// ToMetrics converts a Prometheus remote write request to pmetric.Metrics, reversing the conventions of FromMetrics:
+//   - the `job` and `instance` labels are turned back into the `service.namespace`, `service.name` and
+//     `service.instance.id` resource attributes, and the labels of the `target_info` series into the other
+//     resource attributes;
+//   - the `_bucket`, `_sum` and `_count` series of a histogram, and the quantile, `_sum` and `_count` series of a
+//     summary, are reassembled into a single metric, and the `_created` series set the start timestamps;
+//   - the `_total` and unit suffixes are trimmed from the metric names, and the units are set back;
+//   - the native histograms are converted to exponential histograms.
+//
+// The metric types, units and descriptions are taken from the metadata of the request when it is sent, the types
+// are guessed from the series names and labels otherwise.
+//
+// ToMetrics only relies on the request it converts: the series of a histogram or summary sent in different
+// requests are converted to several partial points, and the metadata sent in other requests is not used. Prometheus
+// spreads the series and the metadata over several requests, the Translator keeps the state needed to reassemble them.
+func ToMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	md, _, err := convert(req, nil, time.Time{})
+	return md, err
+}
+
+// knownFamilies are the metric names, histogram and summary families, and metadata the series are assigned with.
+type knownFamilies struct {
+	names      map[string]bool
+	histograms map[string]bool
+	summaries  map[string]bool
+	metadata   map[string]prompb.MetricMetadata
+}
+
+func requestFamilies(req *prompb.WriteRequest) knownFamilies {
+	known := knownFamilies{
+		names:      make(map[string]bool),
+		histograms: make(map[string]bool),
+		summaries:  make(map[string]bool),
+		metadata:   make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+	}
+
+	for _, md := range req.Metadata {
+		known.metadata[md.MetricFamilyName] = md
+		switch md.Type {
+		case prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_GAUGEHISTOGRAM:
+			known.histograms[md.MetricFamilyName] = true
+		case prompb.MetricMetadata_SUMMARY:
+			known.summaries[md.MetricFamilyName] = true
+		}
+	}
+	for i := range req.Timeseries {
+		name := labelValue(req.Timeseries[i].Labels, model.MetricNameLabel)
+		known.names[name] = true
+		if base := strings.TrimSuffix(name, bucketStr); base != name && hasLabel(req.Timeseries[i].Labels, leStr) {
+			known.histograms[base] = true
+		}
+		if hasLabel(req.Timeseries[i].Labels, quantileStr) {
+			known.summaries[name] = true
+		}
+	}
+	return known
+}
+
+// resourceSet are the resources of the converted metrics, in the order of their first series
+type resourceSet struct {
+	resources map[resourceKey]*promResource
+	order     []resourceKey
+}
+
+func newResourceSet() *resourceSet {
+	return &resourceSet{resources: make(map[resourceKey]*promResource)}
+}
+
+func (s *resourceSet) get(key resourceKey) *promResource {
+	res, ok := s.resources[key]
+	if !ok {
+		res = &promResource{key: key, families: make(map[familyKey]*promFamily)}
+		s.resources[key] = res
+		s.order = append(s.order, key)
+	}
+	return res
+}
+
+// convert converts the request, with the state kept across the requests if not nil, and returns the held points
+// released to the metrics.
+func convert(req *prompb.WriteRequest, state *translatorState, now time.Time) (pmetric.Metrics, *Released, error) {
+	// the histograms and summaries are identified before assigning their series
+	known := requestFamilies(req)
+	if state != nil {
+		known = state.learn(known, now)
+	}
+
+	var errs error
+	resources := newResourceSet()
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		name := labelValue(ts.Labels, model.MetricNameLabel)
+		if name == "" {
+			errs = multierr.Append(errs, errors.New("time series without a metric name is dropped"))
+			continue
+		}
+
+		res := resources.get(resourceKey{
+			job:      labelValue(ts.Labels, model.JobLabel),
+			instance: labelValue(ts.Labels, model.InstanceLabel),
+		})
+		if name == targetMetricName || strings.HasSuffix(name, "_"+targetMetricName) {
+			res.info = pointLabels(ts.Labels)
+			continue
+		}
+
+		familyName, role := seriesFamily(name, ts, known)
+		key := familyKey{name: familyName, native: role == roleNative}
+		family, ok := res.families[key]
+		if !ok {
+			family = newPromFamily(familyName, role, known)
+			res.families[key] = family
+			res.order = append(res.order, key)
+		}
+		if err := family.addSeries(ts, role); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("time series of %q is dropped: %w", name, err))
+		}
+	}
+
+	var released *Released
+	if state != nil {
+		released = state.reassemble(resources, now)
+	}
+
+	return resources.toMetrics(known.metadata), released, errs
+}
+
+func (s *resourceSet) toMetrics(metadata map[string]prompb.MetricMetadata) pmetric.Metrics {
+	normalizer := prometheustranslator.NewNormalizer(featuregate.GlobalRegistry())
+
+	md := pmetric.NewMetrics()
+	for _, key := range s.order {
+		res := s.resources[key]
+		if !res.hasPoints() {
+			continue
+		}
+
+		rm := md.ResourceMetrics().AppendEmpty()
+		res.setAttributes(rm.Resource().Attributes())
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+		for _, key := range res.order {
+			family := res.families[key]
+			if len(family.order) == 0 {
+				continue
+			}
+			family.toMetric(metrics.AppendEmpty(), metadata[key.name], normalizer)
+		}
+	}
+	return md
+}
+
+func (r *promResource) hasPoints() bool {
+	for _, family := range r.families {
+		if len(family.order) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// seriesFamily returns the name of the metric family the time series belongs to, and the part of it the series holds.
+func seriesFamily(name string, ts *prompb.TimeSeries, known knownFamilies) (string, seriesRole) {
+	names, histograms, summaries := known.names, known.histograms, known.summaries
+	if len(ts.Histograms) > 0 {
+		return name, roleNative
+	}
+	if base := strings.TrimSuffix(name, bucketStr); base != name && histograms[base] && hasLabel(ts.Labels, leStr) {
+		return base, roleBucket
+	}
+	if base := strings.TrimSuffix(name, sumStr); base != name && (histograms[base] || summaries[base]) {
+		return base, roleSum
+	}
+	if base := strings.TrimSuffix(name, countStr); base != name && (histograms[base] || summaries[base]) {
+		return base, roleCount
+	}
+	if base := strings.TrimSuffix(name, createdSuffix); base != name && (names[base] || histograms[base] || summaries[base]) {
+		return base, roleCreated
+	}
+	if summaries[name] && hasLabel(ts.Labels, quantileStr) {
+		return name, roleQuantile
+	}
+	return name, roleValue
+}
+
+func newPromFamily(name string, role seriesRole, known knownFamilies) *promFamily {
+	family := &promFamily{
+		name:    name,
+		points:  make(map[string]*promPoint),
+		created: make(map[string]int64),
+	}
+
+	md, hasMetadata := known.metadata[name]
+	switch {
+	case role == roleNative:
+		family.mtype = pmetric.MetricTypeExponentialHistogram
+	case known.histograms[name]:
+		family.mtype = pmetric.MetricTypeHistogram
+	case known.summaries[name]:
+		family.mtype = pmetric.MetricTypeSummary
+	case hasMetadata && md.Type == prompb.MetricMetadata_COUNTER,
+		!hasMetadata && strings.HasSuffix(name, "_total"):
+		family.mtype = pmetric.MetricTypeSum
+		family.monotonic = true
+	default:
+		family.mtype = pmetric.MetricTypeGauge
+	}
+	return family
+}
+
+func (f *promFamily) point(labels []prompb.Label, timestamp int64) *promPoint {
+	key := labelsSignature(labels) + "@" + strconv.FormatInt(timestamp, 10)
+	p, ok := f.points[key]
+	if !ok {
+		p = &promPoint{labels: labels, timestamp: timestamp}
+		f.points[key] = p
+		f.order = append(f.order, key)
+	}
+	return p
+}
+
+func (f *promFamily) addSeries(ts *prompb.TimeSeries, role seriesRole) error {
+	labels := pointLabels(ts.Labels)
+
+	var last *promPoint
+	switch role {
+	case roleCreated:
+		for _, sample := range ts.Samples {
+			f.created[labelsSignature(labels)] = int64(sample.Value)
+		}
+		return nil
+	case roleNative:
+		for i := range ts.Histograms {
+			last = f.point(labels, ts.Histograms[i].Timestamp)
+			last.native = &ts.Histograms[i]
+		}
+	case roleBucket:
+		bound, err := strconv.ParseFloat(labelValue(ts.Labels, leStr), 64)
+		if err != nil {
+			return fmt.Errorf("invalid bucket bound: %w", err)
+		}
+		for _, sample := range ts.Samples {
+			last = f.point(labels, sample.Timestamp)
+			last.stale = last.stale || value.IsStaleNaN(sample.Value)
+			if last.buckets == nil {
+				last.buckets = make(map[float64]float64)
+			}
+			last.buckets[bound] = sample.Value
+		}
+	case roleQuantile:
+		quantile, err := strconv.ParseFloat(labelValue(ts.Labels, quantileStr), 64)
+		if err != nil {
+			return fmt.Errorf("invalid quantile: %w", err)
+		}
+		for _, sample := range ts.Samples {
+			last = f.point(labels, sample.Timestamp)
+			last.stale = last.stale || value.IsStaleNaN(sample.Value)
+			if last.quantiles == nil {
+				last.quantiles = make(map[float64]float64)
+			}
+			last.quantiles[quantile] = sample.Value
+		}
+	default:
+		for _, sample := range ts.Samples {
+			last = f.point(labels, sample.Timestamp)
+			last.stale = last.stale || value.IsStaleNaN(sample.Value)
+			switch role {
+			case roleSum:
+				last.sum, last.hasSum = sample.Value, true
+			case roleCount:
+				last.count, last.hasCount = sample.Value, true
+			default:
+				last.value = sample.Value
+			}
+		}
+	}
+
+	if last != nil {
+		last.exemplars = append(last.exemplars, ts.Exemplars...)
+	}
+	return nil
+}
+
+func (f *promFamily) toMetric(metric pmetric.Metric, md prompb.MetricMetadata, normalizer *prometheustranslator.Normalizer) {
+	unit := md.Unit
+	if unit == "" {
+		unit = normalizer.PromUnitSuffix(f.name, f.mtype)
+	}
+	metric.SetName(normalizer.TrimPromSuffixes(f.name, f.mtype, unit))
+	metric.SetUnit(prometheustranslator.BuildOTelUnit(unit))
+	metric.SetDescription(md.Help)
+
+	switch f.mtype {
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(f.monotonic)
+		for _, key := range f.order {
+			p := f.points[key]
+			dp := sum.DataPoints().AppendEmpty()
+			f.setPointCommon(p, dp.Attributes(), dp.SetStartTimestamp, dp.SetTimestamp, dp.SetFlags)
+			dp.SetDoubleValue(p.value)
+			if p.stale {
+				dp.SetDoubleValue(0)
+			}
+			setExemplars(p.exemplars, dp.Exemplars())
+		}
+	case pmetric.MetricTypeGauge:
+		gauge := metric.SetEmptyGauge()
+		for _, key := range f.order {
+			p := f.points[key]
+			dp := gauge.DataPoints().AppendEmpty()
+			f.setPointCommon(p, dp.Attributes(), nil, dp.SetTimestamp, dp.SetFlags)
+			dp.SetDoubleValue(p.value)
+			if p.stale {
+				dp.SetDoubleValue(0)
+			}
+			setExemplars(p.exemplars, dp.Exemplars())
+		}
+	case pmetric.MetricTypeHistogram:
+		histogram := metric.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, key := range f.order {
+			p := f.points[key]
+			dp := histogram.DataPoints().AppendEmpty()
+			f.setPointCommon(p, dp.Attributes(), dp.SetStartTimestamp, dp.SetTimestamp, dp.SetFlags)
+			if !p.stale {
+				setHistogramBuckets(p, dp)
+			}
+			setExemplars(p.exemplars, dp.Exemplars())
+		}
+	case pmetric.MetricTypeSummary:
+		summary := metric.SetEmptySummary()
+		for _, key := range f.order {
+			p := f.points[key]
+			dp := summary.DataPoints().AppendEmpty()
+			f.setPointCommon(p, dp.Attributes(), dp.SetStartTimestamp, dp.SetTimestamp, dp.SetFlags)
+			if p.stale {
+				continue
+			}
+			dp.SetSum(p.sum)
+			dp.SetCount(uint64(p.count))
+			quantiles := make([]float64, 0, len(p.quantiles))
+			for quantile := range p.quantiles {
+				quantiles = append(quantiles, quantile)
+			}
+			sort.Float64s(quantiles)
+			for _, quantile := range quantiles {
+				qv := dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(quantile)
+				qv.SetValue(p.quantiles[quantile])
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := metric.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, key := range f.order {
+			p := f.points[key]
+			if p.native == nil {
+				continue
+			}
+			dp := histogram.DataPoints().AppendEmpty()
+			f.setPointCommon(p, dp.Attributes(), dp.SetStartTimestamp, dp.SetTimestamp, dp.SetFlags)
+			nativeToExponentialHistogram(p.native, dp)
+			setExemplars(p.exemplars, dp.Exemplars())
+		}
+	}
+}
+
+func (f *promFamily) setPointCommon(p *promPoint, attributes pcommon.Map, setStart, setTimestamp func(pcommon.Timestamp), setFlags func(pmetric.DataPointFlags)) {
+	for _, label := range p.labels {
+		attributes.PutStr(label.Name, label.Value)
+	}
+	setTimestamp(fromMillis(p.timestamp))
+	if created, ok := f.created[labelsSignature(p.labels)]; ok && setStart != nil {
+		setStart(fromMillis(created))
+	}
+	if p.stale {
+		setFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	}
+}
+
+// setHistogramBuckets converts the cumulative `le` buckets back to the explicit bounds and bucket counts.
+func setHistogramBuckets(p *promPoint, dp pmetric.HistogramDataPoint) {
+	bounds := make([]float64, 0, len(p.buckets))
+	for bound := range p.buckets {
+		if !math.IsInf(bound, 1) {
+			bounds = append(bounds, bound)
+		}
+	}
+	sort.Float64s(bounds)
+
+	count := p.count
+	if inf, ok := p.buckets[math.Inf(1)]; ok && !p.hasCount {
+		count = inf
+	}
+
+	var cumulative float64
+	counts := make([]uint64, 0, len(bounds)+1)
+	for _, bound := range bounds {
+		counts = append(counts, uint64(math.Max(p.buckets[bound]-cumulative, 0)))
+		cumulative = math.Max(p.buckets[bound], cumulative)
+	}
+	counts = append(counts, uint64(math.Max(count-cumulative, 0)))
+
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	dp.SetCount(uint64(count))
+	if p.hasSum {
+		dp.SetSum(p.sum)
+	}
+}
+
+// nativeToExponentialHistogram translates a Prometheus native histogram to the OTel exponential histogram data point,
+// reversing exponentialToNativeHistogram.
+func nativeToExponentialHistogram(h *prompb.Histogram, dp pmetric.ExponentialHistogramDataPoint) {
+	if value.IsStaleNaN(h.Sum) {
+		return
+	}
+
+	dp.SetScale(h.Schema)
+	if _, ok := h.Count.(*prompb.Histogram_CountFloat); ok {
+		dp.SetCount(uint64(math.Round(h.GetCountFloat())))
+		dp.SetZeroCount(uint64(math.Round(h.GetZeroCountFloat())))
+	} else {
+		dp.SetCount(h.GetCountInt())
+		dp.SetZeroCount(h.GetZeroCountInt())
+	}
+	dp.SetSum(h.Sum)
+
+	spansToBuckets(h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts, dp.Positive())
+	spansToBuckets(h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts, dp.Negative())
+}
+
+// spansToBuckets translates the Prometheus sparse buckets to the OTel dense buckets, the counts are either deltas
+// for integer histograms or absolute counts for float histograms.
+func spansToBuckets(spans []prompb.BucketSpan, deltas []int64, counts []float64, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+
+	var (
+		dense    []uint64
+		bucket   int
+		count    int64
+		firstIdx = spans[0].Offset
+	)
+	for i, span := range spans {
+		if i > 0 {
+			// the empty buckets between the spans
+			for j := int32(0); j < span.Offset; j++ {
+				dense = append(dense, 0)
+			}
+		}
+		for j := uint32(0); j < span.Length; j++ {
+			switch {
+			case bucket < len(deltas):
+				count += deltas[bucket]
+				dense = append(dense, uint64(count))
+			case bucket < len(counts):
+				dense = append(dense, uint64(math.Round(counts[bucket])))
+			default:
+				dense = append(dense, 0)
+			}
+			bucket++
+		}
+	}
+
+	// Prometheus bucket index 0 is the range (base^-1, 1] while OTel bucket index 0 is the range (1, base]
+	buckets.SetOffset(firstIdx - 1)
+	buckets.BucketCounts().FromRaw(dense)
+}
+
+func setExemplars(exemplars []prompb.Exemplar, dest pmetric.ExemplarSlice) {
+	for _, exemplar := range exemplars {
+		e := dest.AppendEmpty()
+		e.SetDoubleValue(exemplar.Value)
+		e.SetTimestamp(fromMillis(exemplar.Timestamp))
+		for _, label := range exemplar.Labels {
+			switch label.Name {
+			case traceIDKey:
+				var traceID pcommon.TraceID
+				if b, err := hex.DecodeString(label.Value); err == nil && len(b) == len(traceID) {
+					copy(traceID[:], b)
+					e.SetTraceID(traceID)
+					continue
+				}
+			case spanIDKey:
+				var spanID pcommon.SpanID
+				if b, err := hex.DecodeString(label.Value); err == nil && len(b) == len(spanID) {
+					copy(spanID[:], b)
+					e.SetSpanID(spanID)
+					continue
+				}
+			}
+			e.FilteredAttributes().PutStr(label.Name, label.Value)
+		}
+	}
+}
+
+// setAttributes sets the resource attributes from the `job` and `instance` labels, and the `target_info` labels.
+func (r *promResource) setAttributes(attributes pcommon.Map) {
+	if r.key.job != "" {
+		if namespace, name, ok := strings.Cut(r.key.job, "/"); ok {
+			attributes.PutStr(conventions.AttributeServiceNamespace, namespace)
+			attributes.PutStr(conventions.AttributeServiceName, name)
+		} else {
+			attributes.PutStr(conventions.AttributeServiceName, r.key.job)
+		}
+	}
+	if r.key.instance != "" {
+		attributes.PutStr(conventions.AttributeServiceInstanceID, r.key.instance)
+	}
+	for _, label := range r.info {
+		attributes.PutStr(label.Name, label.Value)
+	}
+}
+
+// pointLabels returns the labels of the time series which are the attributes of the data points, sorted by name.
+func pointLabels(labels []prompb.Label) []prompb.Label {
+	attrs := make([]prompb.Label, 0, len(labels))
+	for _, label := range labels {
+		switch label.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel, leStr, quantileStr:
+		default:
+			attrs = append(attrs, label)
+		}
+	}
+	sort.Sort(ByLabelName(attrs))
+	return attrs
+}
+
+func labelsSignature(labels []prompb.Label) string {
+	b := strings.Builder{}
+	for _, label := range labels {
+		b.WriteString(label.Name)
+		b.WriteString("=")
+		b.WriteString(label.Value)
+		b.WriteString(",")
+	}
+	return b.String()
+}
+
+func labelValue(labels []prompb.Label, name string) string {
+	for _, label := range labels {
+		if label.Name == name {
+			return label.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, label := range labels {
+		if label.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fromMillis converts a Prometheus timestamp in ms to an OTLP timestamp in ns
+func fromMillis(ms int64) pcommon.Timestamp {
+	return pcommon.Timestamp(ms * 1e6)
+}
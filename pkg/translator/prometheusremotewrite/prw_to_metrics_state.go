@@ -0,0 +1,431 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Translator converts the Prometheus remote write requests to pmetric.Metrics like ToMetrics, and keeps the state
+// needed to reassemble the metrics Prometheus spreads over several requests:
+//   - the metadata, the histogram and summary families, the `target_info` labels and the `_created` samples
+//     received in a request are used to convert the following ones;
+//   - the histogram and summary points are held until all of their series are received, which is when they have
+//     as many buckets or quantiles as the previous point of the series, and a `_sum` and a `_count`. The parts
+//     received after a point has been converted are dropped.
+//
+// The state not refreshed for the staleness duration is dropped, and the points held for longer are converted as
+// they are, by the following requests or ReleaseStale.
+//
+// The points released are forgotten by the Translator: the metrics they are converted to must be consumed, or the
+// points given back to Restore.
+type Translator struct {
+	now func() time.Time
+
+	mu    sync.Mutex
+	state *translatorState
+}
+
+// NewTranslator returns a Translator keeping its state for the staleness duration.
+func NewTranslator(staleness time.Duration) *Translator {
+	return &Translator{
+		now: time.Now,
+		state: &translatorState{
+			staleness: staleness,
+			known: knownFamilies{
+				names:      make(map[string]bool),
+				histograms: make(map[string]bool),
+				summaries:  make(map[string]bool),
+				metadata:   make(map[string]prompb.MetricMetadata),
+			},
+			lastSeen: make(map[string]time.Time),
+			info:     make(map[resourceKey]cached[[]prompb.Label]),
+			created:  make(map[seriesKey]cached[int64]),
+			layouts:  make(map[seriesKey]cached[pointLayout]),
+			pending:  make(map[pointKey]*pendingPoint),
+		},
+	}
+}
+
+// ToMetrics converts a Prometheus remote write request to pmetric.Metrics, see the ToMetrics function, and returns
+// the held points released to the metrics.
+func (t *Translator) ToMetrics(req *prompb.WriteRequest) (pmetric.Metrics, *Released, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return convert(req, t.state, t.now())
+}
+
+// ReleaseStale converts the points held for longer than the staleness duration, which would otherwise wait for the
+// following request.
+func (t *Translator) ReleaseStale() (pmetric.Metrics, *Released) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state.flush(t.now(), false)
+}
+
+// ReleaseAll converts all the held points, complete or not, when no more requests are going to be converted.
+func (t *Translator) ReleaseAll() (pmetric.Metrics, *Released) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state.flush(t.now(), true)
+}
+
+// Restore holds again the released points whose metrics could not be consumed, for them to be released again with
+// the parts received in the meantime.
+func (t *Translator) Restore(released *Released) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state.restore(released)
+}
+
+// Released are the held points released to converted metrics.
+type Released struct {
+	points []releasedPoint
+}
+
+// Len returns the number of points released.
+func (r *Released) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.points)
+}
+
+// releasedPoint is a released point, and the layout of its series before it was released
+type releasedPoint struct {
+	key       pointKey
+	pending   *pendingPoint
+	layout    cached[pointLayout]
+	hasLayout bool
+}
+
+type cached[T any] struct {
+	value    T
+	lastSeen time.Time
+}
+
+// seriesKey identifies the series of a metric family with the same labels
+type seriesKey struct {
+	resource  resourceKey
+	family    string
+	signature string
+}
+
+type pointKey struct {
+	seriesKey
+	timestamp int64
+}
+
+type pendingPoint struct {
+	mtype    pmetric.MetricType
+	point    *promPoint
+	received time.Time
+}
+
+// pointLayout are the parts of the last point converted for a histogram or summary series
+type pointLayout struct {
+	buckets       int
+	quantiles     int
+	hasSum        bool
+	hasCount      bool
+	lastTimestamp int64
+}
+
+type translatorState struct {
+	staleness time.Duration
+
+	known    knownFamilies
+	lastSeen map[string]time.Time
+	info     map[resourceKey]cached[[]prompb.Label]
+	created  map[seriesKey]cached[int64]
+	layouts  map[seriesKey]cached[pointLayout]
+	pending  map[pointKey]*pendingPoint
+
+	lastSweep time.Time
+}
+
+// learn adds the families of the request to the known ones, and returns them.
+func (s *translatorState) learn(known knownFamilies, now time.Time) knownFamilies {
+	for name := range known.names {
+		s.known.names[name] = true
+		s.lastSeen[name] = now
+	}
+	for name := range known.histograms {
+		s.known.histograms[name] = true
+		s.lastSeen[name] = now
+	}
+	for name := range known.summaries {
+		s.known.summaries[name] = true
+		s.lastSeen[name] = now
+	}
+	for name, md := range known.metadata {
+		s.known.metadata[name] = md
+		s.lastSeen[name] = now
+	}
+	return s.known
+}
+
+// reassemble completes the resources converted from a request with the state, holds their histogram and summary
+// points until they are complete, and adds the points released to them.
+func (s *translatorState) reassemble(resources *resourceSet, now time.Time) *Released {
+	for _, key := range resources.order {
+		res := resources.resources[key]
+		if res.info != nil {
+			s.info[key] = cached[[]prompb.Label]{value: res.info, lastSeen: now}
+		}
+		for _, family := range res.families {
+			for signature, start := range family.created {
+				s.created[seriesKey{resource: key, family: family.name, signature: signature}] = cached[int64]{value: start, lastSeen: now}
+			}
+			if family.mtype != pmetric.MetricTypeHistogram && family.mtype != pmetric.MetricTypeSummary {
+				continue
+			}
+			for _, pk := range family.order {
+				s.hold(key, family, family.points[pk], now)
+			}
+			family.points = make(map[string]*promPoint)
+			family.order = nil
+		}
+	}
+
+	released := s.release(resources, now, false)
+	s.complete(resources)
+	s.sweep(now)
+	return released
+}
+
+// flush converts the points released without a request.
+func (s *translatorState) flush(now time.Time, all bool) (pmetric.Metrics, *Released) {
+	resources := newResourceSet()
+	released := s.release(resources, now, all)
+	s.complete(resources)
+	s.sweep(now)
+	return resources.toMetrics(s.known.metadata), released
+}
+
+// complete sets the `target_info` labels and the start timestamps of the resources from the state.
+func (s *translatorState) complete(resources *resourceSet) {
+	for _, key := range resources.order {
+		res := resources.resources[key]
+		if info, ok := s.info[key]; ok && res.info == nil {
+			res.info = info.value
+		}
+		for _, family := range res.families {
+			for _, pk := range family.order {
+				signature := labelsSignature(family.points[pk].labels)
+				if _, ok := family.created[signature]; ok {
+					continue
+				}
+				if start, ok := s.created[seriesKey{resource: key, family: family.name, signature: signature}]; ok {
+					family.created[signature] = start.value
+				}
+			}
+		}
+	}
+}
+
+func (s *translatorState) hold(resource resourceKey, family *promFamily, p *promPoint, now time.Time) {
+	key := pointKey{
+		seriesKey: seriesKey{resource: resource, family: family.name, signature: labelsSignature(p.labels)},
+		timestamp: p.timestamp,
+	}
+	if layout, ok := s.layouts[key.seriesKey]; ok && p.timestamp <= layout.value.lastTimestamp {
+		// the point has already been converted
+		return
+	}
+
+	pending, ok := s.pending[key]
+	if !ok {
+		pending = &pendingPoint{
+			mtype:    family.mtype,
+			point:    &promPoint{labels: p.labels, timestamp: p.timestamp},
+			received: now,
+		}
+		s.pending[key] = pending
+	}
+	pending.point.merge(p)
+}
+
+// release adds the points which are complete, or held for longer than the staleness duration, or all the points,
+// to the resources.
+func (s *translatorState) release(resources *resourceSet, now time.Time, all bool) *Released {
+	var released []pointKey
+	for key, pending := range s.pending {
+		layout, hasLayout := s.layouts[key.seriesKey]
+		if all || pending.complete(layout.value, hasLayout) || now.Sub(pending.received) >= s.staleness {
+			released = append(released, key)
+		}
+	}
+	sort.Slice(released, func(i, j int) bool {
+		if released[i].timestamp != released[j].timestamp {
+			return released[i].timestamp < released[j].timestamp
+		}
+		if released[i].family != released[j].family {
+			return released[i].family < released[j].family
+		}
+		return released[i].signature < released[j].signature
+	})
+
+	result := &Released{}
+	for _, key := range released {
+		pending := s.pending[key]
+		delete(s.pending, key)
+
+		layout, hasLayout := s.layouts[key.seriesKey]
+		if hasLayout && key.timestamp <= layout.value.lastTimestamp {
+			// a later point of the series has already been converted
+			continue
+		}
+		result.points = append(result.points, releasedPoint{key: key, pending: pending, layout: layout, hasLayout: hasLayout})
+		if !hasLayout || pending.complete(layout.value, hasLayout) {
+			layout.value = pending.point.layout()
+		} else {
+			layout.value.lastTimestamp = key.timestamp
+		}
+		layout.lastSeen = now
+		s.layouts[key.seriesKey] = layout
+
+		res := resources.get(key.resource)
+		fk := familyKey{name: key.family}
+		family, ok := res.families[fk]
+		if !ok {
+			family = &promFamily{
+				name:    key.family,
+				mtype:   pending.mtype,
+				points:  make(map[string]*promPoint),
+				created: make(map[string]int64),
+			}
+			res.families[fk] = family
+			res.order = append(res.order, fk)
+		}
+		family.point(pending.point.labels, pending.point.timestamp).merge(pending.point)
+	}
+	return result
+}
+
+// restore holds the released points again, and sets back the layouts of their series unless a later point has been
+// converted since.
+func (s *translatorState) restore(released *Released) {
+	if released == nil {
+		return
+	}
+	for i := len(released.points) - 1; i >= 0; i-- {
+		rp := released.points[i]
+		if layout, ok := s.layouts[rp.key.seriesKey]; ok && layout.value.lastTimestamp == rp.key.timestamp {
+			if rp.hasLayout {
+				s.layouts[rp.key.seriesKey] = rp.layout
+			} else {
+				delete(s.layouts, rp.key.seriesKey)
+			}
+		}
+		if pending, ok := s.pending[rp.key]; ok {
+			rp.pending.point.merge(pending.point)
+		}
+		s.pending[rp.key] = rp.pending
+	}
+}
+
+// sweep drops the state not refreshed for the staleness duration, at most once per staleness duration.
+func (s *translatorState) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.staleness {
+		return
+	}
+	s.lastSweep = now
+
+	for name, lastSeen := range s.lastSeen {
+		if now.Sub(lastSeen) >= s.staleness {
+			delete(s.lastSeen, name)
+			delete(s.known.names, name)
+			delete(s.known.histograms, name)
+			delete(s.known.summaries, name)
+			delete(s.known.metadata, name)
+		}
+	}
+	for key, info := range s.info {
+		if now.Sub(info.lastSeen) >= s.staleness {
+			delete(s.info, key)
+		}
+	}
+	for key, start := range s.created {
+		if now.Sub(start.lastSeen) >= s.staleness {
+			delete(s.created, key)
+		}
+	}
+	for key, layout := range s.layouts {
+		if now.Sub(layout.lastSeen) >= s.staleness {
+			delete(s.layouts, key)
+		}
+	}
+}
+
+// complete returns whether the point has all the parts of the previous point of its series, or the parts
+// Prometheus always sends when the series has no previous point.
+func (p *pendingPoint) complete(layout pointLayout, hasLayout bool) bool {
+	pt := p.point
+	if hasLayout {
+		return len(pt.buckets) >= layout.buckets && len(pt.quantiles) >= layout.quantiles &&
+			(pt.hasSum || !layout.hasSum) && (pt.hasCount || !layout.hasCount)
+	}
+	if p.mtype == pmetric.MetricTypeHistogram {
+		if _, ok := pt.buckets[math.Inf(1)]; !ok {
+			return false
+		}
+	}
+	return pt.hasSum && pt.hasCount
+}
+
+func (p *promPoint) layout() pointLayout {
+	return pointLayout{
+		buckets:       len(p.buckets),
+		quantiles:     len(p.quantiles),
+		hasSum:        p.hasSum,
+		hasCount:      p.hasCount,
+		lastTimestamp: p.timestamp,
+	}
+}
+
+// merge adds the parts of the other point of the same series and timestamp to the point.
+func (p *promPoint) merge(other *promPoint) {
+	p.stale = p.stale || other.stale
+	p.value = other.value
+	if other.hasSum {
+		p.sum, p.hasSum = other.sum, true
+	}
+	if other.hasCount {
+		p.count, p.hasCount = other.count, true
+	}
+	for bound, count := range other.buckets {
+		if p.buckets == nil {
+			p.buckets = make(map[float64]float64)
+		}
+		p.buckets[bound] = count
+	}
+	for quantile, value := range other.quantiles {
+		if p.quantiles == nil {
+			p.quantiles = make(map[float64]float64)
+		}
+		p.quantiles[quantile] = value
+	}
+	if other.native != nil {
+		p.native = other.native
+	}
+	p.exemplars = append(p.exemplars, other.exemplars...)
+}
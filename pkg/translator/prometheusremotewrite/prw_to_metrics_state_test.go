@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestTranslator(now *time.Time) *Translator {
+	translator := NewTranslator(time.Minute)
+	translator.now = func() time.Time { return *now }
+	return translator
+}
+
+func TestTranslatorMetadataAndInfoFromOtherRequests(t *testing.T) {
+	now := time.Unix(1000, 0)
+	translator := newTestTranslator(&now)
+
+	md, _, err := translator.ToMetrics(&prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{{
+			MetricFamilyName: "memory_usage",
+			Type:             prompb.MetricMetadata_GAUGE,
+			Help:             "The memory in use.",
+			Unit:             "bytes",
+		}},
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "target_info", "job", "svc", "host_name", "host"), getSample(1, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "memory_usage", "job", "svc"), getSample(10, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{"service.name": "svc", "host_name": "host"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "memory_usage", metric.Name())
+	assert.Equal(t, "By", metric.Unit())
+	assert.Equal(t, "The memory in use.", metric.Description())
+
+	// the state is dropped once it has not been refreshed for the staleness duration
+	now = now.Add(2 * time.Minute)
+	_, _, err = translator.ToMetrics(&prompb.WriteRequest{})
+	require.NoError(t, err)
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "memory_usage", "job", "svc"), getSample(10, 2000)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"service.name": "svc"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, "", md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Description())
+}
+
+func TestTranslatorHistogramFromSeveralRequests(t *testing.T) {
+	now := time.Unix(1000, 0)
+	translator := newTestTranslator(&now)
+
+	// the buckets and the sum of the point are received first
+	md, _, err := translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "1"), getSample(1, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "+Inf"), getSample(3, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_sum"), getSample(4.5, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_created"), getSample(500, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	// the count completes the point
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_count"), getSample(3, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, md.DataPointCount())
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "lat", metric.Name())
+	require.Equal(t, pmetric.MetricTypeHistogram, metric.Type())
+	dp := metric.Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 2}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, 4.5, dp.Sum())
+	assert.Equal(t, int64(500e6), int64(dp.StartTimestamp()))
+
+	// the parts received after the point has been converted are dropped
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "1"), getSample(1, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	// the next point waits for as many buckets as the previous one
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "+Inf"), getSample(5, 2000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_sum"), getSample(6, 2000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_count"), getSample(5, 2000)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "1"), getSample(2, 2000)),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, md.DataPointCount())
+	dp = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, []uint64{2, 3}, dp.BucketCounts().AsRaw())
+}
+
+func TestTranslatorReleasesStalePoints(t *testing.T) {
+	now := time.Unix(1000, 0)
+	translator := newTestTranslator(&now)
+
+	md, _, err := translator.ToMetrics(&prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{{MetricFamilyName: "rpc", Type: prompb.MetricMetadata_SUMMARY}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	// the sum is assigned to the summary known from the metadata, and held without its count
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "rpc_sum"), getSample(4.5, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	now = now.Add(time.Minute)
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, md.DataPointCount())
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "rpc", metric.Name())
+	require.Equal(t, pmetric.MetricTypeSummary, metric.Type())
+	assert.Equal(t, 4.5, metric.Summary().DataPoints().At(0).Sum())
+}
+
+func TestTranslatorReleasesStalePointsWithoutRequests(t *testing.T) {
+	now := time.Unix(1000, 0)
+	translator := newTestTranslator(&now)
+
+	md, _, err := translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "+Inf"), getSample(3, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_sum"), getSample(4.5, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "rpc", "quantile", "0.5"), getSample(2, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	md, released := translator.ReleaseStale()
+	assert.Equal(t, 0, md.DataPointCount())
+	assert.Equal(t, 0, released.Len())
+
+	now = now.Add(time.Minute)
+	md, released = translator.ReleaseStale()
+	assert.Equal(t, 2, md.DataPointCount())
+	assert.Equal(t, 2, released.Len())
+
+	md, released = translator.ReleaseStale()
+	assert.Equal(t, 0, md.DataPointCount())
+	assert.Equal(t, 0, released.Len())
+}
+
+func TestTranslatorReleasesAllPoints(t *testing.T) {
+	now := time.Unix(1000, 0)
+	translator := newTestTranslator(&now)
+
+	_, _, err := translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "+Inf"), getSample(3, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_sum"), getSample(4.5, 1000)),
+		},
+	})
+	require.NoError(t, err)
+
+	md, released := translator.ReleaseAll()
+	require.Equal(t, 1, md.DataPointCount())
+	assert.Equal(t, 1, released.Len())
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, 4.5, dp.Sum())
+}
+
+func TestTranslatorRestoresReleasedPoints(t *testing.T) {
+	now := time.Unix(1000, 0)
+	translator := newTestTranslator(&now)
+
+	_, _, err := translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "+Inf"), getSample(3, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_sum"), getSample(4.5, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	md, released, err := translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_count"), getSample(3, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, md.DataPointCount())
+
+	// the metrics could not be consumed, the client retries the request
+	translator.Restore(released)
+	md, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_count"), getSample(3, 1000)),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, md.DataPointCount())
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, 4.5, dp.Sum())
+
+	// the stale points which could not be consumed are released again
+	_, _, err = translator.ToMetrics(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "lat_sum"), getSample(6, 2000)),
+		},
+	})
+	require.NoError(t, err)
+	now = now.Add(time.Minute)
+	md, released = translator.ReleaseStale()
+	require.Equal(t, 1, md.DataPointCount())
+	translator.Restore(released)
+	md, _ = translator.ReleaseStale()
+	require.Equal(t, 1, md.DataPointCount())
+	dp = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, 6.0, dp.Sum())
+}
@@ -0,0 +1,281 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestToMetricsResources(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "target_info", "job", "ns/svc", "instance", "host:80", "host_name", "host"),
+				getSample(1, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "up", "job", "ns/svc", "instance", "host:80"),
+				getSample(1, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "up", "job", "other", "instance", "host:81", "code", "200"),
+				getSample(0, 1000)),
+			*getTimeSeries(getPromLabels("code", "200"), getSample(1, 1000)),
+		},
+	}
+
+	md, err := ToMetrics(req)
+	assert.Error(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	res := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		"service.namespace":   "ns",
+		"service.name":        "svc",
+		"service.instance.id": "host:80",
+		"host_name":           "host",
+	}, res.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, res.ScopeMetrics().At(0).Metrics().Len())
+	metric := res.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "up", metric.Name())
+	require.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+	assert.Equal(t, 1.0, metric.Gauge().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(1e9), metric.Gauge().DataPoints().At(0).Timestamp())
+
+	res = md.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]any{
+		"service.name":        "other",
+		"service.instance.id": "host:81",
+	}, res.Resource().Attributes().AsRaw())
+	dp := res.ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, map[string]any{"code": "200"}, dp.Attributes().AsRaw())
+}
+
+func TestToMetricsCounter(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeriesWithSamplesAndExemplars(getPromLabels("__name__", "requests_bytes_total", "code", "200"),
+				[]prompb.Sample{getSample(10, 1000), getSample(math.Float64frombits(value.StaleNaN), 2000)},
+				[]prompb.Exemplar{{
+					Value:     3,
+					Timestamp: 1500,
+					Labels: getPromLabels(traceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736", spanIDKey, "00f067aa0ba902b7",
+						"user", "alice"),
+				}}),
+			*getTimeSeries(getPromLabels("__name__", "requests_bytes_total_created", "code", "200"), getSample(500, 1000)),
+		},
+		Metadata: []prompb.MetricMetadata{{
+			MetricFamilyName: "requests_bytes_total",
+			Type:             prompb.MetricMetadata_COUNTER,
+			Help:             "The size of the requests.",
+		}},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+
+	metric := metrics.At(0)
+	assert.Equal(t, "requests", metric.Name())
+	assert.Equal(t, "By", metric.Unit())
+	assert.Equal(t, "The size of the requests.", metric.Description())
+	require.Equal(t, pmetric.MetricTypeSum, metric.Type())
+	assert.True(t, metric.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+	require.Equal(t, 2, metric.Sum().DataPoints().Len())
+
+	dp := metric.Sum().DataPoints().At(0)
+	assert.Equal(t, 10.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(500e6), dp.StartTimestamp())
+	assert.Equal(t, map[string]any{"code": "200"}, dp.Attributes().AsRaw())
+
+	dp = metric.Sum().DataPoints().At(1)
+	assert.True(t, dp.Flags().NoRecordedValue())
+	require.Equal(t, 1, dp.Exemplars().Len())
+	exemplar := dp.Exemplars().At(0)
+	assert.Equal(t, 3.0, exemplar.DoubleValue())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", exemplar.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", exemplar.SpanID().String())
+	assert.Equal(t, map[string]any{"user": "alice"}, exemplar.FilteredAttributes().AsRaw())
+}
+
+func TestToMetricsHistogramAndSummary(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("__name__", "latency_seconds_bucket", "le", "0.1"), getSample(2, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "latency_seconds_bucket", "le", "1"), getSample(5, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "latency_seconds_bucket", "le", "+Inf"), getSample(6, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "latency_seconds_sum"), getSample(4.5, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "latency_seconds_count"), getSample(6, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "latency_seconds_created"), getSample(100, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "size", "quantile", "0.5"), getSample(10, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "size", "quantile", "0.9"), getSample(20, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "size_sum"), getSample(100, 1000)),
+			*getTimeSeries(getPromLabels("__name__", "size_count"), getSample(8, 1000)),
+		},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	metric := metrics.At(0)
+	assert.Equal(t, "latency", metric.Name())
+	assert.Equal(t, "s", metric.Unit())
+	require.Equal(t, pmetric.MetricTypeHistogram, metric.Type())
+	hdp := metric.Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{0.1, 1}, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 3, 1}, hdp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(6), hdp.Count())
+	assert.Equal(t, 4.5, hdp.Sum())
+	assert.Equal(t, pcommon.Timestamp(100e6), hdp.StartTimestamp())
+
+	metric = metrics.At(1)
+	assert.Equal(t, "size", metric.Name())
+	require.Equal(t, pmetric.MetricTypeSummary, metric.Type())
+	sdp := metric.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(8), sdp.Count())
+	assert.Equal(t, 100.0, sdp.Sum())
+	require.Equal(t, 2, sdp.QuantileValues().Len())
+	assert.Equal(t, 0.9, sdp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 20.0, sdp.QuantileValues().At(1).Value())
+}
+
+func TestToMetricsNativeHistogram(t *testing.T) {
+	want := pmetric.NewExponentialHistogramDataPoint()
+	want.SetScale(2)
+	want.SetCount(12)
+	want.SetZeroCount(1)
+	want.SetSum(42)
+	want.SetTimestamp(pcommon.Timestamp(2e9))
+	want.Positive().SetOffset(-2)
+	want.Positive().BucketCounts().FromRaw([]uint64{3, 1, 0, 0, 0, 1})
+	want.Negative().SetOffset(4)
+	want.Negative().BucketCounts().FromRaw([]uint64{4, 2})
+
+	h, err := exponentialToNativeHistogram(want)
+	require.NoError(t, err)
+
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:     getPromLabels("__name__", "latency"),
+			Histograms: []prompb.Histogram{h},
+		}},
+	}
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+	got := metric.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, want.Scale(), got.Scale())
+	assert.Equal(t, want.Count(), got.Count())
+	assert.Equal(t, want.ZeroCount(), got.ZeroCount())
+	assert.Equal(t, want.Sum(), got.Sum())
+	assert.Equal(t, want.Timestamp(), got.Timestamp())
+	assert.Equal(t, want.Positive().Offset(), got.Positive().Offset())
+	assert.Equal(t, want.Positive().BucketCounts().AsRaw(), got.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, want.Negative().Offset(), got.Negative().Offset())
+	assert.Equal(t, want.Negative().BucketCounts().AsRaw(), got.Negative().BucketCounts().AsRaw())
+}
+
+func TestToMetricsNativeAndClassicHistogram(t *testing.T) {
+	native := pmetric.NewExponentialHistogramDataPoint()
+	native.SetScale(2)
+	native.SetCount(3)
+	native.SetSum(4.5)
+	native.SetTimestamp(pcommon.Timestamp(1e9))
+	native.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	h, err := exponentialToNativeHistogram(native)
+	require.NoError(t, err)
+
+	// Prometheus sends the classic series along with the native histogram when scraping the classic histograms
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:     getPromLabels("__name__", "lat"),
+				Histograms: []prompb.Histogram{h},
+			},
+			*getTimeSeries(getPromLabels("__name__", "lat_bucket", "le", "+Inf"), getSample(3, 2000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_count"), getSample(3, 2000)),
+			*getTimeSeries(getPromLabels("__name__", "lat_sum"), getSample(4.5, 2000)),
+		},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metrics.At(0).Type())
+	require.Equal(t, 1, metrics.At(0).ExponentialHistogram().DataPoints().Len())
+	assert.Equal(t, uint64(3), metrics.At(0).ExponentialHistogram().DataPoints().At(0).Count())
+
+	require.Equal(t, pmetric.MetricTypeHistogram, metrics.At(1).Type())
+	require.Equal(t, 1, metrics.At(1).Histogram().DataPoints().Len())
+	assert.Equal(t, uint64(3), metrics.At(1).Histogram().DataPoints().At(0).Count())
+	assert.Equal(t, 4.5, metrics.At(1).Histogram().DataPoints().At(0).Sum())
+}
+
+func TestToMetricsRoundTrip(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	rm.Resource().Attributes().PutStr("service.instance.id", "host:80")
+	rm.Resource().Attributes().PutStr("host.name", "host")
+	metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("http.server.requests")
+	metric.SetUnit("By")
+	sum := metric.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("code", "200")
+	dp.SetDoubleValue(10)
+	dp.SetStartTimestamp(pcommon.Timestamp(1e9))
+	dp.SetTimestamp(pcommon.Timestamp(2e9))
+
+	tsMap, err := FromMetrics(md, Settings{ExportCreatedMetric: true})
+	require.NoError(t, err)
+	req := &prompb.WriteRequest{}
+	for _, ts := range tsMap {
+		req.Timeseries = append(req.Timeseries, *ts)
+	}
+
+	got, err := ToMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, got.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{
+		"service.name":        "svc",
+		"service.instance.id": "host:80",
+		"host_name":           "host",
+	}, got.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	metrics := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "http_server_requests", metrics.At(0).Name())
+	assert.Equal(t, "By", metrics.At(0).Unit())
+	require.Equal(t, pmetric.MetricTypeSum, metrics.At(0).Type())
+	gotDp := metrics.At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, 10.0, gotDp.DoubleValue())
+	assert.Equal(t, dp.StartTimestamp(), gotDp.StartTimestamp())
+	assert.Equal(t, dp.Timestamp(), gotDp.Timestamp())
+	assert.Equal(t, map[string]any{"code": "200"}, gotDp.Attributes().AsRaw())
+}
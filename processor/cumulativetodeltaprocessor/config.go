@@ -35,6 +35,10 @@ type Config struct {
 	// Cannot be used with deprecated Metrics config option.
 	Include MatchMetrics `mapstructure:"include"`
 	Exclude MatchMetrics `mapstructure:"exclude"`
+
+	// ConvertSummaries enables the conversion of the count and sum of the summaries, which are left as they are
+	// otherwise.
+	ConvertSummaries bool `mapstructure:"convert_summaries"`
 }
 
 type MatchMetrics struct {
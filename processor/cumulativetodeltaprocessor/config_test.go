@@ -59,7 +59,8 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
+				MaxStaleness:     10 * time.Second,
+				ConvertSummaries: true,
 			},
 		},
 		{
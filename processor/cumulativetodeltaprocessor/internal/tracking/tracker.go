@@ -45,6 +45,9 @@ type DeltaValue struct {
 	FloatValue     float64
 	IntValue       int64
 	HistogramValue *HistogramPoint
+
+	ExponentialHistogramValue *ExponentialHistogramPoint
+	SummaryValue              *SummaryPoint
 }
 
 func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
@@ -119,6 +122,36 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.HistogramValue = &delta
+	case pmetric.MetricTypeExponentialHistogram:
+		value := metricPoint.ExponentialHistogramValue
+		prevValue := state.PrevPoint.ExponentialHistogramValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = prevValue.Sum
+		}
+
+		// Start over from the current value if the histogram was reset
+		delta, ok := value.Sub(prevValue)
+		if !ok {
+			delta = value.Clone()
+		}
+
+		out.ExponentialHistogramValue = &delta
+	case pmetric.MetricTypeSummary:
+		value := metricPoint.SummaryValue
+		prevValue := state.PrevPoint.SummaryValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = prevValue.Sum
+		}
+
+		delta := *value
+
+		// Calculate deltas unless summary count was reset
+		if delta.Count >= prevValue.Count {
+			delta.Count -= prevValue.Count
+			delta.Sum -= prevValue.Sum
+		}
+
+		out.SummaryValue = &delta
 	case pmetric.MetricTypeSum:
 		if metricID.IsFloatVal() {
 			value := metricPoint.FloatValue
@@ -16,6 +16,7 @@ package tracking
 
 import (
 	"context"
+	"math"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -151,6 +152,160 @@ func TestMetricTracker_Convert(t *testing.T) {
 	})
 }
 
+func TestMetricTracker_ConvertExponentialHistogram(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeExponentialHistogram,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	tests := []struct {
+		name    string
+		value   ExponentialHistogramPoint
+		wantOut *ExponentialHistogramPoint
+	}{
+		{
+			name: "Initial Value Omitted",
+			value: ExponentialHistogramPoint{
+				Count: 6, Sum: 60, Scale: 2, ZeroCount: 1,
+				Positive: ExponentialHistogramBuckets{Offset: 4, Counts: []uint64{1, 2}},
+				Negative: ExponentialHistogramBuckets{Offset: 0, Counts: []uint64{2}},
+			},
+		},
+		{
+			name: "Same Scale With Offset Shift",
+			value: ExponentialHistogramPoint{
+				Count: 11, Sum: 110, Scale: 2, ZeroCount: 2,
+				Positive: ExponentialHistogramBuckets{Offset: 3, Counts: []uint64{1, 1, 4}},
+				Negative: ExponentialHistogramBuckets{Offset: 0, Counts: []uint64{3}},
+			},
+			wantOut: &ExponentialHistogramPoint{
+				Count: 5, Sum: 50, Scale: 2, ZeroCount: 1,
+				Positive: ExponentialHistogramBuckets{Offset: 3, Counts: []uint64{1, 0, 2}},
+				Negative: ExponentialHistogramBuckets{Offset: 0, Counts: []uint64{1}},
+			},
+		},
+		{
+			name: "Lower Scale",
+			value: ExponentialHistogramPoint{
+				Count: 14, Sum: 140, Scale: 1, ZeroCount: 2,
+				Positive: ExponentialHistogramBuckets{Offset: 1, Counts: []uint64{2, 5, 1}},
+				Negative: ExponentialHistogramBuckets{Offset: 0, Counts: []uint64{4}},
+			},
+			// the previous buckets 3, 4 and 5 are merged into the buckets 1 and 2 of scale 1
+			wantOut: &ExponentialHistogramPoint{
+				Count: 3, Sum: 30, Scale: 1, ZeroCount: 0,
+				Positive: ExponentialHistogramBuckets{Offset: 1, Counts: []uint64{1, 0, 1}},
+				Negative: ExponentialHistogramBuckets{Offset: 0, Counts: []uint64{1}},
+			},
+		},
+		{
+			name: "Bucket Count Decreased Is A Reset",
+			value: ExponentialHistogramPoint{
+				Count: 15, Sum: 150, Scale: 1, ZeroCount: 2,
+				Positive: ExponentialHistogramBuckets{Offset: 2, Counts: []uint64{9, 2}},
+				Negative: ExponentialHistogramBuckets{Offset: 0, Counts: []uint64{4}},
+			},
+			wantOut: &ExponentialHistogramPoint{
+				Count: 15, Sum: 150, Scale: 1, ZeroCount: 2,
+				Positive: ExponentialHistogramBuckets{Offset: 2, Counts: []uint64{9, 2}},
+				Negative: ExponentialHistogramBuckets{Offset: 0, Counts: []uint64{4}},
+			},
+		},
+		{
+			name: "Count Decreased Is A Reset",
+			value: ExponentialHistogramPoint{
+				Count: 2, Sum: 20, Scale: 3, ZeroCount: 0,
+				Positive: ExponentialHistogramBuckets{Offset: -2, Counts: []uint64{2}},
+			},
+			wantOut: &ExponentialHistogramPoint{
+				Count: 2, Sum: 20, Scale: 3, ZeroCount: 0,
+				Positive: ExponentialHistogramBuckets{Offset: -2, Counts: []uint64{2}},
+				Negative: ExponentialHistogramBuckets{Counts: []uint64{}},
+			},
+		},
+		{
+			name: "Higher Scale Than Previous Point",
+			value: ExponentialHistogramPoint{
+				Count: 4, Sum: 40, Scale: 4, ZeroCount: 0,
+				Positive: ExponentialHistogramBuckets{Offset: -4, Counts: []uint64{1, 1, 2}},
+			},
+			// the current buckets -4, -3 and -2 are merged into the bucket -2 of scale 3
+			wantOut: &ExponentialHistogramPoint{
+				Count: 2, Sum: 20, Scale: 3, ZeroCount: 0,
+				Positive: ExponentialHistogramBuckets{Offset: -2, Counts: []uint64{0, 2}},
+				Negative: ExponentialHistogramBuckets{Counts: []uint64{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.value
+			gotOut, valid := m.Convert(MetricPoint{
+				Identity: mi,
+				Value: ValuePoint{
+					ObservedTimestamp:         10,
+					ExponentialHistogramValue: &value,
+				},
+			})
+			if tt.wantOut == nil {
+				assert.False(t, valid)
+				return
+			}
+			require.True(t, valid)
+			assert.Equal(t, pcommon.Timestamp(10), gotOut.StartTimestamp)
+			assert.Equal(t, tt.wantOut, gotOut.ExponentialHistogramValue)
+		})
+	}
+}
+
+func TestExponentialHistogramBucketsDownscale(t *testing.T) {
+	buckets := ExponentialHistogramBuckets{Offset: -3, Counts: []uint64{1, 2, 3, 4, 5}}
+	assert.Equal(t, ExponentialHistogramBuckets{Offset: -2, Counts: []uint64{1, 5, 9}}, buckets.downscale(1))
+	assert.Equal(t, ExponentialHistogramBuckets{Offset: -1, Counts: []uint64{6, 9}}, buckets.downscale(2))
+	assert.Equal(t, ExponentialHistogramBuckets{Offset: -1}, ExponentialHistogramBuckets{Offset: -2}.downscale(1))
+}
+
+func TestMetricTracker_ConvertSummary(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSummary,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	for _, tt := range []struct {
+		value   SummaryPoint
+		wantOut *SummaryPoint
+	}{
+		{value: SummaryPoint{Count: 10, Sum: 100}},
+		{value: SummaryPoint{Count: 15, Sum: 120}, wantOut: &SummaryPoint{Count: 5, Sum: 20}},
+		{value: SummaryPoint{Count: 18, Sum: math.NaN()}, wantOut: &SummaryPoint{Count: 3, Sum: 0}},
+		{value: SummaryPoint{Count: 4, Sum: 30}, wantOut: &SummaryPoint{Count: 4, Sum: 30}},
+	} {
+		value := tt.value
+		gotOut, valid := m.Convert(MetricPoint{
+			Identity: mi,
+			Value:    ValuePoint{ObservedTimestamp: 10, SummaryValue: &value},
+		})
+		if tt.wantOut == nil {
+			assert.False(t, valid)
+			continue
+		}
+		require.True(t, valid)
+		assert.Equal(t, tt.wantOut, gotOut.SummaryValue)
+	}
+}
+
 func Test_metricTracker_removeStale(t *testing.T) {
 	currentTime := pcommon.Timestamp(100)
 	freshPoint := ValuePoint{
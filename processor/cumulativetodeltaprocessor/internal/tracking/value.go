@@ -21,6 +21,9 @@ type ValuePoint struct {
 	FloatValue        float64
 	IntValue          int64
 	HistogramValue    *HistogramPoint
+
+	ExponentialHistogramValue *ExponentialHistogramPoint
+	SummaryValue              *SummaryPoint
 }
 
 type HistogramPoint struct {
@@ -39,3 +42,114 @@ func (point *HistogramPoint) Clone() HistogramPoint {
 		Buckets: bucketValues,
 	}
 }
+
+type ExponentialHistogramPoint struct {
+	Count     uint64
+	Sum       float64
+	Scale     int32
+	ZeroCount uint64
+	Positive  ExponentialHistogramBuckets
+	Negative  ExponentialHistogramBuckets
+}
+
+type ExponentialHistogramBuckets struct {
+	Offset int32
+	Counts []uint64
+}
+
+func (point *ExponentialHistogramPoint) Clone() ExponentialHistogramPoint {
+	return ExponentialHistogramPoint{
+		Count:     point.Count,
+		Sum:       point.Sum,
+		Scale:     point.Scale,
+		ZeroCount: point.ZeroCount,
+		Positive:  point.Positive.clone(),
+		Negative:  point.Negative.clone(),
+	}
+}
+
+// Sub returns the difference between the point and the previous one, at the lowest scale of
+// the two points. It returns false if the point can't follow the previous one, because one of
+// its counts is lower, which indicates a reset.
+func (point *ExponentialHistogramPoint) Sub(prev *ExponentialHistogramPoint) (ExponentialHistogramPoint, bool) {
+	if point.Count < prev.Count || point.ZeroCount < prev.ZeroCount {
+		return ExponentialHistogramPoint{}, false
+	}
+
+	// cumulative histograms lower their scale as their range grows, bring both points to the lowest one
+	cur, old := *point, *prev
+	if cur.Scale > old.Scale {
+		cur = cur.downscale(cur.Scale - old.Scale)
+	} else if old.Scale > cur.Scale {
+		old = old.downscale(old.Scale - cur.Scale)
+	}
+
+	positive, ok := cur.Positive.sub(old.Positive)
+	if !ok {
+		return ExponentialHistogramPoint{}, false
+	}
+	negative, ok := cur.Negative.sub(old.Negative)
+	if !ok {
+		return ExponentialHistogramPoint{}, false
+	}
+
+	return ExponentialHistogramPoint{
+		Count:     cur.Count - old.Count,
+		Sum:       cur.Sum - old.Sum,
+		Scale:     cur.Scale,
+		ZeroCount: cur.ZeroCount - old.ZeroCount,
+		Positive:  positive,
+		Negative:  negative,
+	}, true
+}
+
+func (point ExponentialHistogramPoint) downscale(by int32) ExponentialHistogramPoint {
+	point.Scale -= by
+	point.Positive = point.Positive.downscale(by)
+	point.Negative = point.Negative.downscale(by)
+	return point
+}
+
+func (buckets ExponentialHistogramBuckets) clone() ExponentialHistogramBuckets {
+	counts := make([]uint64, len(buckets.Counts))
+	copy(counts, buckets.Counts)
+	return ExponentialHistogramBuckets{Offset: buckets.Offset, Counts: counts}
+}
+
+// downscale merges the buckets to lower their scale by the given amount: each bucket
+// of the lower scale holds 2^by buckets of the original scale.
+func (buckets ExponentialHistogramBuckets) downscale(by int32) ExponentialHistogramBuckets {
+	offset := buckets.Offset >> by
+	if len(buckets.Counts) == 0 {
+		return ExponentialHistogramBuckets{Offset: offset}
+	}
+
+	last := (buckets.Offset + int32(len(buckets.Counts)) - 1) >> by
+	counts := make([]uint64, last-offset+1)
+	for i, count := range buckets.Counts {
+		counts[((buckets.Offset+int32(i))>>by)-offset] += count
+	}
+	return ExponentialHistogramBuckets{Offset: offset, Counts: counts}
+}
+
+// sub returns the difference between the buckets and the previous ones of the same scale, over the
+// range of the current buckets. It returns false if a previous bucket has a higher count.
+func (buckets ExponentialHistogramBuckets) sub(prev ExponentialHistogramBuckets) (ExponentialHistogramBuckets, bool) {
+	delta := buckets.clone()
+	for i, count := range prev.Counts {
+		if count == 0 {
+			continue
+		}
+		index := prev.Offset + int32(i) - buckets.Offset
+		if index < 0 || int(index) >= len(delta.Counts) || delta.Counts[index] < count {
+			return ExponentialHistogramBuckets{}, false
+		}
+		delta.Counts[index] -= count
+	}
+	return delta, true
+}
+
+type SummaryPoint struct {
+	Count uint64
+	Sum   float64
+}
@@ -31,6 +31,8 @@ type cumulativeToDeltaProcessor struct {
 	logger          *zap.Logger
 	deltaCalculator *tracking.MetricTracker
 	cancelFunc      context.CancelFunc
+
+	convertSummaries bool
 }
 
 func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
@@ -39,6 +41,8 @@ func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulati
 		logger:          logger,
 		deltaCalculator: tracking.NewMetricTracker(ctx, logger, config.MaxStaleness),
 		cancelFunc:      cancel,
+
+		convertSummaries: config.ConvertSummaries,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -104,6 +108,49 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 
 					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+
+					if ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeSummary:
+					// Summaries have no aggregation temporality, their count and sum are always cumulative
+					ms := m.Summary()
+					if !ctdp.convertSummaries || ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertSummaryDataPoints(ms.DataPoints(), baseIdentity)
+					return ms.DataPoints().Len() == 0
 				default:
 					return false
 				}
@@ -211,3 +258,103 @@ func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in interface{
 		})
 	}
 }
+
+func (ctdp *cumulativeToDeltaProcessor) convertExponentialHistogramDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
+	if dps, ok := in.(pmetric.ExponentialHistogramDataPointSlice); ok {
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			id := baseIdentity
+			id.StartTimestamp = dp.StartTimestamp()
+			id.Attributes = dp.Attributes()
+
+			if dp.Flags().NoRecordedValue() {
+				// drop points with no value
+				return true
+			}
+
+			point := tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				ExponentialHistogramValue: &tracking.ExponentialHistogramPoint{
+					Count:     dp.Count(),
+					Sum:       dp.Sum(),
+					Scale:     dp.Scale(),
+					ZeroCount: dp.ZeroCount(),
+					Positive: tracking.ExponentialHistogramBuckets{
+						Offset: dp.Positive().Offset(),
+						Counts: dp.Positive().BucketCounts().AsRaw(),
+					},
+					Negative: tracking.ExponentialHistogramBuckets{
+						Offset: dp.Negative().Offset(),
+						Counts: dp.Negative().BucketCounts().AsRaw(),
+					},
+				},
+			}
+
+			trackingPoint := tracking.MetricPoint{
+				Identity: id,
+				Value:    point,
+			}
+			delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+
+			if valid {
+				value := delta.ExponentialHistogramValue
+				dp.SetStartTimestamp(delta.StartTimestamp)
+				dp.SetCount(value.Count)
+				if dp.HasSum() && !math.IsNaN(dp.Sum()) {
+					dp.SetSum(value.Sum)
+				}
+				dp.SetScale(value.Scale)
+				dp.SetZeroCount(value.ZeroCount)
+				dp.Positive().SetOffset(value.Positive.Offset)
+				dp.Positive().BucketCounts().FromRaw(value.Positive.Counts)
+				dp.Negative().SetOffset(value.Negative.Offset)
+				dp.Negative().BucketCounts().FromRaw(value.Negative.Counts)
+				dp.RemoveMin()
+				dp.RemoveMax()
+				return false
+			}
+
+			return !valid
+		})
+	}
+}
+
+func (ctdp *cumulativeToDeltaProcessor) convertSummaryDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
+	if dps, ok := in.(pmetric.SummaryDataPointSlice); ok {
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+			id := baseIdentity
+			id.StartTimestamp = dp.StartTimestamp()
+			id.Attributes = dp.Attributes()
+
+			if dp.Flags().NoRecordedValue() {
+				// drop points with no value
+				return true
+			}
+
+			point := tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				SummaryValue: &tracking.SummaryPoint{
+					Count: dp.Count(),
+					Sum:   dp.Sum(),
+				},
+			}
+
+			trackingPoint := tracking.MetricPoint{
+				Identity: id,
+				Value:    point,
+			}
+			delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+
+			if valid {
+				dp.SetStartTimestamp(delta.StartTimestamp)
+				dp.SetCount(delta.SummaryValue.Count)
+				if !math.IsNaN(dp.Sum()) {
+					dp.SetSum(delta.SummaryValue.Sum)
+				}
+				// the quantile values can't be converted, they are left as they are
+				return false
+			}
+
+			return !valid
+		})
+	}
+}
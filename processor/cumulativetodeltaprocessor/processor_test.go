@@ -545,6 +545,133 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDeltaExponentialHistogram(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	mgp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.Start(context.Background(), nil))
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("exponential_histogram")
+	hist := m.SetEmptyExponentialHistogram()
+	hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	appendPoint := func(ts pcommon.Timestamp, scale int32, count uint64, offset int32, buckets []uint64) {
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetScale(scale)
+		dp.SetCount(count)
+		dp.SetSum(float64(count * 10))
+		dp.SetMin(1)
+		dp.SetMax(100)
+		dp.SetZeroCount(1)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(buckets)
+	}
+	appendPoint(10, 2, 4, 4, []uint64{1, 2})
+	// the offset moves back and the scale is lowered, the previous buckets 4 and 5 are merged into the bucket 2
+	appendPoint(20, 1, 9, 1, []uint64{2, 3, 3})
+	// reset
+	appendPoint(30, 1, 3, 2, []uint64{2})
+	appendPoint(40, 1, 6, 2, []uint64{4, 1})
+	dp := hist.DataPoints().AppendEmpty()
+	dp.SetFlags(noValueFlag)
+
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, mgp.Shutdown(context.Background()))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	gotHist := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, gotHist.AggregationTemporality())
+
+	expected := []struct {
+		start   pcommon.Timestamp
+		count   uint64
+		sum     float64
+		zero    uint64
+		offset  int32
+		buckets []uint64
+	}{
+		{start: 10, count: 5, sum: 50, zero: 0, offset: 1, buckets: []uint64{2, 0, 3}},
+		{start: 20, count: 3, sum: 30, zero: 1, offset: 2, buckets: []uint64{2}},
+		{start: 30, count: 3, sum: 30, zero: 0, offset: 2, buckets: []uint64{2, 1}},
+	}
+	require.Equal(t, len(expected), gotHist.DataPoints().Len())
+	for i, want := range expected {
+		dp := gotHist.DataPoints().At(i)
+		assert.Equal(t, want.start, dp.StartTimestamp())
+		assert.Equal(t, int32(1), dp.Scale())
+		assert.Equal(t, want.count, dp.Count())
+		assert.Equal(t, want.sum, dp.Sum())
+		assert.Equal(t, want.zero, dp.ZeroCount())
+		assert.Equal(t, want.offset, dp.Positive().Offset())
+		assert.Equal(t, want.buckets, dp.Positive().BucketCounts().AsRaw())
+		assert.False(t, dp.HasMin())
+		assert.False(t, dp.HasMax())
+	}
+}
+
+func generateTestSummaryMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("summary")
+	summary := m.SetEmptySummary()
+	for i, count := range []uint64{10, 15, 4} {
+		dp := summary.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(10 * (i + 1)))
+		dp.SetCount(count)
+		dp.SetSum(float64(count) * 2)
+		q := dp.QuantileValues().AppendEmpty()
+		q.SetQuantile(0.5)
+		q.SetValue(2)
+	}
+	return md
+}
+
+func TestCumulativeToDeltaSummary(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ConvertSummaries = true
+	next := new(consumertest.MetricsSink)
+	mgp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.Start(context.Background(), nil))
+
+	md := generateTestSummaryMetrics()
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, mgp.Shutdown(context.Background()))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	dps := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Summary().DataPoints()
+	require.Equal(t, 2, dps.Len())
+
+	assert.Equal(t, pcommon.Timestamp(10), dps.At(0).StartTimestamp())
+	assert.Equal(t, uint64(5), dps.At(0).Count())
+	assert.Equal(t, 10.0, dps.At(0).Sum())
+	assert.Equal(t, 2.0, dps.At(0).QuantileValues().At(0).Value())
+
+	// reset
+	assert.Equal(t, pcommon.Timestamp(20), dps.At(1).StartTimestamp())
+	assert.Equal(t, uint64(4), dps.At(1).Count())
+	assert.Equal(t, 8.0, dps.At(1).Sum())
+}
+
+func TestCumulativeToDeltaSummaryNotConvertedByDefault(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	mgp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.Start(context.Background(), nil))
+
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), generateTestSummaryMetrics()))
+	require.NoError(t, mgp.Shutdown(context.Background()))
+
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	assert.Equal(t, generateTestSummaryMetrics(), got[0])
+}
+
 func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
@@ -15,7 +15,16 @@
 package deltatorateprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor"
 
 import (
+	"errors"
 	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filtermetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 // Config defines the configuration for the processor.
@@ -23,13 +32,47 @@ type Config struct {
 
 	// List of delta sum metrics to convert to rates
 	Metrics []string `mapstructure:"metrics"`
+
+	// Include match properties describe metrics that should be converted to rates.
+	Include *filtermetric.MatchProperties `mapstructure:"include"`
+
+	// Exclude match properties describe metrics that should not be converted to rates.
+	// Exclude is checked after Include.
+	Exclude *filtermetric.MatchProperties `mapstructure:"exclude"`
+
+	// Conditions is a list of OTTL conditions for an ottlmetric context.
+	// A metric matching at least one condition is converted to rates.
+	Conditions []string `mapstructure:"conditions"`
+
+	// ErrorMode determines how the processor reacts to errors that occur while evaluating an OTTL condition.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+
+	// ConvertCumulative enables the conversion of cumulative sums and histograms to rates, using the
+	// previous point of each stream.
+	ConvertCumulative bool `mapstructure:"convert_cumulative"`
+
+	// MaxStaleness is the time the previous point of a cumulative stream is kept after it was last seen.
+	// Zero keeps it indefinitely.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
 }
 
 // Validate checks whether the input configuration has all of the required fields for the processor.
 // An error is returned if there are any invalid inputs.
 func (config *Config) Validate() error {
-	if len(config.Metrics) == 0 {
+	if len(config.Metrics) == 0 && config.Include == nil && config.Exclude == nil && len(config.Conditions) == 0 {
 		return fmt.Errorf("metric names are missing")
 	}
+	if len(config.Conditions) > 0 && (config.Include != nil || config.Exclude != nil) {
+		return errors.New("cannot use conditions along with include or exclude")
+	}
+	if len(config.Conditions) > 0 {
+		_, err := filterottl.NewBoolExprForMetric(config.Conditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		if err != nil {
+			return err
+		}
+	}
+	if config.MaxStaleness < 0 {
+		return errors.New("max_staleness cannot be negative")
+	}
 	return nil
 }
@@ -17,12 +17,15 @@ package deltatorateprocessor
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filtermetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor/internal/metadata"
 )
 
@@ -41,12 +44,40 @@ func TestLoadConfig(t *testing.T) {
 					"metric1",
 					"metric2",
 				},
+				ErrorMode: ottl.PropagateError,
 			},
 		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "missing_name"),
 			errorMessage: "metric names are missing",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "include"),
+			expected: &Config{
+				Include: &filtermetric.MatchProperties{
+					MatchType:   filtermetric.Regexp,
+					MetricNames: []string{`^http\.server\..*`},
+				},
+				Exclude: &filtermetric.MatchProperties{
+					MatchType:   filtermetric.Strict,
+					MetricNames: []string{"http.server.active_requests"},
+				},
+				ErrorMode:         ottl.PropagateError,
+				ConvertCumulative: true,
+				MaxStaleness:      10 * time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "conditions"),
+			expected: &Config{
+				Conditions: []string{`type == METRIC_DATA_TYPE_HISTOGRAM and IsMatch(name, "^rpc\\.")`},
+				ErrorMode:  ottl.IgnoreError,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "conditions_and_include"),
+			errorMessage: "cannot use conditions along with include or exclude",
+		},
 	}
 
 	for _, tt := range tests {
@@ -70,3 +101,10 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateInvalidCondition(t *testing.T) {
+	cfg := &Config{
+		Conditions: []string{"invalid condition"},
+	}
+	assert.ErrorContains(t, cfg.Validate(), "unable to parse OTTL statement")
+}
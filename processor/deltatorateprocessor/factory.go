@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor/internal/metadata"
 )
 
@@ -37,7 +38,9 @@ func NewFactory() processor.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		ErrorMode: ottl.PropagateError,
+	}
 }
 
 func createMetricsProcessor(
@@ -51,7 +54,10 @@ func createMetricsProcessor(
 		return nil, fmt.Errorf("configuration parsing error")
 	}
 
-	metricsProcessor := newDeltaToRateProcessor(processorConfig, set.Logger)
+	metricsProcessor, err := newDeltaToRateProcessor(processorConfig, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
 
 	return processorhelper.NewMetricsProcessor(
 		ctx,
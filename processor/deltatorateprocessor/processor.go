@@ -17,29 +17,69 @@ package deltatorateprocessor // import "github.com/open-telemetry/opentelemetry-
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filtermetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+const (
+	lowerBoundAttribute = "lower_bound"
+	upperBoundAttribute = "upper_bound"
 )
 
 type deltaToRateProcessor struct {
-	ConfiguredMetrics map[string]bool
+	selectMetricExpr  expr.BoolExpr[ottlmetric.TransformContext]
+	convertCumulative bool
+	streams           *streamTracker
 	logger            *zap.Logger
 }
 
-func newDeltaToRateProcessor(config *Config, logger *zap.Logger) *deltaToRateProcessor {
-	inputMetricSet := make(map[string]bool, len(config.Metrics))
-	for _, name := range config.Metrics {
-		inputMetricSet[name] = true
+func newDeltaToRateProcessor(config *Config, set component.TelemetrySettings) (*deltaToRateProcessor, error) {
+	var matchers []expr.BoolExpr[ottlmetric.TransformContext]
+	if len(config.Metrics) > 0 {
+		skipExpr, err := filtermetric.NewSkipExpr(&filtermetric.MatchProperties{
+			MatchType:   filtermetric.Strict,
+			MetricNames: config.Metrics,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, expr.Not(skipExpr))
+	}
+	if config.Include != nil || config.Exclude != nil {
+		skipExpr, err := filtermetric.NewSkipExpr(config.Include, config.Exclude)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, expr.Not(skipExpr))
+	}
+	if len(config.Conditions) > 0 {
+		conditionsExpr, err := filterottl.NewBoolExprForMetric(config.Conditions, filterottl.StandardMetricFuncs(), config.ErrorMode, set)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, conditionsExpr)
 	}
 
-	return &deltaToRateProcessor{
-		ConfiguredMetrics: inputMetricSet,
-		logger:            logger,
+	dtrp := &deltaToRateProcessor{
+		selectMetricExpr:  expr.Or(matchers...),
+		convertCumulative: config.ConvertCumulative,
+		logger:            set.Logger,
+	}
+	if config.ConvertCumulative {
+		dtrp.streams = newStreamTracker(config.MaxStaleness)
 	}
+	return dtrp, nil
 }
 
 // Start is invoked during service startup.
@@ -48,7 +88,14 @@ func (dtrp *deltaToRateProcessor) Start(context.Context, component.Host) error {
 }
 
 // processMetrics implements the ProcessMetricsFunc type.
-func (dtrp *deltaToRateProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (dtrp *deltaToRateProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if dtrp.selectMetricExpr == nil {
+		return md, nil
+	}
+	if dtrp.streams != nil {
+		dtrp.streams.removeStale(time.Now())
+	}
+
 	resourceMetricsSlice := md.ResourceMetrics()
 
 	for i := 0; i < resourceMetricsSlice.Len(); i++ {
@@ -56,43 +103,42 @@ func (dtrp *deltaToRateProcessor) processMetrics(_ context.Context, md pmetric.M
 		ilms := rm.ScopeMetrics()
 		for i := 0; i < ilms.Len(); i++ {
 			ilm := ilms.At(i)
-			metricSlice := ilm.Metrics()
-			for j := 0; j < metricSlice.Len(); j++ {
-				metric := metricSlice.At(j)
-				if _, ok := dtrp.ConfiguredMetrics[metric.Name()]; !ok {
-					continue
+			var errs error
+			ilm.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+				if errs != nil {
+					return false
 				}
-				if metric.Type() != pmetric.MetricTypeSum || metric.Sum().AggregationTemporality() != pmetric.AggregationTemporalityDelta {
-					dtrp.logger.Info(fmt.Sprintf("Configured metric for rate calculation %s is not a delta sum\n", metric.Name()))
-					continue
+				selected, err := dtrp.selectMetricExpr.Eval(ctx, ottlmetric.NewTransformContext(metric, ilm.Scope(), rm.Resource()))
+				if err != nil {
+					errs = err
+					return false
 				}
-				newDoubleDataPointSlice := pmetric.NewNumberDataPointSlice()
-				dataPoints := metric.Sum().DataPoints()
-
-				for i := 0; i < dataPoints.Len(); i++ {
-					fromDataPoint := dataPoints.At(i)
-					newDp := newDoubleDataPointSlice.AppendEmpty()
-					fromDataPoint.CopyTo(newDp)
-
-					durationNanos := time.Duration(fromDataPoint.Timestamp() - fromDataPoint.StartTimestamp())
-					var rate float64
-					switch fromDataPoint.ValueType() {
-					case pmetric.NumberDataPointValueTypeDouble:
-						rate = calculateRate(fromDataPoint.DoubleValue(), durationNanos)
-					case pmetric.NumberDataPointValueTypeInt:
-						rate = calculateRate(float64(fromDataPoint.IntValue()), durationNanos)
-					default:
-						return md, consumererror.NewPermanent(fmt.Errorf("invalid data point type:%d", fromDataPoint.ValueType()))
-					}
-					newDp.SetDoubleValue(rate)
+				if !selected {
+					return false
 				}
 
-				dps := metric.SetEmptyGauge().DataPoints()
-				dps.EnsureCapacity(newDoubleDataPointSlice.Len())
-				for d := 0; d < newDoubleDataPointSlice.Len(); d++ {
-					dp := dps.AppendEmpty()
-					newDoubleDataPointSlice.At(d).CopyTo(dp)
+				var dps pmetric.NumberDataPointSlice
+				switch {
+				case isTemporality(metric, pmetric.AggregationTemporalityDelta):
+					dps, err = dtrp.deltaRates(metric)
+				case dtrp.convertCumulative && isTemporality(metric, pmetric.AggregationTemporalityCumulative):
+					dps, err = dtrp.cumulativeRates(metric, ilm.Scope(), rm.Resource())
+				default:
+					dtrp.logger.Debug("Configured metric for rate calculation is not a supported sum or histogram", zap.String("metric", metric.Name()))
+					return false
+				}
+				if err != nil {
+					errs = err
+					return false
 				}
+
+				gaugeDps := metric.SetEmptyGauge().DataPoints()
+				dps.MoveAndAppendTo(gaugeDps)
+				// the first point of a cumulative stream has no rate
+				return gaugeDps.Len() == 0
+			})
+			if errs != nil {
+				return md, errs
 			}
 		}
 	}
@@ -104,6 +150,154 @@ func (dtrp *deltaToRateProcessor) Shutdown(context.Context) error {
 	return nil
 }
 
+func isTemporality(metric pmetric.Metric, temporality pmetric.AggregationTemporality) bool {
+	switch metric.Type() {
+	case pmetric.MetricTypeSum:
+		return metric.Sum().AggregationTemporality() == temporality
+	case pmetric.MetricTypeHistogram:
+		return metric.Histogram().AggregationTemporality() == temporality
+	default:
+		return false
+	}
+}
+
+// deltaRates returns the rates of the points of a delta sum or histogram.
+func (dtrp *deltaToRateProcessor) deltaRates(metric pmetric.Metric) (pmetric.NumberDataPointSlice, error) {
+	newDoubleDataPointSlice := pmetric.NewNumberDataPointSlice()
+
+	if metric.Type() == pmetric.MetricTypeHistogram {
+		dataPoints := metric.Histogram().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			fromDataPoint := dataPoints.At(i)
+			if err := validateBuckets(fromDataPoint); err != nil {
+				return newDoubleDataPointSlice, err
+			}
+			appendBucketRates(newDoubleDataPointSlice, fromDataPoint, fromDataPoint.BucketCounts().AsRaw(), fromDataPoint.StartTimestamp())
+		}
+		return newDoubleDataPointSlice, nil
+	}
+
+	dataPoints := metric.Sum().DataPoints()
+	for i := 0; i < dataPoints.Len(); i++ {
+		fromDataPoint := dataPoints.At(i)
+		newDp := newDoubleDataPointSlice.AppendEmpty()
+		fromDataPoint.CopyTo(newDp)
+
+		durationNanos := time.Duration(fromDataPoint.Timestamp() - fromDataPoint.StartTimestamp())
+		var rate float64
+		switch fromDataPoint.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			rate = calculateRate(fromDataPoint.DoubleValue(), durationNanos)
+		case pmetric.NumberDataPointValueTypeInt:
+			rate = calculateRate(float64(fromDataPoint.IntValue()), durationNanos)
+		default:
+			return newDoubleDataPointSlice, consumererror.NewPermanent(fmt.Errorf("invalid data point type:%d", fromDataPoint.ValueType()))
+		}
+		newDp.SetDoubleValue(rate)
+	}
+	return newDoubleDataPointSlice, nil
+}
+
+// cumulativeRates returns the rates of the points of a cumulative sum or histogram, computed from
+// the previous point of their stream. The first point of a stream, and the points following a
+// reset, are dropped.
+func (dtrp *deltaToRateProcessor) cumulativeRates(metric pmetric.Metric, scope pcommon.InstrumentationScope, resource pcommon.Resource) (pmetric.NumberDataPointSlice, error) {
+	newDoubleDataPointSlice := pmetric.NewNumberDataPointSlice()
+
+	if metric.Type() == pmetric.MetricTypeHistogram {
+		dataPoints := metric.Histogram().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			fromDataPoint := dataPoints.At(i)
+			if err := validateBuckets(fromDataPoint); err != nil {
+				return newDoubleDataPointSlice, err
+			}
+			current := streamPoint{
+				startTimestamp: fromDataPoint.StartTimestamp(),
+				timestamp:      fromDataPoint.Timestamp(),
+				buckets:        fromDataPoint.BucketCounts().AsRaw(),
+				bounds:         fromDataPoint.ExplicitBounds().AsRaw(),
+			}
+			prev, ok := dtrp.streams.update(newStreamID(metric, scope, resource, fromDataPoint.Attributes()), current, true)
+			if !ok {
+				continue
+			}
+
+			buckets := make([]uint64, len(current.buckets))
+			for b := range buckets {
+				buckets[b] = current.buckets[b] - prev.buckets[b]
+			}
+			appendBucketRates(newDoubleDataPointSlice, fromDataPoint, buckets, prev.timestamp)
+		}
+		return newDoubleDataPointSlice, nil
+	}
+
+	dataPoints := metric.Sum().DataPoints()
+	for i := 0; i < dataPoints.Len(); i++ {
+		fromDataPoint := dataPoints.At(i)
+		current := streamPoint{
+			startTimestamp: fromDataPoint.StartTimestamp(),
+			timestamp:      fromDataPoint.Timestamp(),
+		}
+		switch fromDataPoint.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			current.value = fromDataPoint.DoubleValue()
+		case pmetric.NumberDataPointValueTypeInt:
+			current.value = float64(fromDataPoint.IntValue())
+		default:
+			return newDoubleDataPointSlice, consumererror.NewPermanent(fmt.Errorf("invalid data point type:%d", fromDataPoint.ValueType()))
+		}
+		if math.IsNaN(current.value) {
+			continue
+		}
+
+		prev, ok := dtrp.streams.update(newStreamID(metric, scope, resource, fromDataPoint.Attributes()), current, metric.Sum().IsMonotonic())
+		if !ok {
+			continue
+		}
+
+		newDp := newDoubleDataPointSlice.AppendEmpty()
+		fromDataPoint.CopyTo(newDp)
+		newDp.SetStartTimestamp(prev.timestamp)
+		newDp.SetDoubleValue(calculateRate(current.value-prev.value, time.Duration(current.timestamp-prev.timestamp)))
+	}
+	return newDoubleDataPointSlice, nil
+}
+
+// validateBuckets checks that the histogram point has a bucket count for each bucket delimited by
+// its explicit bounds, or neither bucket counts nor bounds.
+func validateBuckets(dp pmetric.HistogramDataPoint) error {
+	buckets, bounds := dp.BucketCounts().Len(), dp.ExplicitBounds().Len()
+	if buckets == bounds+1 || (buckets == 0 && bounds == 0) {
+		return nil
+	}
+	return consumererror.NewPermanent(fmt.Errorf("invalid histogram data point: %d bucket counts for %d explicit bounds", buckets, bounds))
+}
+
+// appendBucketRates appends a point for each bucket of the histogram point, holding the rate of its
+// count since the start timestamp. The bounds of the bucket are set as attributes of the point, in
+// addition to the ones of the histogram point. The point must have been validated by validateBuckets.
+func appendBucketRates(dest pmetric.NumberDataPointSlice, fromDataPoint pmetric.HistogramDataPoint, buckets []uint64, startTimestamp pcommon.Timestamp) {
+	bounds := fromDataPoint.ExplicitBounds()
+	durationNanos := time.Duration(fromDataPoint.Timestamp() - startTimestamp)
+	for b, count := range buckets {
+		newDp := dest.AppendEmpty()
+		fromDataPoint.Attributes().CopyTo(newDp.Attributes())
+		lower, upper := math.Inf(-1), math.Inf(1)
+		if b > 0 {
+			lower = bounds.At(b - 1)
+		}
+		if b < bounds.Len() {
+			upper = bounds.At(b)
+		}
+		newDp.Attributes().PutDouble(lowerBoundAttribute, lower)
+		newDp.Attributes().PutDouble(upperBoundAttribute, upper)
+		newDp.SetStartTimestamp(startTimestamp)
+		newDp.SetTimestamp(fromDataPoint.Timestamp())
+		newDp.SetFlags(fromDataPoint.Flags())
+		newDp.SetDoubleValue(calculateRate(float64(count), durationNanos))
+	}
+}
+
 func calculateRate(value float64, durationNanos time.Duration) float64 {
 	duration := durationNanos.Seconds()
 	if duration > 0 {
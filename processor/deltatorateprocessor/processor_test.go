@@ -16,15 +16,21 @@ package deltatorateprocessor
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filtermetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 type testMetric struct {
@@ -191,6 +197,290 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func newTestProcessor(t *testing.T, cfg *Config) *deltaToRateProcessor {
+	if cfg.ErrorMode == "" {
+		cfg.ErrorMode = ottl.PropagateError
+	}
+	require.NoError(t, cfg.Validate())
+	p, err := newDeltaToRateProcessor(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return p
+}
+
+func metricNames(md pmetric.Metrics) map[string]pmetric.MetricType {
+	names := map[string]pmetric.MetricType{}
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		names[ms.At(i).Name()] = ms.At(i).Type()
+	}
+	return names
+}
+
+func TestDeltaToRateSelection(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		cfg      *Config
+		expected map[string]pmetric.MetricType
+	}{
+		{
+			name: "include_exclude",
+			cfg: &Config{
+				Include: &filtermetric.MatchProperties{
+					MatchType:   filtermetric.Regexp,
+					MetricNames: []string{"^metric_.*"},
+				},
+				Exclude: &filtermetric.MatchProperties{
+					MatchType:   filtermetric.Strict,
+					MetricNames: []string{"metric_2"},
+				},
+			},
+			expected: map[string]pmetric.MetricType{
+				"metric_1": pmetric.MetricTypeGauge,
+				"metric_2": pmetric.MetricTypeSum,
+				"other":    pmetric.MetricTypeSum,
+			},
+		},
+		{
+			name: "conditions",
+			cfg: &Config{
+				Conditions: []string{`name == "other"`, `name == "metric_2"`},
+			},
+			expected: map[string]pmetric.MetricType{
+				"metric_1": pmetric.MetricTypeSum,
+				"metric_2": pmetric.MetricTypeGauge,
+				"other":    pmetric.MetricTypeGauge,
+			},
+		},
+		{
+			name: "metrics_and_exclude",
+			cfg: &Config{
+				Metrics: []string{"metric_2"},
+				Exclude: &filtermetric.MatchProperties{
+					MatchType:   filtermetric.Strict,
+					MetricNames: []string{"metric_1", "metric_2"},
+				},
+			},
+			expected: map[string]pmetric.MetricType{
+				"metric_1": pmetric.MetricTypeSum,
+				"metric_2": pmetric.MetricTypeGauge,
+				"other":    pmetric.MetricTypeGauge,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, tt.cfg)
+			md, err := p.processMetrics(context.Background(), generateSumMetrics(testMetric{
+				metricNames:  []string{"metric_1", "metric_2", "other"},
+				metricValues: [][]float64{{120}, {240}, {360}},
+				isDelta:      []bool{true, true, true},
+				deltaSecond:  120,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, metricNames(md))
+		})
+	}
+}
+
+func TestDeltaToRateConditionError(t *testing.T) {
+	md := generateSumMetrics(testMetric{
+		metricNames:  []string{"metric_1"},
+		metricValues: [][]float64{{120}},
+		isDelta:      []bool{true},
+		deltaSecond:  120,
+	})
+
+	p := newTestProcessor(t, &Config{Conditions: []string{`Substring("", 0, 100) == "test"`}})
+	_, err := p.processMetrics(context.Background(), md)
+	assert.Error(t, err)
+
+	p = newTestProcessor(t, &Config{Conditions: []string{`Substring("", 0, 100) == "test"`}, ErrorMode: ottl.IgnoreError})
+	md, err = p.processMetrics(context.Background(), md)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]pmetric.MetricType{"metric_1": pmetric.MetricTypeSum}, metricNames(md))
+}
+
+func appendHistogramPoint(m pmetric.Metric, start, ts pcommon.Timestamp, buckets []uint64) {
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.Attributes().PutStr("method", "GET")
+	dp.ExplicitBounds().FromRaw([]float64{1, 10})
+	dp.BucketCounts().FromRaw(buckets)
+}
+
+func assertBucketRates(t *testing.T, dps pmetric.NumberDataPointSlice, start, ts pcommon.Timestamp, rates []float64) {
+	require.Equal(t, len(rates), dps.Len())
+	bounds := []float64{math.Inf(-1), 1, 10, math.Inf(1)}
+	for i, rate := range rates {
+		dp := dps.At(i)
+		assert.Equal(t, start, dp.StartTimestamp())
+		assert.Equal(t, ts, dp.Timestamp())
+		assert.Equal(t, rate, dp.DoubleValue())
+		assert.Equal(t, map[string]interface{}{
+			"method":            "GET",
+			lowerBoundAttribute: bounds[i],
+			upperBoundAttribute: bounds[i+1],
+		}, dp.Attributes().AsRaw())
+	}
+}
+
+func TestDeltaHistogramToRate(t *testing.T) {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("histogram")
+	m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	start := pcommon.NewTimestampFromTime(time.Unix(100, 0))
+	ts := pcommon.NewTimestampFromTime(time.Unix(110, 0))
+	appendHistogramPoint(m, start, ts, []uint64{10, 20, 0})
+
+	p := newTestProcessor(t, &Config{Metrics: []string{"histogram"}})
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	got := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeGauge, got.Type())
+	assertBucketRates(t, got.Gauge().DataPoints(), start, ts, []float64{1, 2, 0})
+}
+
+func TestCumulativeToRate(t *testing.T) {
+	p := newTestProcessor(t, &Config{Metrics: []string{"sum", "histogram"}, ConvertCumulative: true})
+
+	start := pcommon.NewTimestampFromTime(time.Unix(100, 0))
+	batch := func(ts pcommon.Timestamp, start pcommon.Timestamp, value int64, buckets []uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		sum := ms.AppendEmpty()
+		sum.SetName("sum")
+		sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.Sum().SetIsMonotonic(true)
+		dp := sum.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetIntValue(value)
+
+		hist := ms.AppendEmpty()
+		hist.SetName("histogram")
+		hist.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		appendHistogramPoint(hist, start, ts, buckets)
+		return md
+	}
+	ts := func(sec int64) pcommon.Timestamp {
+		return pcommon.NewTimestampFromTime(time.Unix(sec, 0))
+	}
+
+	// the first points have no rate and are dropped
+	md, err := p.processMetrics(context.Background(), batch(ts(110), start, 100, []uint64{10, 10, 10}))
+	require.NoError(t, err)
+	assert.Zero(t, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+
+	md, err = p.processMetrics(context.Background(), batch(ts(120), start, 150, []uint64{20, 30, 10}))
+	require.NoError(t, err)
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	sumDp := ms.At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, ts(110), sumDp.StartTimestamp())
+	assert.Equal(t, 5.0, sumDp.DoubleValue())
+	assertBucketRates(t, ms.At(1).Gauge().DataPoints(), ts(110), ts(120), []float64{1, 2, 0})
+
+	// the stream restarted, the points are dropped
+	md, err = p.processMetrics(context.Background(), batch(ts(130), ts(125), 10, []uint64{1, 1, 1}))
+	require.NoError(t, err)
+	assert.Zero(t, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+
+	md, err = p.processMetrics(context.Background(), batch(ts(140), ts(125), 30, []uint64{1, 1, 11}))
+	require.NoError(t, err)
+	ms = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	assert.Equal(t, 2.0, ms.At(0).Gauge().DataPoints().At(0).DoubleValue())
+	assertBucketRates(t, ms.At(1).Gauge().DataPoints(), ts(130), ts(140), []float64{0, 0, 1})
+}
+
+func TestHistogramInvalidBuckets(t *testing.T) {
+	testCases := []struct {
+		name        string
+		temporality pmetric.AggregationTemporality
+		buckets     []uint64
+		expectedErr string
+	}{
+		{
+			name:        "delta with too many buckets",
+			temporality: pmetric.AggregationTemporalityDelta,
+			buckets:     []uint64{1, 2, 3, 4},
+			expectedErr: "invalid histogram data point: 4 bucket counts for 2 explicit bounds",
+		},
+		{
+			name:        "delta with too few buckets",
+			temporality: pmetric.AggregationTemporalityDelta,
+			buckets:     []uint64{1, 2},
+			expectedErr: "invalid histogram data point: 2 bucket counts for 2 explicit bounds",
+		},
+		{
+			name:        "cumulative with too many buckets",
+			temporality: pmetric.AggregationTemporalityCumulative,
+			buckets:     []uint64{1, 2, 3, 4},
+			expectedErr: "invalid histogram data point: 4 bucket counts for 2 explicit bounds",
+		},
+		{
+			name:        "cumulative with too few buckets",
+			temporality: pmetric.AggregationTemporalityCumulative,
+			buckets:     []uint64{1},
+			expectedErr: "invalid histogram data point: 1 bucket counts for 2 explicit bounds",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newTestProcessor(t, &Config{Metrics: []string{"histogram"}, ConvertCumulative: true})
+			start := pcommon.NewTimestampFromTime(time.Unix(100, 0))
+
+			// a valid point is sent first, so that the cumulative point gets a rate
+			for i, buckets := range [][]uint64{{1, 1, 1}, tc.buckets} {
+				md := pmetric.NewMetrics()
+				m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+				m.SetName("histogram")
+				m.SetEmptyHistogram().SetAggregationTemporality(tc.temporality)
+				appendHistogramPoint(m, start, pcommon.NewTimestampFromTime(time.Unix(int64(110+10*i), 0)), buckets)
+
+				_, err := p.processMetrics(context.Background(), md)
+				if i == 0 {
+					require.NoError(t, err)
+					continue
+				}
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.True(t, consumererror.IsPermanent(err))
+			}
+		})
+	}
+}
+
+func TestCumulativeNotConvertedByDefault(t *testing.T) {
+	p := newTestProcessor(t, &Config{Metrics: []string{"metric_1"}})
+	md, err := p.processMetrics(context.Background(), generateSumMetrics(testMetric{
+		metricNames:  []string{"metric_1"},
+		metricValues: [][]float64{{120}},
+		isDelta:      []bool{false},
+		deltaSecond:  120,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]pmetric.MetricType{"metric_1": pmetric.MetricTypeSum}, metricNames(md))
+}
+
+func TestStreamTrackerRemoveStale(t *testing.T) {
+	tracker := newStreamTracker(time.Minute)
+	tracker.update("stale", streamPoint{timestamp: 1}, true)
+	tracker.update("fresh", streamPoint{timestamp: 1}, true)
+	tracker.states["stale"].lastSeen = time.Now().Add(-2 * time.Minute)
+
+	// the states are swept at most once per max staleness
+	tracker.removeStale(time.Now())
+	assert.Len(t, tracker.states, 2)
+
+	tracker.lastSweep = time.Now().Add(-time.Minute)
+	tracker.removeStale(time.Now())
+	assert.Len(t, tracker.states, 1)
+	assert.Contains(t, tracker.states, "fresh")
+}
+
 func generateSumMetrics(tm testMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatorateprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor"
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const streamIDSeparator = byte(0x1E)
+
+// streamPoint holds the values of a point of a cumulative stream needed to compute the rate of the next point.
+type streamPoint struct {
+	startTimestamp pcommon.Timestamp
+	timestamp      pcommon.Timestamp
+	value          float64
+	buckets        []uint64
+	bounds         []float64
+}
+
+type streamState struct {
+	point    streamPoint
+	lastSeen time.Time
+}
+
+// streamTracker keeps the previous point of each cumulative stream.
+type streamTracker struct {
+	sync.Mutex
+	maxStaleness time.Duration
+	lastSweep    time.Time
+	states       map[string]*streamState
+}
+
+func newStreamTracker(maxStaleness time.Duration) *streamTracker {
+	return &streamTracker{
+		maxStaleness: maxStaleness,
+		lastSweep:    time.Now(),
+		states:       make(map[string]*streamState),
+	}
+}
+
+// newStreamID identifies the stream of a point by its resource, scope, metric and attributes.
+func newStreamID(metric pmetric.Metric, scope pcommon.InstrumentationScope, resource pcommon.Resource, attributes pcommon.Map) string {
+	var b bytes.Buffer
+	b.WriteString(strconv.Itoa(int(metric.Type())))
+	b.WriteByte(streamIDSeparator)
+	resourceHash := pdatautil.MapHash(resource.Attributes())
+	b.Write(resourceHash[:])
+	b.WriteByte(streamIDSeparator)
+	b.WriteString(scope.Name())
+	b.WriteByte(streamIDSeparator)
+	b.WriteString(scope.Version())
+	b.WriteByte(streamIDSeparator)
+	b.WriteString(metric.Name())
+	b.WriteByte(streamIDSeparator)
+	b.WriteString(metric.Unit())
+	b.WriteByte(streamIDSeparator)
+	attrsHash := pdatautil.MapHash(attributes)
+	b.Write(attrsHash[:])
+	return b.String()
+}
+
+// update stores the point as the latest one of its stream, and returns the previous one. It returns
+// false if the point has no previous point to compute a rate from: it's the first point of the stream,
+// the stream was reset, or the point is older than the previous one.
+func (t *streamTracker) update(id string, point streamPoint, monotonic bool) (streamPoint, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	state, ok := t.states[id]
+	if !ok {
+		t.states[id] = &streamState{point: point, lastSeen: time.Now()}
+		return streamPoint{}, false
+	}
+	prev := state.point
+	if point.timestamp <= prev.timestamp {
+		// out of order point, keep the previous one
+		return streamPoint{}, false
+	}
+
+	state.point = point
+	state.lastSeen = time.Now()
+	if isReset(prev, point, monotonic) {
+		return streamPoint{}, false
+	}
+	return prev, true
+}
+
+// isReset tells whether the cumulative stream restarted between the previous point and the current one.
+func isReset(prev, point streamPoint, monotonic bool) bool {
+	if point.startTimestamp != prev.startTimestamp {
+		return true
+	}
+	if monotonic && point.value < prev.value {
+		return true
+	}
+	if len(point.buckets) != len(prev.buckets) || len(point.bounds) != len(prev.bounds) {
+		return true
+	}
+	for i := range point.bounds {
+		if point.bounds[i] != prev.bounds[i] {
+			return true
+		}
+	}
+	for i := range point.buckets {
+		if point.buckets[i] < prev.buckets[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// removeStale removes the streams not seen for longer than the max staleness, at most once per max staleness.
+func (t *streamTracker) removeStale(now time.Time) {
+	if t.maxStaleness <= 0 {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if now.Sub(t.lastSweep) < t.maxStaleness {
+		return
+	}
+	t.lastSweep = now
+	for id, state := range t.states {
+		if now.Sub(state.lastSeen) > t.maxStaleness {
+			delete(t.states, id)
+		}
+	}
+}
@@ -15,9 +15,14 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
+var errMissingStorage = errors.New("option 'storage' is required when 'store_on_disk' is set")
+
 // Config is the configuration for the processor.
 type Config struct {
 
@@ -41,7 +46,24 @@ type Config struct {
 
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
 	// Useful when the duration to wait for traces to complete is high.
+	// The in-flight traces are resumed when the collector restarts.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of the storage extension keeping the trace spans when StoreOnDisk is set.
+	// Required when StoreOnDisk is set.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// MaxInMemoryBytes is the maximum size of the serialized trace spans buffered in memory
+	// before being written to the storage extension when StoreOnDisk is set.
+	// Default: 0, writing the trace spans as soon as they are received.
+	MaxInMemoryBytes int `mapstructure:"max_in_memory_bytes"`
+}
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StoreOnDisk && cfg.Storage == nil {
+		return errMissingStorage
+	}
+	return nil
 }
@@ -93,6 +93,7 @@ type eventMachine struct {
 	// shutdown sync
 	shutdownLock *sync.RWMutex
 	closed       bool
+	workersDone  sync.WaitGroup
 }
 
 func newEventMachine(logger *zap.Logger, bufferSize int, numWorkers int, numTraces int) *eventMachine {
@@ -146,7 +147,11 @@ func (em *eventMachine) periodicMetrics() {
 
 func (em *eventMachine) startWorkers() {
 	for _, worker := range em.workers {
-		go worker.start()
+		em.workersDone.Add(1)
+		go func(worker *eventMachineWorker) {
+			defer em.workersDone.Done()
+			worker.start()
+		}(worker)
 	}
 }
 
@@ -230,18 +235,22 @@ func (em *eventMachine) consume(td ptrace.Traces) error {
 		return fmt.Errorf("eventmachine consume failed: %w", err)
 	}
 
+	em.workerFor(traceID).fire(event{
+		typ:     traceReceived,
+		payload: tracesWithID{id: traceID, td: td},
+	})
+	return nil
+}
+
+// workerFor returns the worker in charge of the given trace.
+func (em *eventMachine) workerFor(traceID pcommon.TraceID) *eventMachineWorker {
 	var bucket uint64
 	if len(em.workers) != 1 {
 		bucket = workerIndexForTraceID(traceID, len(em.workers))
 	}
 
 	em.logger.Debug("scheduled trace to worker", zap.Uint64("id", bucket))
-
-	em.workers[bucket].fire(event{
-		typ:     traceReceived,
-		payload: tracesWithID{id: traceID, td: td},
-	})
-	return nil
+	return em.workers[bucket]
 }
 
 func workerIndexForTraceID(traceID pcommon.TraceID, numWorkers int) uint64 {
@@ -285,6 +294,7 @@ func (em *eventMachine) shutdown() {
 		em.logger.Info("forcing the shutdown of the event manager", zap.Int("pending-events", em.numEvents()))
 	}
 	close(em.close)
+	em.workersDone.Wait()
 }
 
 func (em *eventMachine) callOnError(e event) {
@@ -19,7 +19,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestDefaultConfiguration(t *testing.T) {
@@ -47,6 +50,30 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	storageID := storagetest.NewStorageID("disk")
+	c.StoreOnDisk = true
+	c.Storage = &storageID
+	require.NoError(t, c.Validate())
+
+	next := &mockProcessor{}
+
+	// test
+	p, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), c, next)
+
+	// verify
+	require.NoError(t, err)
+	assert.IsType(t, &diskStorage{}, p.(*groupByTraceProcessor).st)
+}
+
+func TestValidateConfigWithoutStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.StoreOnDisk = true
+
+	assert.ErrorIs(t, c.Validate(), errMissingStorage)
+}
+
 func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 	// prepare
 	f := NewFactory()
@@ -67,7 +94,7 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 			&Config{
 				StoreOnDisk: true,
 			},
-			errDiskStorageNotSupported,
+			errMissingStorage,
 		},
 	} {
 		p, err := f.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), tt.config, next)
@@ -94,20 +94,62 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	if pst, ok := sp.st.(persistentStorage); ok {
+		traceIDs, err := pst.loadTraceIDs(ctx)
+		if err != nil {
+			return fmt.Errorf("couldn't load the in-flight traces from the storage: %w", err)
+		}
+		sp.resumeTraces(traceIDs)
+	}
+
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (sp *groupByTraceProcessor) Shutdown(_ context.Context) error {
+func (sp *groupByTraceProcessor) Shutdown(ctx context.Context) error {
 	sp.eventMachine.shutdown()
-	return sp.st.shutdown()
+	return sp.st.shutdown(ctx)
+}
+
+// resumeTraces places the traces left in the storage by a previous run back in
+// the ring buffers of the workers, scheduling their release after the wait
+// duration. These include the traces whose release was in progress when the
+// previous run stopped. It must be called before the event machine is started.
+func (sp *groupByTraceProcessor) resumeTraces(traceIDs []pcommon.TraceID) {
+	sp.logger.Debug("resuming in-flight traces", zap.Int("num-traces", len(traceIDs)))
+
+	for _, traceID := range traceIDs {
+		traceID := traceID
+		worker := sp.eventMachine.workerFor(traceID)
+
+		evicted := worker.buffer.put(traceID)
+		if !evicted.IsEmpty() {
+			// the buffers hold fewer traces than during the previous run
+			if err := sp.onTraceRemoved(evicted); err != nil {
+				sp.logger.Warn("couldn't remove evicted trace", zap.Stringer("traceID", evicted), zap.Error(err))
+			}
+			stats.Record(context.Background(), mTracesEvicted.M(1))
+		}
+
+		time.AfterFunc(sp.config.WaitDuration, func() {
+			// if the event machine has stopped, it will just discard the event
+			worker.fire(event{
+				typ:     traceExpired,
+				payload: traceID,
+			})
+		})
+	}
 }
 
 func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eventMachineWorker) error {
@@ -15,6 +15,9 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -36,8 +39,18 @@ type storage interface {
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(context.Context, component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
-	shutdown() error
+	shutdown(context.Context) error
+}
+
+// persistentStorage is a storage whose traces survive restarts, expected or not,
+// so that they can be resumed
+type persistentStorage interface {
+	storage
+
+	// loadTraceIDs returns the IDs of the traces found in the storage when the
+	// processor starts, from the oldest to the newest
+	loadTraceIDs(context.Context) ([]pcommon.TraceID, error)
 }
@@ -0,0 +1,456 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	extensionstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// indexSegmentSize is the number of trace IDs per index segment, so that
+	// listing a new trace rewrites a small value
+	indexSegmentSize = 128
+	traceIDSize      = len(pcommon.TraceID{})
+)
+
+var (
+	errInvalidStoredTrace = errors.New("invalid trace found in the storage")
+	errInvalidIndex       = errors.New("invalid index of the traces found in the storage")
+)
+
+// diskStorage keeps the spans of the in-flight traces in a storage extension,
+// so that only their trace IDs need to be held in memory. The most recently
+// received spans are buffered in memory, up to maxInMemoryBytes of serialized
+// spans, before being written to the storage extension.
+//
+// Each batch of spans written to the storage extension gets its own key, and
+// the number of batches of a trace is kept under the trace key. As the
+// storage extension can't list its keys, the traces are also listed by an
+// index, split in segments of indexSegmentSize trace IDs, which is written
+// along with the first batch of each trace: after a restart, even an
+// unexpected one, all the traces left in the storage extension can be found.
+type diskStorage struct {
+	sync.Mutex
+	storageID   component.ID
+	componentID component.ID
+	client      extensionstorage.Client
+
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	maxInMemoryBytes int
+	inMemoryBytes    int
+	// buffered holds the batches of spans not written to the storage yet,
+	// and order the IDs of their traces, from the least recently buffered
+	buffered map[pcommon.TraceID]*bufferedTrace
+	order    *list.List
+
+	// stored holds the number of batches of each trace written to the storage
+	// extension, and index the trace IDs listed by the index segments, which
+	// still include the traces deleted since the index was last compacted
+	stored map[pcommon.TraceID]int
+	index  []pcommon.TraceID
+}
+
+// bufferedTrace holds the serialized batches of a trace buffered in memory.
+type bufferedTrace struct {
+	batches [][]byte
+	size    int
+	element *list.Element
+}
+
+var _ storage = (*diskStorage)(nil)
+var _ persistentStorage = (*diskStorage)(nil)
+
+func newDiskStorage(storageID, componentID component.ID, maxInMemoryBytes int) *diskStorage {
+	return &diskStorage{
+		storageID:        storageID,
+		componentID:      componentID,
+		maxInMemoryBytes: maxInMemoryBytes,
+		buffered:         make(map[pcommon.TraceID]*bufferedTrace),
+		order:            list.New(),
+		stored:           make(map[pcommon.TraceID]int),
+	}
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (extensionstorage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(extensionstorage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+func traceKey(traceID pcommon.TraceID) string {
+	return "trace_" + traceID.String()
+}
+
+func batchKey(traceID pcommon.TraceID, batch int) string {
+	return fmt.Sprintf("trace_%s_%d", traceID, batch)
+}
+
+func indexKey(segment int) string {
+	return fmt.Sprintf("index_%d", segment)
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	batch, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	if len(batch) > st.maxInMemoryBytes {
+		// the batch doesn't fit in memory, write it right away along with the
+		// batches of the trace buffered before it
+		var batches [][]byte
+		if bt, ok := st.buffered[traceID]; ok {
+			batches = bt.batches
+			st.unbuffer(traceID, bt)
+		}
+		return st.write(traceID, append(batches, batch))
+	}
+
+	bt, ok := st.buffered[traceID]
+	if !ok {
+		bt = &bufferedTrace{element: st.order.PushBack(traceID)}
+		st.buffered[traceID] = bt
+	}
+	bt.batches = append(bt.batches, batch)
+	bt.size += len(batch)
+	st.inMemoryBytes += len(batch)
+
+	// write the least recently buffered traces until the buffer fits in memory
+	for st.inMemoryBytes > st.maxInMemoryBytes {
+		if err := st.flushOldest(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	batches, err := st.load(traceID)
+	st.Unlock()
+	if err != nil || batches == nil {
+		return nil, err
+	}
+	return st.unmarshal(batches)
+}
+
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	batches, err := st.load(traceID)
+	if err != nil || batches == nil {
+		st.Unlock()
+		return nil, err
+	}
+	if bt, ok := st.buffered[traceID]; ok {
+		st.unbuffer(traceID, bt)
+	}
+	err = st.remove(traceID)
+	st.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return st.unmarshal(batches)
+}
+
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	client, err := getStorageClient(ctx, host, st.storageID, st.componentID)
+	if err != nil {
+		return err
+	}
+	st.client = client
+	return nil
+}
+
+// shutdown writes the buffered spans to the storage extension, so that they
+// are available after a restart, and closes the storage client.
+func (st *diskStorage) shutdown(ctx context.Context) error {
+	if st.client == nil {
+		return nil
+	}
+
+	st.Lock()
+	defer st.Unlock()
+	for st.order.Len() > 0 {
+		if err := st.flushOldest(); err != nil {
+			return err
+		}
+	}
+	return st.client.Close(ctx)
+}
+
+// loadTraceIDs returns the IDs of the traces found in the storage extension,
+// in the order they were first written, and compacts the index so that it
+// only lists them.
+func (st *diskStorage) loadTraceIDs(ctx context.Context) ([]pcommon.TraceID, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	var index []pcommon.TraceID
+	for segment := 0; ; segment++ {
+		value, err := st.client.Get(ctx, indexKey(segment))
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			break
+		}
+		if len(value)%traceIDSize != 0 {
+			return nil, fmt.Errorf("%w: segment %d has %d bytes", errInvalidIndex, segment, len(value))
+		}
+		for i := 0; i < len(value); i += traceIDSize {
+			var traceID pcommon.TraceID
+			copy(traceID[:], value[i:])
+			index = append(index, traceID)
+		}
+	}
+
+	ops := make([]extensionstorage.Operation, len(index))
+	for i, traceID := range index {
+		ops[i] = extensionstorage.GetOperation(traceKey(traceID))
+	}
+	if len(ops) > 0 {
+		if err := st.client.Batch(ctx, ops...); err != nil {
+			return nil, err
+		}
+	}
+
+	var traceIDs []pcommon.TraceID
+	for i, traceID := range index {
+		if ops[i].Value == nil {
+			// deleted since the index was last compacted
+			continue
+		}
+		if _, ok := st.stored[traceID]; ok {
+			// listed again after being deleted and received again
+			continue
+		}
+		batches, n := binary.Uvarint(ops[i].Value)
+		if n <= 0 {
+			return nil, errInvalidStoredTrace
+		}
+		st.stored[traceID] = int(batches)
+		traceIDs = append(traceIDs, traceID)
+	}
+
+	st.index = index
+	if err := st.compactIndex(ctx); err != nil {
+		return nil, err
+	}
+	return traceIDs, nil
+}
+
+// load returns the batches of the trace, written to the storage extension or
+// buffered in memory, or nil if the trace can't be found. It must be called
+// with the lock held.
+func (st *diskStorage) load(traceID pcommon.TraceID) ([][]byte, error) {
+	var batches [][]byte
+	if count := st.stored[traceID]; count > 0 {
+		ops := make([]extensionstorage.Operation, count)
+		for i := range ops {
+			ops[i] = extensionstorage.GetOperation(batchKey(traceID, i))
+		}
+		if err := st.client.Batch(context.Background(), ops...); err != nil {
+			return nil, err
+		}
+		for _, op := range ops {
+			if op.Value == nil {
+				return nil, errInvalidStoredTrace
+			}
+			decoded, err := decodeBatches(op.Value)
+			if err != nil {
+				return nil, err
+			}
+			batches = append(batches, decoded...)
+		}
+	}
+
+	if bt, ok := st.buffered[traceID]; ok {
+		batches = append(batches, bt.batches...)
+	}
+	return batches, nil
+}
+
+// write stores the batches under a new key of the trace in the storage
+// extension, listing the trace in the index if they are its first ones. It
+// must be called with the lock held.
+func (st *diskStorage) write(traceID pcommon.TraceID, batches [][]byte) error {
+	count, stored := st.stored[traceID]
+	ops := []extensionstorage.Operation{
+		extensionstorage.SetOperation(batchKey(traceID, count), encodeBatches(nil, batches)),
+		extensionstorage.SetOperation(traceKey(traceID), binary.AppendUvarint(nil, uint64(count+1))),
+	}
+
+	index := st.index
+	if !stored {
+		index = append(index, traceID)
+		segment := (len(index) - 1) / indexSegmentSize
+		ops = append(ops, extensionstorage.SetOperation(indexKey(segment), encodeIndexSegment(index, segment)))
+	}
+
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return err
+	}
+	st.stored[traceID] = count + 1
+	st.index = index
+	return nil
+}
+
+// remove deletes the keys of the trace from the storage extension, and
+// compacts the index once it mostly lists deleted traces. It must be called
+// with the lock held.
+func (st *diskStorage) remove(traceID pcommon.TraceID) error {
+	count, ok := st.stored[traceID]
+	if !ok {
+		return nil
+	}
+
+	ops := make([]extensionstorage.Operation, 0, count+1)
+	ops = append(ops, extensionstorage.DeleteOperation(traceKey(traceID)))
+	for i := 0; i < count; i++ {
+		ops = append(ops, extensionstorage.DeleteOperation(batchKey(traceID, i)))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return err
+	}
+	delete(st.stored, traceID)
+
+	if len(st.index) >= 2*len(st.stored)+indexSegmentSize {
+		return st.compactIndex(context.Background())
+	}
+	return nil
+}
+
+// compactIndex rewrites the index so that it only lists the traces in the
+// storage extension. It must be called with the lock held.
+func (st *diskStorage) compactIndex(ctx context.Context) error {
+	index := make([]pcommon.TraceID, 0, len(st.stored))
+	listed := make(map[pcommon.TraceID]struct{}, len(st.stored))
+	for _, traceID := range st.index {
+		if _, ok := st.stored[traceID]; !ok {
+			continue
+		}
+		if _, ok := listed[traceID]; ok {
+			continue
+		}
+		listed[traceID] = struct{}{}
+		index = append(index, traceID)
+	}
+
+	segments := (len(index) + indexSegmentSize - 1) / indexSegmentSize
+	previousSegments := (len(st.index) + indexSegmentSize - 1) / indexSegmentSize
+	var ops []extensionstorage.Operation
+	for segment := 0; segment < segments; segment++ {
+		ops = append(ops, extensionstorage.SetOperation(indexKey(segment), encodeIndexSegment(index, segment)))
+	}
+	for segment := segments; segment < previousSegments; segment++ {
+		ops = append(ops, extensionstorage.DeleteOperation(indexKey(segment)))
+	}
+	if len(ops) > 0 {
+		if err := st.client.Batch(ctx, ops...); err != nil {
+			return err
+		}
+	}
+	st.index = index
+	return nil
+}
+
+// flushOldest writes the least recently buffered trace to the storage
+// extension. It must be called with the lock held.
+func (st *diskStorage) flushOldest() error {
+	traceID := st.order.Front().Value.(pcommon.TraceID)
+	bt := st.buffered[traceID]
+	st.unbuffer(traceID, bt)
+	return st.write(traceID, bt.batches)
+}
+
+func (st *diskStorage) unbuffer(traceID pcommon.TraceID, bt *bufferedTrace) {
+	st.order.Remove(bt.element)
+	delete(st.buffered, traceID)
+	st.inMemoryBytes -= bt.size
+}
+
+func (st *diskStorage) unmarshal(batches [][]byte) ([]ptrace.ResourceSpans, error) {
+	var result []ptrace.ResourceSpans
+	for _, batch := range batches {
+		td, err := st.unmarshaler.UnmarshalTraces(batch)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			result = append(result, td.ResourceSpans().At(i))
+		}
+	}
+	return result, nil
+}
+
+// encodeIndexSegment returns the value of the given segment of the index.
+func encodeIndexSegment(index []pcommon.TraceID, segment int) []byte {
+	end := (segment + 1) * indexSegmentSize
+	if end > len(index) {
+		end = len(index)
+	}
+	value := make([]byte, 0, (end-segment*indexSegmentSize)*traceIDSize)
+	for _, traceID := range index[segment*indexSegmentSize : end] {
+		value = append(value, traceID[:]...)
+	}
+	return value
+}
+
+// encodeBatches appends the batches to dst, each batch being prefixed by its length.
+func encodeBatches(dst []byte, batches [][]byte) []byte {
+	for _, batch := range batches {
+		dst = binary.AppendUvarint(dst, uint64(len(batch)))
+		dst = append(dst, batch...)
+	}
+	return dst
+}
+
+// decodeBatches splits a value written by encodeBatches into its batches.
+func decodeBatches(value []byte) ([][]byte, error) {
+	var batches [][]byte
+	for len(value) > 0 {
+		size, n := binary.Uvarint(value)
+		if n <= 0 || uint64(len(value)-n) < size {
+			return nil, errInvalidStoredTrace
+		}
+		value = value[n:]
+		batches = append(batches, value[:size])
+		value = value[size:]
+	}
+	return batches, nil
+}
@@ -0,0 +1,409 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+var testComponentID = component.NewID(metadata.Type)
+
+func newStartedDiskStorage(t *testing.T, host component.Host, maxInMemoryBytes int) *diskStorage {
+	st := newDiskStorage(storagetest.NewStorageID("disk"), testComponentID, maxInMemoryBytes)
+	require.NoError(t, st.start(context.Background(), host))
+	return st
+}
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("disk"), 0)
+	defer func() {
+		assert.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("second")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{first.ResourceSpans().At(0), second.ResourceSpans().At(0)}, retrieved)
+	assert.Empty(t, st.buffered)
+
+	retrieved, err = st.get(pcommon.TraceID([16]byte{2, 3, 4, 5}))
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("disk"), 0)
+	defer func() {
+		assert.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+	require.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	deleted, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestDiskBuffersInMemory(t *testing.T) {
+	// prepare
+	first := pcommon.TraceID([16]byte{1})
+	second := pcommon.TraceID([16]byte{2})
+	batch, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(simpleTracesWithID(first))
+	require.NoError(t, err)
+
+	// the buffer fits two batches
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("disk"), 2*len(batch))
+	defer func() {
+		assert.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	// test
+	require.NoError(t, st.createOrAppend(first, simpleTracesWithID(first)))
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+
+	// verify
+	assert.Len(t, st.buffered, 2)
+	assert.Equal(t, 2*len(batch), st.inMemoryBytes)
+	value, err := st.client.Get(context.Background(), traceKey(first))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// the least recently buffered trace is written to make room for the new batch
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+	assert.Len(t, st.buffered, 1)
+	assert.Contains(t, st.buffered, second)
+	value, err = st.client.Get(context.Background(), traceKey(first))
+	require.NoError(t, err)
+	assert.NotNil(t, value)
+
+	retrieved, err := st.get(second)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 2)
+
+	deleted, err := st.delete(second)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 2)
+	assert.Empty(t, st.buffered)
+	assert.Zero(t, st.inMemoryBytes)
+}
+
+func TestDiskShutdownWritesBufferedTraces(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("disk", storageDir), 1024)
+	require.NoError(t, st.createOrAppend(traceID, trace))
+	assert.Len(t, st.buffered, 1)
+
+	// test
+	require.NoError(t, st.shutdown(context.Background()))
+
+	// verify
+	st = newStartedDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("disk", storageDir), 1024)
+	defer func() {
+		assert.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	traceIDs, err := st.loadTraceIDs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []pcommon.TraceID{traceID}, traceIDs)
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, retrieved)
+}
+
+func TestDiskInvalidStoredTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("disk"), 0)
+	defer func() {
+		assert.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.client.Set(context.Background(), batchKey(traceID, 0), []byte{10, 1}))
+	require.NoError(t, st.client.Set(context.Background(), indexKey(0), []byte{1, 2, 3}))
+
+	// test
+	_, getErr := st.get(traceID)
+	_, loadErr := st.loadTraceIDs(context.Background())
+
+	// verify
+	assert.ErrorIs(t, getErr, errInvalidStoredTrace)
+	assert.ErrorIs(t, loadErr, errInvalidIndex)
+}
+
+func TestDiskWritesEachBatchUnderItsOwnKey(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("disk"), 0)
+	defer func() {
+		assert.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(simpleTracesWithID(traceID))
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// verify
+	value, err := st.client.Get(context.Background(), batchKey(traceID, 0))
+	require.NoError(t, err)
+	assert.Equal(t, encodeBatches(nil, [][]byte{first}), value, "the first batch must not be rewritten")
+	value, err = st.client.Get(context.Background(), batchKey(traceID, 1))
+	require.NoError(t, err)
+	assert.NotNil(t, value)
+	value, err = st.client.Get(context.Background(), traceKey(traceID))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, value)
+	value, err = st.client.Get(context.Background(), indexKey(0))
+	require.NoError(t, err)
+	assert.Equal(t, traceID[:], value, "the trace must be listed once")
+
+	_, err = st.delete(traceID)
+	require.NoError(t, err)
+	for _, key := range []string{traceKey(traceID), batchKey(traceID, 0), batchKey(traceID, 1)} {
+		value, err = st.client.Get(context.Background(), key)
+		require.NoError(t, err)
+		assert.Nil(t, value, key)
+	}
+}
+
+func TestDiskTracesFoundAfterCrash(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	released := pcommon.TraceID([16]byte{1})
+	inFlight := pcommon.TraceID([16]byte{2})
+	trace := simpleTracesWithID(inFlight)
+
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("disk", storageDir), 0)
+	require.NoError(t, st.createOrAppend(released, simpleTracesWithID(released)))
+	require.NoError(t, st.createOrAppend(inFlight, trace))
+	_, err := st.delete(released)
+	require.NoError(t, err)
+
+	// test
+	// the client is closed without the storage being shut down
+	require.NoError(t, st.client.Close(context.Background()))
+	st = newStartedDiskStorage(t, storagetest.NewStorageHost().WithFileBackedStorageExtension("disk", storageDir), 0)
+	defer func() {
+		assert.NoError(t, st.shutdown(context.Background()))
+	}()
+	traceIDs, err := st.loadTraceIDs(context.Background())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []pcommon.TraceID{inFlight}, traceIDs)
+
+	retrieved, err := st.get(inFlight)
+	require.NoError(t, err)
+	assert.Equal(t, []ptrace.ResourceSpans{trace.ResourceSpans().At(0)}, retrieved)
+
+	value, err := st.client.Get(context.Background(), indexKey(0))
+	require.NoError(t, err)
+	assert.Equal(t, inFlight[:], value, "the index must be compacted")
+}
+
+func TestDiskIndexCompactedAfterDeletes(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("disk"), 0)
+	defer func() {
+		assert.NoError(t, st.shutdown(context.Background()))
+	}()
+
+	var traceIDs []pcommon.TraceID
+	for i := 0; i < 2*indexSegmentSize; i++ {
+		traceID := pcommon.TraceID([16]byte{byte(i), byte(i >> 8), 1})
+		traceIDs = append(traceIDs, traceID)
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+	require.Len(t, st.index, 2*indexSegmentSize)
+
+	// test
+	// the index is compacted once it lists twice the stored traces, plus a segment
+	deleted := 2*indexSegmentSize - indexSegmentSize/2
+	for _, traceID := range traceIDs[:deleted] {
+		_, err := st.delete(traceID)
+		require.NoError(t, err)
+	}
+
+	// verify
+	assert.Equal(t, traceIDs[deleted:], st.index)
+	value, err := st.client.Get(context.Background(), indexKey(0))
+	require.NoError(t, err)
+	assert.Len(t, value, (2*indexSegmentSize-deleted)*traceIDSize)
+	value, err = st.client.Get(context.Background(), indexKey(1))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestDiskStorageExtensionNotFound(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		host component.Host
+	}{
+		{
+			name: "missing",
+			host: storagetest.NewStorageHost(),
+		},
+		{
+			name: "not a storage extension",
+			host: storagetest.NewStorageHost().WithNonStorageExtension("disk"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newDiskStorage(storagetest.NewStorageID("disk"), testComponentID, 0)
+			assert.Error(t, st.start(context.Background(), tt.host))
+			assert.NoError(t, st.shutdown(context.Background()))
+		})
+	}
+}
+
+func TestInFlightTracesResumedAfterRestart(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("disk")
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	traces := simpleTracesWithID(traceID)
+
+	newProcessor := func(config Config, next *mockProcessor) *groupByTraceProcessor {
+		st := newDiskStorage(storageID, testComponentID, 0)
+		p := newGroupByTraceProcessor(zap.NewNop(), st, next, config)
+		require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost().WithFileBackedStorageExtension("disk", storageDir)))
+		return p
+	}
+
+	// the trace is received, but the processor is stopped before releasing it
+	p := newProcessor(Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   2,
+	}, &mockProcessor{
+		onTraces: func(context.Context, ptrace.Traces) error {
+			t.Error("the trace should not be released before the restart")
+			return nil
+		},
+	})
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// test
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	p = newProcessor(Config{
+		WaitDuration: time.Millisecond,
+		NumTraces:    10,
+		NumWorkers:   2,
+	}, &mockProcessor{
+		onTraces: func(_ context.Context, received ptrace.Traces) error {
+			assert.Equal(t, traces, received)
+			wg.Done()
+			return nil
+		},
+	})
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	wg.Wait()
+}
+
+func TestReleasingTracesResumedAfterRestart(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("disk")
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	traces := simpleTracesWithID(traceID)
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+	}
+
+	st := newDiskStorage(storageID, testComponentID, 0)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, config)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost().WithFileBackedStorageExtension("disk", storageDir)))
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+	require.Eventually(t, func() bool {
+		retrieved, err := st.get(traceID)
+		return err == nil && retrieved != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// the trace has expired, but the processor is stopped before its release completes
+	require.NoError(t, p.Shutdown(context.Background()))
+	p.eventMachine.workers[0].buffer.delete(traceID)
+
+	// test
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	config.WaitDuration = time.Millisecond
+	p = newGroupByTraceProcessor(zap.NewNop(), newDiskStorage(storageID, testComponentID, 0), &mockProcessor{
+		onTraces: func(_ context.Context, received ptrace.Traces) error {
+			assert.Equal(t, traces, received)
+			wg.Done()
+			return nil
+		},
+	}, config)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost().WithFileBackedStorageExtension("disk", storageDir)))
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	wg.Wait()
+}
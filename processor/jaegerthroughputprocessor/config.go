@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/jaegerthroughputprocessor"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errMissingExtension = errors.New("the jaegerremotesampling extension recording the throughput has to be specified")
+
+// Config defines the configuration for the jaegerthroughput processor.
+type Config struct {
+	// Extension is the jaegerremotesampling extension the throughput is recorded in.
+	Extension component.ID `mapstructure:"extension"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Extension.Type() == "" {
+		return errMissingExtension
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/jaegerthroughputprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+)
+
+type throughputProcessor struct {
+	extension component.ID
+	recorder  jaegerremotesampling.ThroughputRecorder
+}
+
+func newThroughputProcessor(cfg *Config) *throughputProcessor {
+	return &throughputProcessor{
+		extension: cfg.Extension,
+	}
+}
+
+func (p *throughputProcessor) start(_ context.Context, host component.Host) error {
+	extension, ok := host.GetExtensions()[p.extension]
+	if !ok {
+		return fmt.Errorf("extension '%s' not found", p.extension)
+	}
+
+	recorder, ok := extension.(jaegerremotesampling.ThroughputRecorder)
+	if !ok {
+		return fmt.Errorf("extension '%s' does not record the span throughput", p.extension)
+	}
+
+	p.recorder = recorder
+	return nil
+}
+
+// processTraces records the throughput of the root spans and passes the traces through unchanged.
+func (p *throughputProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	p.recorder.RecordThroughput(td)
+	return td, nil
+}
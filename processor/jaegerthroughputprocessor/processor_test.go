@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerthroughputprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+type testRecorder struct {
+	component.StartFunc
+	component.ShutdownFunc
+	recorded []ptrace.Traces
+}
+
+func (r *testRecorder) RecordThroughput(td ptrace.Traces) {
+	r.recorded = append(r.recorded, td)
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestProcessTraces(t *testing.T) {
+	recorder := &testRecorder{}
+	host := testHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{component.NewID("jaegerremotesampling"): recorder},
+	}
+
+	sink := &consumertest.TracesSink{}
+	factory := NewFactory()
+	tp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), factory.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), host))
+	defer func() { assert.NoError(t, tp.Shutdown(context.Background())) }()
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("op")
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	assert.Equal(t, []ptrace.Traces{td}, recorder.recorded)
+	assert.Equal(t, []ptrace.Traces{td}, sink.AllTraces())
+}
+
+func TestStartWithoutRecorder(t *testing.T) {
+	id := component.NewID("jaegerremotesampling")
+	for _, tt := range []struct {
+		name       string
+		extensions map[component.ID]component.Component
+		expected   string
+	}{
+		{
+			name:     "not found",
+			expected: "extension 'jaegerremotesampling' not found",
+		},
+		{
+			name:       "not a recorder",
+			extensions: map[component.ID]component.Component{id: &nopExtension{}},
+			expected:   "extension 'jaegerremotesampling' does not record the span throughput",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newThroughputProcessor(&Config{Extension: id})
+			err := p.start(context.Background(), testHost{Host: componenttest.NewNopHost(), extensions: tt.extensions})
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
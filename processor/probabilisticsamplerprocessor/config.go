@@ -22,6 +22,9 @@ import (
 
 type AttributeSource string
 
+// SamplerMode determines how the sampling decision is made for traces.
+type SamplerMode string
+
 const (
 	traceIDAttributeSource = AttributeSource("traceID")
 	recordAttributeSource  = AttributeSource("record")
@@ -34,6 +37,18 @@ var validAttributeSource = map[AttributeSource]bool{
 	recordAttributeSource:  true,
 }
 
+const (
+	hashSeedMode              = SamplerMode("hash_seed")
+	consistentProbabilityMode = SamplerMode("consistent_probability")
+
+	defaultMode = hashSeedMode
+)
+
+var validModes = map[SamplerMode]bool{
+	hashSeedMode:              true,
+	consistentProbabilityMode: true,
+}
+
 // Config has the configuration guiding the sampler processor.
 type Config struct {
 
@@ -46,6 +61,11 @@ type Config struct {
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
 
+	// Mode (traces only) defines how the sampling decision is made. The allowed values are `hash_seed`, which hashes
+	// the trace ID with the HashSeed, and `consistent_probability`, which implements the OpenTelemetry consistent
+	// probability sampling based on the `ot` entry of the W3C tracestate. Default is `hash_seed`.
+	Mode SamplerMode `mapstructure:"mode"`
+
 	// AttributeSource (logs only) defines where to look for the attribute in from_attribute. The allowed values are
 	// `traceID` or `record`. Default is `traceID`.
 	AttributeSource `mapstructure:"attribute_source"`
@@ -66,6 +86,12 @@ func (cfg *Config) Validate() error {
 	if cfg.SamplingPercentage < 0 {
 		return fmt.Errorf("negative sampling rate: %.2f", cfg.SamplingPercentage)
 	}
+	if cfg.Mode != "" && !validModes[cfg.Mode] {
+		return fmt.Errorf("invalid mode: %v. Expected: %v or %v", cfg.Mode, hashSeedMode, consistentProbabilityMode)
+	}
+	if cfg.Mode == consistentProbabilityMode && cfg.HashSeed != 0 {
+		return fmt.Errorf("hash_seed can't be used with the %v mode", consistentProbabilityMode)
+	}
 	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
 		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
 	}
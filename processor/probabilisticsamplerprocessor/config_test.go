@@ -36,6 +36,15 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
+				Mode:               "hash_seed",
+				AttributeSource:    "traceID",
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "consistent"),
+			expected: &Config{
+				SamplingPercentage: 12.5,
+				Mode:               "consistent_probability",
 				AttributeSource:    "traceID",
 			},
 		},
@@ -44,6 +53,7 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
+				Mode:               "hash_seed",
 				AttributeSource:    "record",
 				FromAttribute:      "foo",
 				SamplingPriority:   "bar",
@@ -81,3 +91,35 @@ func TestLoadInvalidConfig(t *testing.T) {
 	_, err = otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "invalid.yaml"), factories)
 	require.ErrorContains(t, err, "negative sampling rate: -15.30")
 }
+
+func TestValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		cfg  *Config
+		err  string
+	}{
+		{
+			desc: "consistent probability",
+			cfg:  &Config{SamplingPercentage: 10, Mode: consistentProbabilityMode},
+		},
+		{
+			desc: "invalid mode",
+			cfg:  &Config{SamplingPercentage: 10, Mode: "random"},
+			err:  "invalid mode: random. Expected: hash_seed or consistent_probability",
+		},
+		{
+			desc: "hash seed with consistent probability",
+			cfg:  &Config{SamplingPercentage: 10, HashSeed: 22, Mode: consistentProbabilityMode},
+			err:  "hash_seed can't be used with the consistent_probability mode",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
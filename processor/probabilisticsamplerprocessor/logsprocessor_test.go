@@ -57,6 +57,15 @@ func TestNewLogsProcessor(t *testing.T) {
 				HashSeed:           4321,
 			},
 		},
+		{
+			name:         "consistent_probability",
+			nextConsumer: consumertest.NewNop(),
+			cfg: &Config{
+				SamplingPercentage: 12.5,
+				Mode:               consistentProbabilityMode,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -16,6 +16,7 @@ package probabilisticsamplerprocessor // import "github.com/open-telemetry/opent
 
 import (
 	"context"
+	"math"
 	"strconv"
 
 	"go.opencensus.io/stats"
@@ -26,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 // samplingPriority has the semantic result of parsing the "sampling.priority"
@@ -55,7 +58,9 @@ const (
 type traceSamplerProcessor struct {
 	scaledSamplingRate uint32
 	hashSeed           uint32
-	logger             *zap.Logger
+	// consistentSampler is only set in the consistent_probability mode.
+	consistentSampler *sampling.ConsistentSampler
+	logger            *zap.Logger
 }
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform head sampling according to the given
@@ -68,6 +73,15 @@ func newTracesProcessor(ctx context.Context, set processor.CreateSettings, cfg *
 		logger:             set.Logger,
 	}
 
+	if cfg.Mode == consistentProbabilityMode {
+		probability := math.Min(float64(cfg.SamplingPercentage)/100, 1)
+		cs, err := sampling.NewConsistentSampler(probability)
+		if err != nil {
+			return nil, err
+		}
+		tsp.consistentSampler = cs
+	}
+
 	return processorhelper.NewTracesProcessor(
 		ctx,
 		set,
@@ -100,6 +114,10 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 					statCountTracesSampled.M(int64(1)),
 				)
 
+				if tsp.consistentSampler != nil {
+					return !tsp.sampleConsistently(ctx, s, sp)
+				}
+
 				// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
 				// with various different criteria to generate trace id and perhaps were already sampled without hashing.
 				// Hashing here prevents bias due to such systems.
@@ -126,6 +144,35 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 	return td, nil
 }
 
+// sampleConsistently makes the sampling decision for the span based on the r-value and p-value of
+// its W3C tracestate, which is updated with the new values when the span is sampled, so that later
+// samplers agree with this decision and the adjusted count of the span can be derived from its p-value.
+// Spans that must be sampled because of their "sampling.priority" are kept even when they aren't
+// sampled based on their probability, in which case their adjusted count is set to zero.
+func (tsp *traceSamplerProcessor) sampleConsistently(ctx context.Context, s ptrace.Span, sp samplingPriority) bool {
+	ts, err := sampling.ParseW3CTraceState(s.TraceState().AsRaw())
+	if err != nil {
+		tsp.logger.Debug("Invalid tracestate, ignoring the invalid values", zap.Error(err))
+	}
+
+	sampled := tsp.consistentSampler.Decide(s.TraceID(), ts.OTel())
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(tagPolicyKey, "consistent_probability"), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
+		statCountTracesSampled.M(int64(1)),
+	)
+
+	if !sampled && sp == mustSampleSpan {
+		_ = ts.OTel().SetPValue(sampling.MaxPValue)
+		sampled = true
+	}
+
+	if sampled {
+		s.TraceState().FromRaw(ts.String())
+	}
+	return sampled
+}
+
 // parseSpanSamplingPriority checks if the span has the "sampling.priority" tag to
 // decide if the span should be sampled or not. The usage of the tag follows the
 // OpenTracing semantic tags:
@@ -18,6 +18,7 @@ import (
 	"context"
 	"math"
 	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,6 +31,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/idutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 func TestNewTracesProcessor(t *testing.T) {
@@ -61,6 +63,14 @@ func TestNewTracesProcessor(t *testing.T) {
 				HashSeed:           4321,
 			},
 		},
+		{
+			name:         "happy_path_consistent_probability",
+			nextConsumer: consumertest.NewNop(),
+			cfg: &Config{
+				SamplingPercentage: 13.33,
+				Mode:               consistentProbabilityMode,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -130,6 +140,36 @@ func Test_tracesamplerprocessor_SamplingPercentageRange(t *testing.T) {
 			numTracesPerBatch: 1,
 			acceptableDelta:   0.0,
 		},
+		{
+			name: "consistent_sampling_small",
+			cfg: &Config{
+				SamplingPercentage: 5,
+				Mode:               consistentProbabilityMode,
+			},
+			numBatches:        1e5,
+			numTracesPerBatch: 2,
+			acceptableDelta:   0.2,
+		},
+		{
+			name: "consistent_sampling_medium",
+			cfg: &Config{
+				SamplingPercentage: 50.0,
+				Mode:               consistentProbabilityMode,
+			},
+			numBatches:        1e5,
+			numTracesPerBatch: 4,
+			acceptableDelta:   0.2,
+		},
+		{
+			name: "consistent_sampling_all",
+			cfg: &Config{
+				SamplingPercentage: 100.0,
+				Mode:               consistentProbabilityMode,
+			},
+			numBatches:        1e5,
+			numTracesPerBatch: 1,
+			acceptableDelta:   0.0,
+		},
 	}
 	const testSvcName = "test-svc"
 	for _, tt := range tests {
@@ -321,6 +361,89 @@ func Test_tracesamplerprocessor_SpanSamplingPriority(t *testing.T) {
 	}
 }
 
+// Test_tracesamplerprocessor_ConsistentProbability checks that the tracestate is used and updated in the
+// consistent_probability mode.
+func Test_tracesamplerprocessor_ConsistentProbability(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage float32
+		traceState string
+		priority   string
+		sampled    bool
+		expected   string
+	}{
+		{
+			name:       "sampled_with_lower_probability",
+			percentage: 50,
+			traceState: "vendor=value,ot=p:0;r:1",
+			sampled:    true,
+			expected:   "ot=p:1;r:1,vendor=value",
+		},
+		{
+			name:       "not_sampled",
+			percentage: 25,
+			traceState: "ot=p:0;r:1",
+		},
+		{
+			name:       "upstream_decision_is_kept",
+			percentage: 50,
+			traceState: "ot=p:3;r:3",
+			sampled:    true,
+			expected:   "ot=p:3;r:3",
+		},
+		{
+			name:       "invalid_values_are_replaced",
+			percentage: 100,
+			traceState: "ot=p:0;r:100",
+			sampled:    true,
+			expected:   "ot=p:0;r:" + strconv.Itoa(sampling.RValueFromTraceID(pcommon.TraceID{1, 2, 3})),
+		},
+		{
+			name:       "must_sample_has_zero_adjusted_count",
+			percentage: 0,
+			traceState: "ot=r:1",
+			priority:   "1",
+			sampled:    true,
+			expected:   "ot=p:63;r:1",
+		},
+		{
+			name:       "must_not_sample",
+			percentage: 100,
+			traceState: "ot=r:1",
+			priority:   "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			cfg := &Config{
+				SamplingPercentage: tt.percentage,
+				Mode:               consistentProbabilityMode,
+			}
+			tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+
+			td := ptrace.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(pcommon.TraceID{1, 2, 3})
+			span.TraceState().FromRaw(tt.traceState)
+			if tt.priority != "" {
+				span.Attributes().PutStr("sampling.priority", tt.priority)
+			}
+
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+
+			if !tt.sampled {
+				assert.Equal(t, 0, sink.SpanCount())
+				return
+			}
+			require.Equal(t, 1, sink.SpanCount())
+			sampledSpan := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expected, sampledSpan.TraceState().AsRaw())
+		})
+	}
+}
+
 // Test_parseSpanSamplingPriority ensures that the function parsing the attributes is taking "sampling.priority"
 // attribute correctly.
 func Test_parseSpanSamplingPriority(t *testing.T) {
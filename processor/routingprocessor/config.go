@@ -65,6 +65,13 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// RoutingMode determines what happens when the data is matched by the OTTL statements or conditions of several routes.
+	// Valid values are `fan_out` and `first_match`.
+	// `fan_out` means the data is routed to the exporters of all the matching routes.
+	// `first_match` means the data is routed to the exporters of the first matching route only, in the order of the table.
+	// The default value is `fan_out`.
+	RoutingMode RoutingMode `mapstructure:"routing_mode"`
+
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
@@ -77,11 +84,15 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid routing table: %w", errNoTableItems)
 	}
 
+	if c.RoutingMode != "" && !validRoutingModes[c.RoutingMode] {
+		return fmt.Errorf("invalid routing mode: %v. Expected: %v or %v", c.RoutingMode, fanOutRoutingMode, firstMatchRoutingMode)
+	}
+
 	ottlRoutingOnly := true
 	// validate that every route has a value for the routing attribute and has
 	// at least one exporter
 	for _, item := range c.Table {
-		if len(item.Value) == 0 && len(item.Statement) == 0 {
+		if len(item.Value) == 0 && len(item.Statement) == 0 && len(item.Condition) == 0 {
 			return fmt.Errorf("invalid (empty) route : %w", errEmptyRoute)
 		}
 
@@ -89,6 +100,19 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("invalid route: both statement (%s) and value (%s) provided", item.Statement, item.Value)
 		}
 
+		if len(item.Condition) != 0 && (len(item.Value) != 0 || len(item.Statement) != 0) {
+			return fmt.Errorf("invalid route: condition (%s) can't be combined with a statement or a value", item.Condition)
+		}
+
+		if item.Context != "" {
+			if len(item.Condition) == 0 {
+				return fmt.Errorf("invalid route: context (%s) provided without a condition", item.Context)
+			}
+			if !validRouteContexts[item.Context] {
+				return fmt.Errorf("invalid route: unsupported context %s. Expected: %v, %v or %v", item.Context, resourceRouteContext, logRouteContext, dataPointRouteContext)
+			}
+		}
+
 		if len(item.Exporters) == 0 {
 			return fmt.Errorf("invalid route %s: %w", item.Value, errNoExporters)
 		}
@@ -125,6 +149,38 @@ const (
 	defaultAttributeSource = contextAttributeSource
 )
 
+// RoutingMode determines how the data matched by several routes is routed.
+type RoutingMode string
+
+const (
+	fanOutRoutingMode     = RoutingMode("fan_out")
+	firstMatchRoutingMode = RoutingMode("first_match")
+
+	defaultRoutingMode = fanOutRoutingMode
+)
+
+var validRoutingModes = map[RoutingMode]bool{
+	fanOutRoutingMode:     true,
+	firstMatchRoutingMode: true,
+}
+
+// RouteContext is the OTTL context in which the condition of a route is evaluated.
+type RouteContext string
+
+const (
+	resourceRouteContext  = RouteContext("resource")
+	logRouteContext       = RouteContext("log")
+	dataPointRouteContext = RouteContext("datapoint")
+
+	defaultRouteContext = resourceRouteContext
+)
+
+var validRouteContexts = map[RouteContext]bool{
+	resourceRouteContext:  true,
+	logRouteContext:       true,
+	dataPointRouteContext: true,
+}
+
 // RoutingTableItem specifies how data should be routed to the different exporters
 type RoutingTableItem struct {
 	// Value represents a possible value for the field specified under FromAttribute.
@@ -132,9 +188,22 @@ type RoutingTableItem struct {
 	Value string `mapstructure:"value"`
 
 	// Statement is a OTTL statement used for making a routing decision.
-	// Required when 'Value' isn't provided.
+	// Required when neither 'Value' nor 'Condition' are provided.
 	Statement string `mapstructure:"statement"`
 
+	// Condition is a OTTL condition used for making a routing decision, evaluated in the context set by 'Context'.
+	// Required when neither 'Value' nor 'Statement' are provided.
+	Condition string `mapstructure:"condition"`
+
+	// Context is the OTTL context in which 'Condition' is evaluated. The allowed values are:
+	// - "resource" - the condition is evaluated once per resource, which is routed as a whole
+	// - "log" - the condition is evaluated for each log record (logs only)
+	// - "datapoint" - the condition is evaluated for each data point (metrics only)
+	// With the "log" and "datapoint" contexts, the items of the same resource can be routed to different exporters.
+	// The default value is "resource".
+	// Optional.
+	Context RouteContext `mapstructure:"context"`
+
 	// Exporters contains the list of exporters to use when the value from the FromAttribute field matches this table item.
 	// When no exporters are specified, the ones specified under DefaultExporters are used, if any.
 	// The routing processor will fail upon the first failure from these exporters.
@@ -149,7 +218,7 @@ func rewriteRoutingEntriesToOTTL(cfg *Config) *Config {
 	}
 	table := make([]RoutingTableItem, 0, len(cfg.Table))
 	for _, e := range cfg.Table {
-		if e.Statement != "" {
+		if e.Statement != "" || e.Condition != "" {
 			table = append(table, e)
 			continue
 		}
@@ -178,6 +247,8 @@ func rewriteRoutingEntriesToOTTL(cfg *Config) *Config {
 	}
 	return &Config{
 		DefaultExporters: cfg.DefaultExporters,
+		ErrorMode:        cfg.ErrorMode,
+		RoutingMode:      cfg.RoutingMode,
 		Table:            table,
 	}
 }
@@ -40,6 +40,7 @@ func TestLoadConfig(t *testing.T) {
 				AttributeSource:  "context",
 				FromAttribute:    "X-Tenant",
 				ErrorMode:        ottl.PropagateError,
+				RoutingMode:      fanOutRoutingMode,
 				Table: []RoutingTableItem{
 					{
 						Value:     "acme",
@@ -60,6 +61,7 @@ func TestLoadConfig(t *testing.T) {
 				AttributeSource:  "context",
 				FromAttribute:    "X-Custom-Metrics-Header",
 				ErrorMode:        ottl.PropagateError,
+				RoutingMode:      fanOutRoutingMode,
 				Table: []RoutingTableItem{
 					{
 						Value:     "acme",
@@ -80,6 +82,7 @@ func TestLoadConfig(t *testing.T) {
 				AttributeSource:  "context",
 				FromAttribute:    "X-Custom-Logs-Header",
 				ErrorMode:        ottl.PropagateError,
+				RoutingMode:      fanOutRoutingMode,
 				Table: []RoutingTableItem{
 					{
 						Value:     "acme",
@@ -100,6 +103,7 @@ func TestLoadConfig(t *testing.T) {
 				AttributeSource:  resourceAttributeSource,
 				FromAttribute:    "X-Tenant",
 				ErrorMode:        ottl.IgnoreError,
+				RoutingMode:      fanOutRoutingMode,
 				Table: []RoutingTableItem{
 					{
 						Value:     "acme",
@@ -114,6 +118,7 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				DefaultExporters: []string{"jaeger"},
 				ErrorMode:        ottl.PropagateError,
+				RoutingMode:      fanOutRoutingMode,
 				Table: []RoutingTableItem{
 					{
 						Statement: "route() where resource.attributes[\"X-Tenant\"] == \"acme\"",
@@ -126,6 +131,26 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configPath: "config_conditions.yaml",
+			id:         component.NewIDWithName(typeStr, ""),
+			expected: &Config{
+				DefaultExporters: []string{"otlp"},
+				ErrorMode:        ottl.PropagateError,
+				RoutingMode:      firstMatchRoutingMode,
+				Table: []RoutingTableItem{
+					{
+						Condition: "severity_number >= SEVERITY_NUMBER_ERROR",
+						Context:   logRouteContext,
+						Exporters: []string{"otlp/errors"},
+					},
+					{
+						Condition: "resource.attributes[\"X-Tenant\"] == \"acme\"",
+						Exporters: []string{"otlp/acme"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range testcases {
@@ -195,6 +220,58 @@ func TestValidateConfig(t *testing.T) {
 			},
 			error: "using a different attribute source than 'attribute' and drop_resource_routing_attribute is set to true",
 		},
+		{
+			name: "both condition and statement specified",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Exporters: []string{"otlp"},
+						Statement: `route() where resource.attributes["attr"] == "acme"`,
+						Condition: `resource.attributes["attr"] == "acme"`,
+					},
+				},
+			},
+			error: "invalid route: condition (resource.attributes[\"attr\"] == \"acme\") can't be combined with a statement or a value",
+		},
+		{
+			name: "context without condition",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Exporters: []string{"otlp"},
+						Statement: `route() where resource.attributes["attr"] == "acme"`,
+						Context:   logRouteContext,
+					},
+				},
+			},
+			error: "invalid route: context (log) provided without a condition",
+		},
+		{
+			name: "unsupported context",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Exporters: []string{"otlp"},
+						Condition: `attributes["attr"] == "acme"`,
+						Context:   "span",
+					},
+				},
+			},
+			error: "invalid route: unsupported context span. Expected: resource, log or datapoint",
+		},
+		{
+			name: "invalid routing mode",
+			config: &Config{
+				RoutingMode: "all",
+				Table: []RoutingTableItem{
+					{
+						Exporters: []string{"otlp"},
+						Condition: `resource.attributes["attr"] == "acme"`,
+					},
+				},
+			},
+			error: "invalid routing mode: all. Expected: fan_out or first_match",
+		},
 	}
 
 	for _, tt := range tests {
@@ -47,6 +47,7 @@ func createDefaultConfig() component.Config {
 	return &Config{
 		AttributeSource: defaultAttributeSource,
 		ErrorMode:       ottl.PropagateError,
+		RoutingMode:     defaultRoutingMode,
 	}
 }
 
@@ -26,7 +26,6 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/common"
 )
@@ -50,8 +49,8 @@ func newLogProcessor(settings component.TelemetrySettings, config component.Conf
 		logger: settings.Logger,
 		config: cfg,
 		router: newRouter[exporter.Logs, ottllog.TransformContext](
-			cfg.Table,
-			cfg.DefaultExporters,
+			cfg,
+			logRouteContext,
 			settings,
 			logParser,
 		),
@@ -103,26 +102,25 @@ func (p *logProcessor) route(ctx context.Context, l plog.Logs) error {
 			rlogs.Resource(),
 		)
 
-		matchCount := len(p.router.routes)
-		for key, route := range p.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, ltx)
-			if err != nil {
-				if p.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				p.group("", groups, p.router.defaultExporters, rlogs)
-				continue
-			}
-			if !isMatch {
-				matchCount--
-				continue
+		res, err := p.router.matchResource(ctx, ltx)
+		if err != nil {
+			return err
+		}
+
+		if p.router.hasItemRoutes {
+			// the log records of the resource may be routed to different exporters
+			if err = p.routeLogRecords(ctx, groups, rlogs, res); err != nil {
+				return err
 			}
-			p.group(key, groups, route.exporters, rlogs)
+			continue
 		}
 
-		if matchCount == 0 {
-			// no route conditions are matched, add resource logs to default exporters group
-			p.group("", groups, p.router.defaultExporters, rlogs)
+		keys, err := p.router.matchItem(ctx, ltx, res)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			p.group(key, groups, p.router.routeExporters(key), rlogs)
 		}
 	}
 	for _, g := range groups {
@@ -133,19 +131,73 @@ func (p *logProcessor) route(ctx context.Context, l plog.Logs) error {
 	return errs
 }
 
+// routeLogRecords evaluates the routes for each log record of the resource
+// logs and copies the log records to the groups of the matching routes.
+func (p *logProcessor) routeLogRecords(
+	ctx context.Context,
+	groups map[string]logsGroup,
+	rlogs plog.ResourceLogs,
+	res resourceMatch,
+) error {
+	// the copies of the resource logs and scope logs within each group, created
+	// only when a log record is routed to the group
+	resources := map[string]plog.ResourceLogs{}
+	for i := 0; i < rlogs.ScopeLogs().Len(); i++ {
+		slogs := rlogs.ScopeLogs().At(i)
+		scopes := map[string]plog.ScopeLogs{}
+		for j := 0; j < slogs.LogRecords().Len(); j++ {
+			lr := slogs.LogRecords().At(j)
+			ltx := ottllog.NewTransformContext(lr, slogs.Scope(), rlogs.Resource())
+			keys, err := p.router.matchItem(ctx, ltx, res)
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				dest, ok := scopes[key]
+				if !ok {
+					rl, ok := resources[key]
+					if !ok {
+						group := p.groupFor(key, groups, p.router.routeExporters(key))
+						rl = group.logs.ResourceLogs().AppendEmpty()
+						rlogs.Resource().CopyTo(rl.Resource())
+						rl.SetSchemaUrl(rlogs.SchemaUrl())
+						resources[key] = rl
+					}
+					dest = rl.ScopeLogs().AppendEmpty()
+					slogs.Scope().CopyTo(dest.Scope())
+					dest.SetSchemaUrl(slogs.SchemaUrl())
+					scopes[key] = dest
+				}
+				lr.CopyTo(dest.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	return nil
+}
+
 func (p *logProcessor) group(
 	key string,
 	groups map[string]logsGroup,
 	exporters []exporter.Logs,
 	spans plog.ResourceLogs,
 ) {
+	group := p.groupFor(key, groups, exporters)
+	spans.CopyTo(group.logs.ResourceLogs().AppendEmpty())
+}
+
+// groupFor returns the group for the given key, creating it if needed.
+func (p *logProcessor) groupFor(
+	key string,
+	groups map[string]logsGroup,
+	exporters []exporter.Logs,
+) logsGroup {
 	group, ok := groups[key]
 	if !ok {
 		group.logs = plog.NewLogs()
 		group.exporters = exporters
+		groups[key] = group
 	}
-	spans.CopyTo(group.logs.ResourceLogs().AppendEmpty())
-	groups[key] = group
+	return group
 }
 
 func (p *logProcessor) routeForContext(ctx context.Context, l plog.Logs) error {
@@ -371,6 +371,164 @@ func TestLogsAreCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
 	})
 }
 
+func TestLogsAreSplitPerLogRecordWithConditions(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	errorsExp := &mockLogsExporter{}
+	acmeExp := &mockLogsExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {
+			component.NewID("otlp"):                   defaultExp,
+			component.NewIDWithName("otlp", "errors"): errorsExp,
+			component.NewIDWithName("otlp", "acme"):   acmeExp,
+		},
+	})
+
+	newLogs := func() plog.Logs {
+		l := plog.NewLogs()
+		rl := l.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("X-Tenant", "acme")
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName("scope")
+		sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberInfo)
+		sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberError)
+
+		rl = l.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("X-Tenant", "globex")
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberInfo)
+		return l
+	}
+
+	table := []RoutingTableItem{
+		{
+			Condition: `severity_number >= SEVERITY_NUMBER_ERROR`,
+			Context:   logRouteContext,
+			Exporters: []string{"otlp/errors"},
+		},
+		{
+			Condition: `resource.attributes["X-Tenant"] == "acme"`,
+			Exporters: []string{"otlp/acme"},
+		},
+	}
+
+	t.Run("fan out", func(t *testing.T) {
+		defaultExp.Reset()
+		errorsExp.Reset()
+		acmeExp.Reset()
+
+		exp := newLogProcessor(component.TelemetrySettings{Logger: zap.NewNop()}, &Config{
+			DefaultExporters: []string{"otlp"},
+			RoutingMode:      fanOutRoutingMode,
+			Table:            table,
+		})
+		require.NoError(t, exp.Start(context.Background(), host))
+		require.NoError(t, exp.ConsumeLogs(context.Background(), newLogs()))
+
+		require.Len(t, errorsExp.AllLogs(), 1)
+		assert.Equal(t, 1, errorsExp.AllLogs()[0].LogRecordCount())
+		rl := errorsExp.AllLogs()[0].ResourceLogs().At(0)
+		tenant, _ := rl.Resource().Attributes().Get("X-Tenant")
+		assert.Equal(t, "acme", tenant.Str())
+		assert.Equal(t, "scope", rl.ScopeLogs().At(0).Scope().Name())
+		assert.Equal(t, plog.SeverityNumberError, rl.ScopeLogs().At(0).LogRecords().At(0).SeverityNumber())
+
+		require.Len(t, acmeExp.AllLogs(), 1)
+		assert.Equal(t, 2, acmeExp.AllLogs()[0].LogRecordCount())
+
+		require.Len(t, defaultExp.AllLogs(), 1)
+		assert.Equal(t, 1, defaultExp.AllLogs()[0].LogRecordCount())
+		tenant, _ = defaultExp.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("X-Tenant")
+		assert.Equal(t, "globex", tenant.Str())
+	})
+
+	t.Run("first match", func(t *testing.T) {
+		defaultExp.Reset()
+		errorsExp.Reset()
+		acmeExp.Reset()
+
+		exp := newLogProcessor(component.TelemetrySettings{Logger: zap.NewNop()}, &Config{
+			DefaultExporters: []string{"otlp"},
+			RoutingMode:      firstMatchRoutingMode,
+			Table:            table,
+		})
+		require.NoError(t, exp.Start(context.Background(), host))
+		require.NoError(t, exp.ConsumeLogs(context.Background(), newLogs()))
+
+		require.Len(t, errorsExp.AllLogs(), 1)
+		assert.Equal(t, 1, errorsExp.AllLogs()[0].LogRecordCount())
+
+		require.Len(t, acmeExp.AllLogs(), 1)
+		assert.Equal(t, 1, acmeExp.AllLogs()[0].LogRecordCount())
+		lr := acmeExp.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+
+		require.Len(t, defaultExp.AllLogs(), 1)
+		assert.Equal(t, 1, defaultExp.AllLogs()[0].LogRecordCount())
+	})
+}
+
+func TestLogsFirstMatchWithResourceConditions(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	firstExp := &mockLogsExporter{}
+	secondExp := &mockLogsExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {
+			component.NewID("otlp"):              defaultExp,
+			component.NewIDWithName("otlp", "1"): firstExp,
+			component.NewIDWithName("otlp", "2"): secondExp,
+		},
+	})
+
+	exp := newLogProcessor(component.TelemetrySettings{Logger: zap.NewNop()}, &Config{
+		DefaultExporters: []string{"otlp"},
+		RoutingMode:      firstMatchRoutingMode,
+		Table: []RoutingTableItem{
+			{
+				Condition: `IsMatch(resource.attributes["X-Tenant"], ".*acme")`,
+				Exporters: []string{"otlp/1"},
+			},
+			{
+				Condition: `IsMatch(resource.attributes["X-Tenant"], "_acme")`,
+				Exporters: []string{"otlp/2"},
+			},
+		},
+	})
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "_acme")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	assert.Len(t, defaultExp.AllLogs(), 0)
+	assert.Len(t, firstExp.AllLogs(), 1)
+	assert.Len(t, secondExp.AllLogs(), 0)
+}
+
+func TestLogsFailToStartWithUnsupportedRouteContext(t *testing.T) {
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {
+			component.NewID("otlp"): &mockLogsExporter{},
+		},
+	})
+
+	exp := newLogProcessor(component.TelemetrySettings{Logger: zap.NewNop()}, &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Condition: `attributes["attr"] == "acme"`,
+				Context:   dataPointRouteContext,
+				Exporters: []string{"otlp"},
+			},
+		},
+	})
+	assert.EqualError(t, exp.Start(context.Background(), host),
+		`the context "datapoint" of the route with condition "attributes[\"attr\"] == \"acme\"" isn't supported for this pipeline type`)
+}
+
 type mockLogsExporter struct {
 	mockComponent
 	consumertest.LogsSink
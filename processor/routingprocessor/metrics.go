@@ -26,7 +26,6 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/common"
 )
@@ -50,8 +49,8 @@ func newMetricProcessor(settings component.TelemetrySettings, config component.C
 		logger: settings.Logger,
 		config: cfg,
 		router: newRouter[exporter.Metrics](
-			cfg.Table,
-			cfg.DefaultExporters,
+			cfg,
+			dataPointRouteContext,
 			settings,
 			dataPointParser,
 		),
@@ -105,26 +104,25 @@ func (p *metricsProcessor) route(ctx context.Context, tm pmetric.Metrics) error
 			rmetrics.Resource(),
 		)
 
-		matchCount := len(p.router.routes)
-		for key, route := range p.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, mtx)
-			if err != nil {
-				if p.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				p.group("", groups, p.router.defaultExporters, rmetrics)
-				continue
-			}
-			if !isMatch {
-				matchCount--
-				continue
+		res, err := p.router.matchResource(ctx, mtx)
+		if err != nil {
+			return err
+		}
+
+		if p.router.hasItemRoutes {
+			// the data points of the resource may be routed to different exporters
+			if err = p.routeDataPoints(ctx, groups, rmetrics, res); err != nil {
+				return err
 			}
-			p.group(key, groups, route.exporters, rmetrics)
+			continue
 		}
 
-		if matchCount == 0 {
-			// no route conditions are matched, add resource metrics to default exporters group
-			p.group("", groups, p.router.defaultExporters, rmetrics)
+		keys, err := p.router.matchItem(ctx, mtx, res)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			p.group(key, groups, p.router.routeExporters(key), rmetrics)
 		}
 	}
 
@@ -136,19 +134,145 @@ func (p *metricsProcessor) route(ctx context.Context, tm pmetric.Metrics) error
 	return errs
 }
 
+// routeDataPoints evaluates the routes for each data point of the resource
+// metrics and copies the data points to the groups of the matching routes.
+func (p *metricsProcessor) routeDataPoints(
+	ctx context.Context,
+	groups map[string]metricsGroup,
+	rmetrics pmetric.ResourceMetrics,
+	res resourceMatch,
+) error {
+	// the copies of the resource metrics, scope metrics and metrics within
+	// each group, created only when a data point is routed to the group
+	resources := map[string]pmetric.ResourceMetrics{}
+	for i := 0; i < rmetrics.ScopeMetrics().Len(); i++ {
+		smetrics := rmetrics.ScopeMetrics().At(i)
+		scopes := map[string]pmetric.ScopeMetrics{}
+		for j := 0; j < smetrics.Metrics().Len(); j++ {
+			metric := smetrics.Metrics().At(j)
+			metrics := map[string]pmetric.Metric{}
+			// destination returns the copy of the metric within the group
+			// of the given key.
+			destination := func(key string) pmetric.Metric {
+				if dest, ok := metrics[key]; ok {
+					return dest
+				}
+				sm, ok := scopes[key]
+				if !ok {
+					rm, ok := resources[key]
+					if !ok {
+						group := p.groupFor(key, groups, p.router.routeExporters(key))
+						rm = group.metrics.ResourceMetrics().AppendEmpty()
+						rmetrics.Resource().CopyTo(rm.Resource())
+						rm.SetSchemaUrl(rmetrics.SchemaUrl())
+						resources[key] = rm
+					}
+					sm = rm.ScopeMetrics().AppendEmpty()
+					smetrics.Scope().CopyTo(sm.Scope())
+					sm.SetSchemaUrl(smetrics.SchemaUrl())
+					scopes[key] = sm
+				}
+				dest := sm.Metrics().AppendEmpty()
+				copyMetricMetadata(metric, dest)
+				metrics[key] = dest
+				return dest
+			}
+			route := func(dp any, copyTo func(dest pmetric.Metric)) error {
+				mtx := ottldatapoint.NewTransformContext(dp, metric, smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource())
+				keys, err := p.router.matchItem(ctx, mtx, res)
+				if err != nil {
+					return err
+				}
+				for _, key := range keys {
+					copyTo(destination(key))
+				}
+				return nil
+			}
+
+			var err error
+			switch metric.Type() {
+			case pmetric.MetricTypeGauge:
+				dps := metric.Gauge().DataPoints()
+				for k := 0; k < dps.Len() && err == nil; k++ {
+					dp := dps.At(k)
+					err = route(dp, func(dest pmetric.Metric) { dp.CopyTo(dest.Gauge().DataPoints().AppendEmpty()) })
+				}
+			case pmetric.MetricTypeSum:
+				dps := metric.Sum().DataPoints()
+				for k := 0; k < dps.Len() && err == nil; k++ {
+					dp := dps.At(k)
+					err = route(dp, func(dest pmetric.Metric) { dp.CopyTo(dest.Sum().DataPoints().AppendEmpty()) })
+				}
+			case pmetric.MetricTypeHistogram:
+				dps := metric.Histogram().DataPoints()
+				for k := 0; k < dps.Len() && err == nil; k++ {
+					dp := dps.At(k)
+					err = route(dp, func(dest pmetric.Metric) { dp.CopyTo(dest.Histogram().DataPoints().AppendEmpty()) })
+				}
+			case pmetric.MetricTypeExponentialHistogram:
+				dps := metric.ExponentialHistogram().DataPoints()
+				for k := 0; k < dps.Len() && err == nil; k++ {
+					dp := dps.At(k)
+					err = route(dp, func(dest pmetric.Metric) { dp.CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty()) })
+				}
+			case pmetric.MetricTypeSummary:
+				dps := metric.Summary().DataPoints()
+				for k := 0; k < dps.Len() && err == nil; k++ {
+					dp := dps.At(k)
+					err = route(dp, func(dest pmetric.Metric) { dp.CopyTo(dest.Summary().DataPoints().AppendEmpty()) })
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyMetricMetadata copies the metric, without its data points, to dest.
+func copyMetricMetadata(metric pmetric.Metric, dest pmetric.Metric) {
+	dest.SetName(metric.Name())
+	dest.SetDescription(metric.Description())
+	dest.SetUnit(metric.Unit())
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		dest.SetEmptySum().SetAggregationTemporality(metric.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(metric.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(metric.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(metric.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dest.SetEmptySummary()
+	}
+}
+
 func (p *metricsProcessor) group(
 	key string,
 	groups map[string]metricsGroup,
 	exporters []exporter.Metrics,
 	metrics pmetric.ResourceMetrics,
 ) {
+	group := p.groupFor(key, groups, exporters)
+	metrics.CopyTo(group.metrics.ResourceMetrics().AppendEmpty())
+}
+
+// groupFor returns the group for the given key, creating it if needed.
+func (p *metricsProcessor) groupFor(
+	key string,
+	groups map[string]metricsGroup,
+	exporters []exporter.Metrics,
+) metricsGroup {
 	group, ok := groups[key]
 	if !ok {
 		group.metrics = pmetric.NewMetrics()
 		group.exporters = exporters
+		groups[key] = group
 	}
-	metrics.CopyTo(group.metrics.ResourceMetrics().AppendEmpty())
-	groups[key] = group
+	return group
 }
 
 func (p *metricsProcessor) routeForContext(ctx context.Context, m pmetric.Metrics) error {
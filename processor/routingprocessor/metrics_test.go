@@ -258,6 +258,77 @@ func TestMetrics_RoutingWorks_ResourceAttribute_DropsRoutingAttribute(t *testing
 	assert.Equal(t, "acme", v.Str())
 }
 
+func TestMetricsAreSplitPerDataPointWithConditions(t *testing.T) {
+	defaultExp := &mockMetricsExporter{}
+	euExp := &mockMetricsExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeMetrics: {
+			component.NewID("otlp"):               defaultExp,
+			component.NewIDWithName("otlp", "eu"): euExp,
+		},
+	})
+
+	exp := newMetricProcessor(component.TelemetrySettings{Logger: zap.NewNop()}, &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Condition: `attributes["region"] == "eu"`,
+				Context:   dataPointRouteContext,
+				Exporters: []string{"otlp/eu"},
+			},
+		},
+	})
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	m := pmetric.NewMetrics()
+	rm := m.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("region", "eu")
+	dp.SetIntValue(1)
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("region", "us")
+	dp.SetIntValue(2)
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("temperature")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("region", "us")
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), m))
+
+	require.Len(t, euExp.AllMetrics(), 1)
+	eu := euExp.AllMetrics()[0]
+	assert.Equal(t, 1, eu.DataPointCount())
+	require.Equal(t, 1, eu.MetricCount())
+	rm = eu.ResourceMetrics().At(0)
+	svc, _ := rm.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "svc", svc.Str())
+	assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+	metric := rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests", metric.Name())
+	assert.Equal(t, "1", metric.Unit())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+	assert.True(t, metric.Sum().IsMonotonic())
+	assert.Equal(t, int64(1), metric.Sum().DataPoints().At(0).IntValue())
+
+	require.Len(t, defaultExp.AllMetrics(), 1)
+	def := defaultExp.AllMetrics()[0]
+	assert.Equal(t, 2, def.DataPointCount())
+	assert.Equal(t, 2, def.MetricCount())
+	metric = def.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests", metric.Name())
+	assert.Equal(t, int64(2), metric.Sum().DataPoints().At(0).IntValue())
+}
+
 type mockMetricsExporter struct {
 	mockComponent
 	consumertest.MetricsSink
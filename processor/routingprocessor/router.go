@@ -15,6 +15,7 @@
 package routingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -35,16 +36,25 @@ type router[E component.Component, K any] struct {
 
 	defaultExporterIDs []string
 	table              []RoutingTableItem
+	errorMode          ottl.ErrorMode
+	routingMode        RoutingMode
+	// itemContext is the route context whose conditions are evaluated for
+	// each item of a resource, empty if the signal doesn't support one.
+	itemContext RouteContext
 
 	defaultExporters []E
 	routes           map[string]routingItem[E, K]
+	// order holds the keys of the routes in the order of the routing table.
+	order []string
+	// hasItemRoutes is set when at least one route is evaluated per item.
+	hasItemRoutes bool
 }
 
 // newRouter creates a new router instance with its type parameter constrained
 // to component.Component.
 func newRouter[E component.Component, K any](
-	table []RoutingTableItem,
-	defaultExporterIDs []string,
+	cfg *Config,
+	itemContext RouteContext,
 	settings component.TelemetrySettings,
 	parser ottl.Parser[K],
 ) router[E, K] {
@@ -52,8 +62,11 @@ func newRouter[E component.Component, K any](
 		logger: settings.Logger,
 		parser: parser,
 
-		table:              table,
-		defaultExporterIDs: defaultExporterIDs,
+		table:              cfg.Table,
+		defaultExporterIDs: cfg.DefaultExporters,
+		errorMode:          cfg.ErrorMode,
+		routingMode:        cfg.RoutingMode,
+		itemContext:        itemContext,
 
 		routes: make(map[string]routingItem[E, K]),
 	}
@@ -62,6 +75,9 @@ func newRouter[E component.Component, K any](
 type routingItem[E component.Component, K any] struct {
 	exporters []E
 	statement *ottl.Statement[K]
+	// itemLevel is set when the statement is evaluated for each item of a
+	// resource rather than once for the whole resource.
+	itemLevel bool
 }
 
 func (r *router[E, K]) registerExporters(available map[component.ID]component.Component) error {
@@ -101,6 +117,11 @@ func (r *router[E, K]) registerDefaultExporters(available map[component.ID]compo
 // available exporters map to check if they were available.
 func (r *router[E, K]) registerRouteExporters(available map[component.ID]component.Component) error {
 	for _, item := range r.table {
+		itemLevel, err := r.isItemLevel(item)
+		if err != nil {
+			return err
+		}
+
 		statement, err := r.getStatementFrom(item)
 		if err != nil {
 			return err
@@ -109,6 +130,9 @@ func (r *router[E, K]) registerRouteExporters(available map[component.ID]compone
 		route, ok := r.routes[key(item)]
 		if !ok {
 			route.statement = statement
+			route.itemLevel = itemLevel
+			r.order = append(r.order, key(item))
+			r.hasItemRoutes = r.hasItemRoutes || itemLevel
 		}
 
 		for _, name := range item.Exporters {
@@ -126,17 +150,39 @@ func (r *router[E, K]) registerRouteExporters(available map[component.ID]compone
 	return nil
 }
 
+// isItemLevel reports whether the condition of the routing table entry is
+// evaluated for each item of a resource. It returns an error if the context
+// of the entry isn't supported by the signal of the router.
+func (r *router[E, K]) isItemLevel(item RoutingTableItem) (bool, error) {
+	switch item.Context {
+	case "", resourceRouteContext:
+		return false, nil
+	case r.itemContext:
+		return true, nil
+	default:
+		return false, fmt.Errorf("the context %q of the route with condition %q isn't supported for this pipeline type", item.Context, item.Condition)
+	}
+}
+
 // getStatementFrom builds a routing OTTL statements from provided
-// routing table entry configuration. If routing table entry configuration
-// does not contain a OTTL statement then nil is returned.
+// routing table entry configuration. A condition is turned into a statement
+// calling the noop route() function. If routing table entry configuration
+// does not contain a OTTL statement nor a condition then nil is returned.
 func (r *router[E, K]) getStatementFrom(item RoutingTableItem) (*ottl.Statement[K], error) {
 	var statement *ottl.Statement[K]
-	if item.Statement != "" {
+	switch {
+	case item.Statement != "":
 		var err error
 		statement, err = r.parser.ParseStatement(item.Statement)
 		if err != nil {
 			return statement, err
 		}
+	case item.Condition != "":
+		var err error
+		statement, err = r.parser.ParseStatement("route() where " + item.Condition)
+		if err != nil {
+			return statement, err
+		}
 	}
 	return statement, nil
 }
@@ -145,9 +191,95 @@ func key(entry RoutingTableItem) string {
 	if entry.Value != "" {
 		return entry.Value
 	}
+	if entry.Condition != "" {
+		routeContext := entry.Context
+		if routeContext == "" {
+			routeContext = defaultRouteContext
+		}
+		return fmt.Sprintf("%s: %s", routeContext, entry.Condition)
+	}
 	return entry.Statement
 }
 
+// resourceMatch holds the results of the resource level routes for a resource.
+type resourceMatch struct {
+	matched map[string]bool
+	// errored is set when a route couldn't be evaluated and the error was
+	// ignored, in which case the data is also routed to the default exporters.
+	errored bool
+}
+
+// matchResource evaluates the resource level routes, in the order of the
+// routing table, against the given context which only holds a resource.
+func (r *router[E, K]) matchResource(ctx context.Context, tCtx K) (resourceMatch, error) {
+	res := resourceMatch{matched: make(map[string]bool)}
+	for _, key := range r.order {
+		route := r.routes[key]
+		if route.itemLevel {
+			continue
+		}
+		_, isMatch, err := route.statement.Execute(ctx, tCtx)
+		if err != nil {
+			if r.errorMode == ottl.PropagateError {
+				return res, err
+			}
+			res.errored = true
+			continue
+		}
+		res.matched[key] = isMatch
+		// no route after this one can be selected in the first_match mode
+		if isMatch && r.routingMode == firstMatchRoutingMode {
+			break
+		}
+	}
+	return res, nil
+}
+
+// matchItem returns the keys of the routes that the item in the given context
+// is routed to, in the order of the routing table, evaluating the item level
+// routes and reusing the results of the resource level routes. The key of the
+// default route ("") is returned when no route matches or when a route
+// couldn't be evaluated.
+func (r *router[E, K]) matchItem(ctx context.Context, tCtx K, res resourceMatch) ([]string, error) {
+	var keys []string
+	errored := res.errored
+	for _, key := range r.order {
+		route := r.routes[key]
+		isMatch := res.matched[key]
+		if route.itemLevel {
+			var err error
+			_, isMatch, err = route.statement.Execute(ctx, tCtx)
+			if err != nil {
+				if r.errorMode == ottl.PropagateError {
+					return nil, err
+				}
+				errored = true
+				continue
+			}
+		}
+		if !isMatch {
+			continue
+		}
+		keys = append(keys, key)
+		if r.routingMode == firstMatchRoutingMode {
+			break
+		}
+	}
+	if len(keys) == 0 || errored {
+		keys = append(keys, "")
+	}
+	return keys, nil
+}
+
+// routeExporters returns the exporters of the route with the given key, the
+// default exporters being returned for the default route.
+func (r *router[E, K]) routeExporters(key string) []E {
+	if key == "" {
+		return r.defaultExporters
+	}
+	return r.routes[key].exporters
+}
+
 // extractExporter returns an exporter for the given name (type/name) and type
 // argument if it exists in the list of available exporters.
 func (r *router[E, K]) extractExporter(name string, available map[component.ID]component.Component) (E, error) {
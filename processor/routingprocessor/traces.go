@@ -26,7 +26,6 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/common"
 )
@@ -50,8 +49,8 @@ func newTracesProcessor(settings component.TelemetrySettings, config component.C
 		logger: settings.Logger,
 		config: cfg,
 		router: newRouter[exporter.Traces, ottlspan.TransformContext](
-			cfg.Table,
-			cfg.DefaultExporters,
+			cfg,
+			"",
 			settings,
 			spanParser,
 		),
@@ -103,26 +102,16 @@ func (p *tracesProcessor) route(ctx context.Context, t ptrace.Traces) error {
 			rspans.Resource(),
 		)
 
-		matchCount := len(p.router.routes)
-		for key, route := range p.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, stx)
-			if err != nil {
-				if p.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				p.group("", groups, p.router.defaultExporters, rspans)
-				continue
-			}
-			if !isMatch {
-				matchCount--
-				continue
-			}
-			p.group(key, groups, route.exporters, rspans)
+		res, err := p.router.matchResource(ctx, stx)
+		if err != nil {
+			return err
 		}
-
-		if matchCount == 0 {
-			// no route conditions are matched, add resource spans to default exporters group
-			p.group("", groups, p.router.defaultExporters, rspans)
+		keys, err := p.router.matchItem(ctx, stx, res)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			p.group(key, groups, p.router.routeExporters(key), rspans)
 		}
 	}
 
@@ -16,6 +16,10 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 // PolicyType indicates the type of sampling policy.
@@ -49,6 +53,9 @@ const (
 	// BooleanAttribute sample traces having an attribute, of type bool, that matches
 	// the specified boolean value [true|false].
 	BooleanAttribute PolicyType = "boolean_attribute"
+	// OTTLCondition sample traces which have spans or span events matching the given
+	// OTTL conditions.
+	OTTLCondition PolicyType = "ottl_condition"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -76,6 +83,8 @@ type sharedPolicyCfg struct {
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
 	// Configs for boolean attribute filter sampling policy evaluator.
 	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
+	// Configs for OTTL condition filter sampling policy evaluator.
+	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -155,8 +164,22 @@ type ProbabilisticCfg struct {
 	// SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample.
 	// Values greater or equal 100 are treated as "sample all traces".
 	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+	// Mode defines how the sampling decision is made. The allowed values are `hash_salt` (default), which hashes the
+	// trace ID with the HashSalt, and `consistent_probability`, which implements the OpenTelemetry consistent probability
+	// sampling based on the r-value of the W3C tracestate, agreeing with the probabilistic sampler processor in the same mode.
+	Mode ProbabilisticMode `mapstructure:"mode"`
 }
 
+// ProbabilisticMode is the mode of the probabilistic sampling policy.
+type ProbabilisticMode string
+
+const (
+	// HashSalt samples traces based on the hash of their trace ID and the salt.
+	HashSalt ProbabilisticMode = "hash_salt"
+	// ConsistentProbability samples traces following the OpenTelemetry consistent probability sampling.
+	ConsistentProbability ProbabilisticMode = "consistent_probability"
+)
+
 // StatusCodeCfg holds the configurable settings to create a status code filter sampling
 // policy evaluator.
 type StatusCodeCfg struct {
@@ -208,6 +231,35 @@ type BooleanAttributeCfg struct {
 	Value bool `mapstructure:"value"`
 }
 
+// OTTLConditionCfg holds the configurable settings to create an OTTL condition filter
+// sampling policy evaluator.
+type OTTLConditionCfg struct {
+	// ErrorMode determines how errors returned from evaluating the conditions are handled.
+	// Defaults to propagate, which results in the policy evaluation failing.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+	// Match indicates whether any (the default) or all of the spans of the trace must
+	// match the conditions for the trace to be sampled.
+	Match string `mapstructure:"match"`
+	// SpanConditions are the OTTL conditions evaluated against each span.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions are the OTTL conditions evaluated against each span event.
+	SpanEventConditions []string `mapstructure:"spanevent"`
+}
+
+// DecisionCacheConfig holds the configurable settings of the caches remembering the
+// sampling decisions of the traces, used for the spans arriving after the decision.
+type DecisionCacheConfig struct {
+	// SampledCacheSize is the maximum number of sampled trace IDs kept in the cache.
+	// Zero disables the cache of sampled traces.
+	SampledCacheSize uint64 `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the maximum number of not sampled trace IDs kept in the cache.
+	// Zero disables the cache of not sampled traces.
+	NonSampledCacheSize uint64 `mapstructure:"non_sampled_cache_size"`
+	// TTL is the time a trace ID is kept in the cache after the sampling decision was made.
+	// Zero keeps the trace IDs until they are evicted to make room for newer ones.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	// DecisionWait is the desired wait time from the arrival of the first span of
@@ -222,4 +274,11 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// Storage is the ID of the storage extension used to keep the spans of the traces
+	// waiting for a sampling decision. When set, the spans are not held in memory and
+	// the pending traces are restored when the collector restarts.
+	Storage *component.ID `mapstructure:"storage"`
+	// DecisionCache configures the caches keeping the sampling decisions of the traces,
+	// so that spans arriving after a trace was removed from memory follow its decision.
+	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
 }
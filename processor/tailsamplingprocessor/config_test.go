@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -38,6 +40,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 
+	storageID := component.NewID("file_storage")
 	assert.Equal(t,
 		cfg,
 		&Config{
@@ -114,6 +117,28 @@ func TestLoadConfig(t *testing.T) {
 						BooleanAttributeCfg: BooleanAttributeCfg{Key: "key4", Value: true},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-11",
+						Type: OTTLCondition,
+						OTTLConditionCfg: OTTLConditionCfg{
+							ErrorMode: ottl.IgnoreError,
+							Match:     "all",
+							SpanConditions: []string{
+								"attributes[\"test_attr_key_1\"] == \"test_attr_val_1\"",
+								"attributes[\"test_attr_key_2\"] != \"test_attr_val_1\"",
+							},
+							SpanEventConditions: []string{"name != \"test_span_event_name\""},
+						},
+					},
+				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:             "test-policy-12",
+						Type:             Probabilistic,
+						ProbabilisticCfg: ProbabilisticCfg{Mode: ConsistentProbability, SamplingPercentage: 12.5},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -181,5 +206,11 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 			},
+			Storage: &storageID,
+			DecisionCache: DecisionCacheConfig{
+				SampledCacheSize:    1000,
+				NonSampledCacheSize: 5000,
+				TTL:                 10 * time.Minute,
+			},
 		})
 }
@@ -26,6 +26,8 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -52,6 +54,7 @@ func TestCreateProcessor(t *testing.T) {
 
 	// this will cause the processor to properly initialize, so that we can later shutdown and
 	// have all the go routines cleanly shut down
-	assert.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	host := storagetest.NewStorageHost().WithExtension(component.NewID("file_storage"), storagetest.NewInMemoryStorageExtension("file_storage"))
+	assert.NoError(t, tp.Start(context.Background(), host))
 	assert.NoError(t, tp.Shutdown(context.Background()))
 }
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache defines a bounded cache of trace IDs, used to remember the
+// sampling decisions made for traces no longer held in memory.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ErrInvalidSize occurs when an invalid cache size is specified.
+var ErrInvalidSize = errors.New("invalid cache size, it must be greater than zero")
+
+// EvictionReason indicates why an id was removed from the cache.
+type EvictionReason int
+
+const (
+	// EvictedBySize is used when an id is removed to make room for a newer one.
+	EvictedBySize EvictionReason = iota
+	// EvictedByTTL is used when an id is removed because its time to live elapsed.
+	EvictedByTTL
+)
+
+// String returns the name of the reason, as used in metrics.
+func (r EvictionReason) String() string {
+	if r == EvictedByTTL {
+		return "ttl"
+	}
+	return "size"
+}
+
+// Cache holds up to a fixed number of trace IDs, each for at most its time to live.
+// When full, the oldest id is evicted to make room for a new one. It is safe for
+// concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	size    uint64
+	ttl     time.Duration
+	order   *list.List
+	entries map[pcommon.TraceID]*list.Element
+	onEvict func(EvictionReason)
+	now     func() time.Time
+}
+
+type entry struct {
+	id      pcommon.TraceID
+	addedAt time.Time
+}
+
+// New creates a Cache holding up to size ids. If ttl is greater than zero, ids are
+// evicted once they have been in the cache for longer than it. onEvict, if not nil,
+// is called every time an id is evicted.
+func New(size uint64, ttl time.Duration, onEvict func(EvictionReason)) (*Cache, error) {
+	if size < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	return &Cache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[pcommon.TraceID]*list.Element),
+		onEvict: onEvict,
+		now:     time.Now,
+	}, nil
+}
+
+// Put adds the id to the cache. If it is already present, its time to live starts over.
+func (c *Cache) Put(id pcommon.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.evictExpired(now)
+	if elem, ok := c.entries[id]; ok {
+		elem.Value.(*entry).addedAt = now
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	for uint64(c.order.Len()) >= c.size {
+		c.evict(c.order.Back(), EvictedBySize)
+	}
+	c.entries[id] = c.order.PushFront(&entry{id: id, addedAt: now})
+}
+
+// Get returns the time the id was added to the cache and whether it was found.
+func (c *Cache) Get(id pcommon.TraceID) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	e := elem.Value.(*entry)
+	if c.expired(e, c.now()) {
+		c.evict(elem, EvictedByTTL)
+		return time.Time{}, false
+	}
+	return e.addedAt, true
+}
+
+// Len returns the number of ids in the cache, including the expired ones not
+// evicted yet.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache) expired(e *entry, now time.Time) bool {
+	return c.ttl > 0 && now.Sub(e.addedAt) > c.ttl
+}
+
+// evictExpired removes the expired ids, which are always at the back of the list.
+func (c *Cache) evictExpired(now time.Time) {
+	for elem := c.order.Back(); elem != nil && c.expired(elem.Value.(*entry), now); elem = c.order.Back() {
+		c.evict(elem, EvictedByTTL)
+	}
+}
+
+func (c *Cache) evict(elem *list.Element, reason EvictionReason) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*entry).id)
+	if c.onEvict != nil {
+		c.onEvict(reason)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestCacheNew(t *testing.T) {
+	_, err := New(0, 0, nil)
+	require.ErrorIs(t, err, ErrInvalidSize)
+
+	c, err := New(1, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCacheEvictsOldestWhenFull(t *testing.T) {
+	var evictions []EvictionReason
+	c, err := New(2, 0, func(reason EvictionReason) { evictions = append(evictions, reason) })
+	require.NoError(t, err)
+
+	c.Put(traceID(1))
+	c.Put(traceID(2))
+	c.Put(traceID(1))
+	c.Put(traceID(3))
+
+	_, ok := c.Get(traceID(2))
+	assert.False(t, ok, "oldest id should have been evicted")
+	_, ok = c.Get(traceID(1))
+	assert.True(t, ok, "refreshed id should be kept")
+	_, ok = c.Get(traceID(3))
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, []EvictionReason{EvictedBySize}, evictions)
+}
+
+func TestCacheEvictsExpired(t *testing.T) {
+	var evictions []EvictionReason
+	c, err := New(10, time.Minute, func(reason EvictionReason) { evictions = append(evictions, reason) })
+	require.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	c.now = func() time.Time { return now }
+
+	c.Put(traceID(1))
+	now = now.Add(30 * time.Second)
+	c.Put(traceID(2))
+
+	addedAt, ok := c.Get(traceID(1))
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1000, 0), addedAt)
+
+	now = now.Add(31 * time.Second)
+	_, ok = c.Get(traceID(1))
+	assert.False(t, ok, "id should have expired")
+	_, ok = c.Get(traceID(2))
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	c.Put(traceID(3))
+	assert.Equal(t, 1, c.Len(), "expired ids should be evicted when adding new ones")
+	assert.Equal(t, []EvictionReason{EvictedByTTL, EvictedByTTL}, evictions)
+	assert.Equal(t, "ttl", EvictedByTTL.String())
+	assert.Equal(t, "size", EvictedBySize.String())
+}
+
+func traceID(id byte) pcommon.TraceID {
+	return pcommon.TraceID([16]byte{id})
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+)
+
+const (
+	// OTTLMatchAny samples the trace when any of its spans matches the conditions.
+	OTTLMatchAny = "any"
+	// OTTLMatchAll samples the trace when all of its spans match the conditions.
+	OTTLMatchAll = "all"
+)
+
+type ottlConditionFilter struct {
+	logger              *zap.Logger
+	matchAll            bool
+	sampleSpanExpr      expr.BoolExpr[ottlspan.TransformContext]
+	sampleSpanEventExpr expr.BoolExpr[ottlspanevent.TransformContext]
+}
+
+var _ PolicyEvaluator = (*ottlConditionFilter)(nil)
+
+// NewOTTLConditionFilter creates a policy evaluator that samples traces based on OTTL
+// conditions evaluated against their spans and span events. A span matches when any of
+// the span conditions is true for it, or any of the span event conditions is true for
+// one of its events. Depending on match, the trace is sampled when any or all of its
+// spans match.
+func NewOTTLConditionFilter(logger *zap.Logger, spanConditions, spanEventConditions []string, match string, errorMode ottl.ErrorMode) (PolicyEvaluator, error) {
+	if len(spanConditions) == 0 && len(spanEventConditions) == 0 {
+		return nil, errors.New("expected at least one OTTL condition to filter on")
+	}
+
+	filter := &ottlConditionFilter{logger: logger}
+	switch match {
+	case "", OTTLMatchAny:
+	case OTTLMatchAll:
+		filter.matchAll = true
+	default:
+		return nil, fmt.Errorf("unknown match %q, supported: %s, %s", match, OTTLMatchAny, OTTLMatchAll)
+	}
+	if errorMode == "" {
+		errorMode = ottl.PropagateError
+	}
+
+	set := component.TelemetrySettings{Logger: logger}
+	var err error
+	if len(spanConditions) > 0 {
+		if filter.sampleSpanExpr, err = filterottl.NewBoolExprForSpan(spanConditions, filterottl.StandardSpanFuncs(), errorMode, set); err != nil {
+			return nil, err
+		}
+	}
+	if len(spanEventConditions) > 0 {
+		if filter.sampleSpanEventExpr, err = filterottl.NewBoolExprForSpanEvent(spanEventConditions, filterottl.StandardSpanEventFuncs(), errorMode, set); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (ocf *ottlConditionFilter) Evaluate(_ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	ocf.logger.Debug("Evaluating spans with OTTL conditions filter")
+
+	trace.Lock()
+	defer trace.Unlock()
+
+	ctx := context.Background()
+	matchedSpans := 0
+	batches := trace.ReceivedBatches
+	for i := 0; i < batches.ResourceSpans().Len(); i++ {
+		rs := batches.ResourceSpans().At(i)
+		resource := rs.Resource()
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			scope := ss.Scope()
+			for k := 0; k < ss.Spans().Len(); k++ {
+				matched, err := ocf.matchSpan(ctx, ss.Spans().At(k), scope, resource)
+				if err != nil {
+					return Error, err
+				}
+				switch {
+				case matched && !ocf.matchAll:
+					return Sampled, nil
+				case !matched && ocf.matchAll:
+					return NotSampled, nil
+				case matched:
+					matchedSpans++
+				}
+			}
+		}
+	}
+
+	if matchedSpans > 0 {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+func (ocf *ottlConditionFilter) matchSpan(ctx context.Context, span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) (bool, error) {
+	if ocf.sampleSpanExpr != nil {
+		matched, err := ocf.sampleSpanExpr.Eval(ctx, ottlspan.NewTransformContext(span, scope, resource))
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+
+	if ocf.sampleSpanEventExpr != nil {
+		events := span.Events()
+		for i := 0; i < events.Len(); i++ {
+			matched, err := ocf.sampleSpanEventExpr.Eval(ctx, ottlspanevent.NewTransformContext(events.At(i), span, scope, resource))
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestNewOTTLConditionFilter_errorHandling(t *testing.T) {
+	_, err := NewOTTLConditionFilter(zap.NewNop(), nil, nil, OTTLMatchAny, ottl.PropagateError)
+	assert.EqualError(t, err, "expected at least one OTTL condition to filter on")
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{`name == "a"`}, nil, "some", ottl.PropagateError)
+	assert.EqualError(t, err, "unknown match \"some\", supported: any, all")
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), []string{`name ==`}, nil, OTTLMatchAny, ottl.PropagateError)
+	assert.Error(t, err)
+
+	_, err = NewOTTLConditionFilter(zap.NewNop(), nil, []string{`NotAFunction() == true`}, OTTLMatchAny, ottl.PropagateError)
+	assert.Error(t, err)
+}
+
+func TestOTTLConditionFilter(t *testing.T) {
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		Match               string
+		ErrorMode           ottl.ErrorMode
+		Decision            Decision
+		ExpectError         bool
+	}{
+		{
+			Desc:           "span condition matches",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "span condition does not match",
+			SpanConditions: []string{`attributes["http.status_code"] >= 600`},
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "any span condition matches",
+			SpanConditions: []string{`name == "unknown"`, `resource.attributes["service.name"] == "checkout"`},
+			Decision:       Sampled,
+		},
+		{
+			Desc:                "span event condition matches",
+			SpanEventConditions: []string{`name == "exception"`},
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "span event condition does not match",
+			SpanEventConditions: []string{`name == "retry"`},
+			Decision:            NotSampled,
+		},
+		{
+			Desc:           "all spans match",
+			SpanConditions: []string{`IsMatch(name, "^op-")`},
+			Match:          OTTLMatchAll,
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "not all spans match",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Match:          OTTLMatchAll,
+			Decision:       NotSampled,
+		},
+		{
+			Desc:                "all spans match either a span or a span event condition",
+			SpanConditions:      []string{`attributes["http.status_code"] == 200`},
+			SpanEventConditions: []string{`name == "exception"`},
+			Match:               OTTLMatchAll,
+			Decision:            Sampled,
+		},
+		{
+			Desc:           "evaluation error is propagated",
+			SpanConditions: []string{`Substring(name, 0, 20) == "op-list"`},
+			ErrorMode:      ottl.PropagateError,
+			Decision:       Error,
+			ExpectError:    true,
+		},
+		{
+			Desc:           "evaluation error is ignored",
+			SpanConditions: []string{`Substring(name, 0, 20) == "op-list"`, `attributes["http.status_code"] >= 500`},
+			ErrorMode:      ottl.IgnoreError,
+			Decision:       Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewOTTLConditionFilter(zap.NewNop(), c.SpanConditions, c.SpanEventConditions, c.Match, c.ErrorMode)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(pcommon.TraceID{}, newTraceForOTTL())
+			if c.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func newTraceForOTTL() *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	ok := spans.AppendEmpty()
+	ok.SetName("op-list")
+	ok.Attributes().PutInt("http.status_code", 200)
+
+	failed := spans.AppendEmpty()
+	failed.SetName("op-pay")
+	failed.Attributes().PutInt("http.status_code", 503)
+	failed.Events().AppendEmpty().SetName("exception")
+
+	return &TraceData{
+		ReceivedBatches: traces,
+	}
+}
@@ -36,6 +36,9 @@ type TraceData struct {
 	SpanCount *atomic.Int64
 	// ReceivedBatches stores all the batches received for the trace.
 	ReceivedBatches ptrace.Traces
+	// StoredBatches is the number of batches of the trace kept in the storage
+	// extension instead of ReceivedBatches.
+	StoredBatches int
 	// FinalDecision.
 	FinalDecision Decision
 }
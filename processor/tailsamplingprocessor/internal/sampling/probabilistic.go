@@ -20,7 +20,10 @@ import (
 	"math/big"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 const (
@@ -61,6 +64,55 @@ func (s *probabilisticSampler) Evaluate(traceID pcommon.TraceID, _ *TraceData) (
 	return NotSampled, nil
 }
 
+type consistentProbabilisticSampler struct {
+	logger  *zap.Logger
+	sampler *pkgsampling.ConsistentSampler
+}
+
+var _ PolicyEvaluator = (*consistentProbabilisticSampler)(nil)
+
+// NewConsistentProbabilisticSampler creates a policy evaluator that samples a percentage of traces
+// following the OpenTelemetry consistent probability sampling, so that its decisions agree with the
+// ones of the SDKs and collectors using the r-value of the W3C tracestate. The tracestate of the spans
+// isn't modified.
+func NewConsistentProbabilisticSampler(logger *zap.Logger, samplingPercentage float64) (PolicyEvaluator, error) {
+	probability := math.Max(0, math.Min(samplingPercentage/100, 1))
+	sampler, err := pkgsampling.NewConsistentSampler(probability)
+	if err != nil {
+		return nil, err
+	}
+
+	return &consistentProbabilisticSampler{
+		logger:  logger,
+		sampler: sampler,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (s *consistentProbabilisticSampler) Evaluate(traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	s.logger.Debug("Evaluating spans in consistent probabilistic filter")
+
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	// the r-value is the same for all the spans of the trace: use the first valid one
+	var ts pkgsampling.W3CTraceState
+	hasSpanWithCondition(batches, func(span ptrace.Span) bool {
+		candidate, err := pkgsampling.ParseW3CTraceState(span.TraceState().AsRaw())
+		if _, ok := candidate.OTel().RValue(); !ok || err != nil {
+			return false
+		}
+		ts = candidate
+		return true
+	})
+
+	if s.sampler.Decide(traceID, ts.OTel()) {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
 // calculateThreshold converts a ratio into a value between 0 and MaxUint64
 func calculateThreshold(ratio float64) uint64 {
 	// Use big.Float and big.Int to calculate threshold because directly convert
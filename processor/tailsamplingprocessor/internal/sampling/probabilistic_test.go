@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/zap"
 )
@@ -100,6 +101,65 @@ func TestProbabilisticSampling(t *testing.T) {
 	}
 }
 
+func TestConsistentProbabilisticSampling(t *testing.T) {
+	for _, tt := range []struct {
+		name                       string
+		samplingPercentage         float64
+		expectedSamplingPercentage float64
+	}{
+		{"100%", 100, 100},
+		{"0%", 0, 0},
+		{"25%", 25, 25},
+		{"33%", 33, 33},
+		{"-%50", -50, 0},
+		{"150%", 150, 100},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			traceCount := 100_000
+
+			probabilisticSampler, err := NewConsistentProbabilisticSampler(zap.NewNop(), tt.samplingPercentage)
+			require.NoError(t, err)
+
+			sampled := 0
+			for _, traceID := range genRandomTraceIDs(traceCount) {
+				trace := newTraceStringAttrs(nil, "example", "value")
+
+				decision, err := probabilisticSampler.Evaluate(traceID, trace)
+				assert.NoError(t, err)
+
+				if decision == Sampled {
+					sampled++
+				}
+			}
+
+			effectiveSamplingPercentage := float32(sampled) / float32(traceCount) * 100
+			assert.InDelta(t, tt.expectedSamplingPercentage, effectiveSamplingPercentage, 0.5,
+				"Effective sampling percentage is %f, expected %f", effectiveSamplingPercentage, tt.expectedSamplingPercentage,
+			)
+		})
+	}
+}
+
+func TestConsistentProbabilisticSamplingUsesTraceState(t *testing.T) {
+	probabilisticSampler, err := NewConsistentProbabilisticSampler(zap.NewNop(), 25)
+	require.NoError(t, err)
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	// the r-value of the tracestate is used instead of the one derived from the trace ID
+	decision, err := probabilisticSampler.Evaluate(traceID, newTraceState("ot=r:2"))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	decision, err = probabilisticSampler.Evaluate(traceID, newTraceState("ot=r:1"))
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+
+	// upstream decisions with a lower probability are kept
+	decision, err = probabilisticSampler.Evaluate(traceID, newTraceState("vendor=value,ot=p:4;r:5"))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
 func genRandomTraceIDs(num int) (ids []pcommon.TraceID) {
 	r := rand.New(rand.NewSource(1))
 	ids = make([]pcommon.TraceID, 0, num)
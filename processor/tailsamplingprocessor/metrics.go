@@ -27,6 +27,7 @@ var (
 	tagPolicyKey, _    = tag.NewKey("policy")
 	tagSampledKey, _   = tag.NewKey("sampled")
 	tagSourceFormat, _ = tag.NewKey("source_format")
+	tagReasonKey, _    = tag.NewKey("reason")
 
 	statDecisionLatencyMicroSec  = stats.Int64("sampling_decision_latency", "Latency (in microseconds) of a given sampling policy", "µs")
 	statOverallDecisionLatencyUs = stats.Int64("sampling_decision_timer_latency", "Latency (in microseconds) of each run of the sampling decision timer", "µs")
@@ -41,6 +42,9 @@ var (
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
 	statTracesOnMemoryGauge     = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
+
+	statDecisionCacheHitCount      = stats.Int64("sampling_decision_cache_hit", "Count of traces with late spans whose sampling decision was found in the decision cache", stats.UnitDimensionless)
+	statDecisionCacheEvictionCount = stats.Int64("sampling_decision_cache_eviction", "Count of trace IDs evicted from the decision cache", stats.UnitDimensionless)
 )
 
 // SamplingProcessorMetricViews return the metrics views according to given telemetry level.
@@ -116,6 +120,21 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: view.LastValue(),
 	}
 
+	decisionCacheHitView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheHitCount.Name()),
+		Measure:     statDecisionCacheHitCount,
+		Description: statDecisionCacheHitCount.Description(),
+		TagKeys:     []tag.Key{tagSampledKey},
+		Aggregation: view.Sum(),
+	}
+	decisionCacheEvictionView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheEvictionCount.Name()),
+		Measure:     statDecisionCacheEvictionCount,
+		Description: statDecisionCacheEvictionCount.Description(),
+		TagKeys:     []tag.Key{tagSampledKey, tagReasonKey},
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		decisionLatencyView,
 		overallDecisionLatencyView,
@@ -130,5 +149,8 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		countTraceDroppedTooEarlyView,
 		countTraceIDArrivalView,
 		trackTracesOnMemorylView,
+
+		decisionCacheHitView,
+		decisionCacheEvictionView,
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,6 +33,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -61,6 +63,11 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+	id              component.ID
+	storageID       *component.ID
+	storage         *traceStorage
+	sampledCache    *cache.Cache
+	nonSampledCache *cache.Cache
 }
 
 const (
@@ -69,7 +76,8 @@ const (
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform tail sampling according to the given
 // configuration.
-func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Config) (processor.Traces, error) {
+func newTracesProcessor(set processor.CreateSettings, nextConsumer consumer.Traces, cfg Config) (processor.Traces, error) {
+	logger := set.Logger
 	if nextConsumer == nil {
 		return nil, component.ErrNilNextConsumer
 	}
@@ -109,6 +117,19 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  &atomic.Uint64{},
+		id:              set.ID,
+		storageID:       cfg.Storage,
+	}
+
+	if cfg.DecisionCache.SampledCacheSize > 0 {
+		if tsp.sampledCache, err = newDecisionCache(ctx, cfg.DecisionCache.SampledCacheSize, cfg.DecisionCache.TTL, true); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.DecisionCache.NonSampledCacheSize > 0 {
+		if tsp.nonSampledCache, err = newDecisionCache(ctx, cfg.DecisionCache.NonSampledCacheSize, cfg.DecisionCache.TTL, false); err != nil {
+			return nil, err
+		}
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -117,6 +138,17 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 	return tsp, nil
 }
 
+// newDecisionCache creates a cache for the IDs of the traces with the given sampling
+// decision, recording its evictions.
+func newDecisionCache(ctx context.Context, size uint64, ttl time.Duration, sampled bool) (*cache.Cache, error) {
+	sampledTag := tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))
+	return cache.New(size, ttl, func(reason cache.EvictionReason) {
+		_ = stats.RecordWithTags(ctx,
+			[]tag.Mutator{sampledTag, tag.Upsert(tagReasonKey, reason.String())},
+			statDecisionCacheEvictionCount.M(int64(1)))
+	})
+}
+
 func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
 	switch cfg.Type {
 	case Composite:
@@ -140,7 +172,17 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue), nil
 	case Probabilistic:
 		pCfg := cfg.ProbabilisticCfg
-		return sampling.NewProbabilisticSampler(logger, pCfg.HashSalt, pCfg.SamplingPercentage), nil
+		switch pCfg.Mode {
+		case HashSalt, "":
+			return sampling.NewProbabilisticSampler(logger, pCfg.HashSalt, pCfg.SamplingPercentage), nil
+		case ConsistentProbability:
+			if pCfg.HashSalt != "" {
+				return nil, fmt.Errorf("hash_salt can't be used with the %s mode", ConsistentProbability)
+			}
+			return sampling.NewConsistentProbabilisticSampler(logger, pCfg.SamplingPercentage)
+		default:
+			return nil, fmt.Errorf("unknown probabilistic mode %s", pCfg.Mode)
+		}
 	case StringAttribute:
 		safCfg := cfg.StringAttributeCfg
 		return sampling.NewStringAttributeFilter(logger, safCfg.Key, safCfg.Values, safCfg.EnabledRegexMatching, safCfg.CacheMaxSize, safCfg.InvertMatch), nil
@@ -159,6 +201,9 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 	case BooleanAttribute:
 		bafCfg := cfg.BooleanAttributeCfg
 		return sampling.NewBooleanAttributeFilter(logger, bafCfg.Key, bafCfg.Value), nil
+	case OTTLCondition:
+		ottlfCfg := cfg.OTTLConditionCfg
+		return sampling.NewOTTLConditionFilter(logger, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.Match, ottlfCfg.ErrorMode)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -184,15 +229,23 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
 
+		// Bring the stored spans back into memory for the policies to evaluate them.
+		trace.Lock()
+		loadedBatches := tsp.loadStoredBatches(id, trace, 0)
+		trace.Unlock()
+
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
 
 		// Sampled or not, remove the batches
 		trace.Lock()
+		tsp.loadStoredBatches(id, trace, loadedBatches)
+		tsp.deleteStoredBatches(id, trace)
 		allSpans := trace.ReceivedBatches
 		trace.FinalDecision = decision
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
+		tsp.cacheDecision(id, decision)
 		if decision == sampling.Sampled {
 			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
 		}
@@ -326,6 +379,10 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
+		if tsp.processCachedDecision(id, resourceSpans, spans) {
+			continue
+		}
+
 		lenSpans := int64(len(spans))
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
@@ -348,19 +405,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			actualData.SpanCount.Add(lenSpans)
 		} else {
 			newTraceIDs++
-			tsp.decisionBatcher.AddToCurrentBatch(id)
-			tsp.numTracesOnMap.Add(1)
-			postDeletion := false
-			currTime := time.Now()
-			for !postDeletion {
-				select {
-				case tsp.deleteChan <- id:
-					postDeletion = true
-				default:
-					traceKeyToDrop := <-tsp.deleteChan
-					tsp.dropTrace(traceKeyToDrop, currTime)
-				}
-			}
+			tsp.trackNewTrace(id)
 		}
 
 		// The only thing we really care about here is the final decision.
@@ -369,7 +414,11 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 		if finalDecision == sampling.Unspecified {
 			// If the final decision hasn't been made, add the new spans under the lock.
-			appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
+			if tsp.storage != nil {
+				tsp.storeSpans(id, actualData, resourceSpans, spans)
+			} else {
+				appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
+			}
 			actualData.Unlock()
 		} else {
 			actualData.Unlock()
@@ -396,21 +445,167 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// cacheDecision remembers the sampling decision of the trace, so that it can be applied
+// to its late spans after the trace is removed from idToTrace.
+func (tsp *tailSamplingSpanProcessor) cacheDecision(id pcommon.TraceID, decision sampling.Decision) {
+	switch {
+	case decision == sampling.Sampled && tsp.sampledCache != nil:
+		tsp.sampledCache.Put(id)
+	case decision == sampling.NotSampled && tsp.nonSampledCache != nil:
+		tsp.nonSampledCache.Put(id)
+	}
+}
+
+// processCachedDecision applies the cached sampling decision of the trace to the spans,
+// returning false if there's no decision cached for it.
+func (tsp *tailSamplingSpanProcessor) processCachedDecision(id pcommon.TraceID, rss ptrace.ResourceSpans, spans []*ptrace.Span) bool {
+	if tsp.sampledCache != nil {
+		if _, ok := tsp.sampledCache.Get(id); ok {
+			_ = stats.RecordWithTags(tsp.ctx, []tag.Mutator{tag.Upsert(tagSampledKey, "true")}, statDecisionCacheHitCount.M(int64(1)))
+			traceTd := ptrace.NewTraces()
+			appendToTraces(traceTd, rss, spans)
+			if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
+				tsp.logger.Warn(
+					"Error sending late arrived spans to destination",
+					zap.Error(err))
+			}
+			return true
+		}
+	}
+
+	if tsp.nonSampledCache != nil {
+		if decisionTime, ok := tsp.nonSampledCache.Get(id); ok {
+			_ = stats.RecordWithTags(tsp.ctx, []tag.Mutator{tag.Upsert(tagSampledKey, "false")}, statDecisionCacheHitCount.M(int64(1)))
+			stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(decisionTime)/time.Second)))
+			return true
+		}
+	}
+
+	return false
+}
+
+// trackNewTrace schedules the sampling decision of a trace just added to idToTrace,
+// dropping the oldest trace if the maximum number of traces was reached.
+func (tsp *tailSamplingSpanProcessor) trackNewTrace(id pcommon.TraceID) {
+	tsp.decisionBatcher.AddToCurrentBatch(id)
+	tsp.numTracesOnMap.Add(1)
+	postDeletion := false
+	currTime := time.Now()
+	for !postDeletion {
+		select {
+		case tsp.deleteChan <- id:
+			postDeletion = true
+		default:
+			traceKeyToDrop := <-tsp.deleteChan
+			tsp.dropTrace(traceKeyToDrop, currTime)
+		}
+	}
+}
+
+// storeSpans writes the spans as a new batch of the trace to the storage extension.
+// The spans are kept in memory if they can't be stored. Must be called with the
+// trace locked.
+func (tsp *tailSamplingSpanProcessor) storeSpans(id pcommon.TraceID, trace *sampling.TraceData, rss ptrace.ResourceSpans, spans []*ptrace.Span) {
+	td := ptrace.NewTraces()
+	appendToTraces(td, rss, spans)
+	state := storedTrace{
+		ArrivalTime: trace.ArrivalTime,
+		SpanCount:   trace.SpanCount.Load(),
+		Batches:     trace.StoredBatches + 1,
+	}
+	if err := tsp.storage.appendBatch(tsp.ctx, id, td, state); err != nil {
+		tsp.logger.Warn("Failed to store spans, keeping them in memory", zap.Error(err))
+		td.ResourceSpans().MoveAndAppendTo(trace.ReceivedBatches.ResourceSpans())
+		return
+	}
+	trace.StoredBatches++
+}
+
+// loadStoredBatches appends the stored batches of the trace, starting at from,
+// to its ReceivedBatches and returns the number of stored batches. Must be called
+// with the trace locked.
+func (tsp *tailSamplingSpanProcessor) loadStoredBatches(id pcommon.TraceID, trace *sampling.TraceData, from int) int {
+	if tsp.storage == nil {
+		return 0
+	}
+	if err := tsp.storage.loadBatches(tsp.ctx, id, from, trace.StoredBatches, trace.ReceivedBatches); err != nil {
+		tsp.logger.Warn("Failed to load stored spans", zap.Error(err))
+	}
+	return trace.StoredBatches
+}
+
+// deleteStoredBatches removes the stored batches of the trace from the storage
+// extension. Must be called with the trace locked.
+func (tsp *tailSamplingSpanProcessor) deleteStoredBatches(id pcommon.TraceID, trace *sampling.TraceData) {
+	if tsp.storage == nil || trace.StoredBatches == 0 {
+		return
+	}
+	if err := tsp.storage.deleteBatches(tsp.ctx, id, trace.StoredBatches); err != nil {
+		tsp.logger.Warn("Failed to delete stored spans", zap.Error(err))
+	}
+	trace.StoredBatches = 0
+}
+
+// restorePendingTraces adds the traces left in the storage extension by a
+// previous run back to idToTrace. Their sampling decision is made after the
+// decision wait.
+func (tsp *tailSamplingSpanProcessor) restorePendingTraces(ctx context.Context) error {
+	pending, err := tsp.storage.loadPendingTraces(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pending {
+		decisions := make([]sampling.Decision, len(tsp.policies))
+		for i := range decisions {
+			decisions[i] = sampling.Pending
+		}
+		spanCount := &atomic.Int64{}
+		spanCount.Store(p.SpanCount)
+		if _, loaded := tsp.idToTrace.LoadOrStore(p.id, &sampling.TraceData{
+			Decisions:       decisions,
+			ArrivalTime:     p.ArrivalTime,
+			SpanCount:       spanCount,
+			ReceivedBatches: ptrace.NewTraces(),
+			StoredBatches:   p.Batches,
+		}); !loaded {
+			tsp.trackNewTrace(p.id)
+		}
+	}
+
+	tsp.logger.Debug("Restored pending traces from storage", zap.Int("traces", len(pending)))
+	return nil
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		client, err := getStorageClient(ctx, host, *tsp.storageID, tsp.id)
+		if err != nil {
+			return err
+		}
+		tsp.storage = newTraceStorage(client)
+		if err := tsp.restorePendingTraces(ctx); err != nil {
+			return fmt.Errorf("failed to restore pending traces: %w", err)
+		}
+	}
+
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
-	return nil
+	if tsp.storage == nil {
+		return nil
+	}
+	return tsp.storage.close(ctx)
 }
 
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
@@ -426,6 +621,10 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 		return
 	}
 
+	trace.Lock()
+	tsp.deleteStoredBatches(traceID, trace)
+	trace.Unlock()
+
 	stats.Record(tsp.ctx, statTraceRemovalAgeSec.M(int64(deletionTime.Sub(trace.ArrivalTime)/time.Second)))
 }
 
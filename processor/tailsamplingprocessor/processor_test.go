@@ -24,14 +24,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -50,7 +53,7 @@ func TestSequentialTraceArrival(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -81,7 +84,7 @@ func TestConcurrentTraceArrival(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -121,7 +124,7 @@ func TestSequentialTraceMapSize(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -151,7 +154,7 @@ func TestConcurrentTraceMapSize(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -564,6 +567,107 @@ func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 	require.EqualValues(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
 }
 
+func TestLateArrivingSpansUseCachedDecision(t *testing.T) {
+	// Only one trace is kept in memory, so the decided traces are removed from it
+	// as soon as a new trace arrives.
+	const maxSize = 1
+	nextConsumer := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	sampledCache, err := cache.New(10, time.Minute, nil)
+	require.NoError(t, err)
+	nonSampledCache, err := cache.New(10, time.Minute, nil)
+	require.NoError(t, err)
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    nextConsumer,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(1),
+		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:      make(chan pcommon.TraceID, maxSize),
+		policyTicker:    &manualTTicker{},
+		tickerFrequency: 100 * time.Millisecond,
+		numTracesOnMap:  &atomic.Uint64{},
+		sampledCache:    sampledCache,
+		nonSampledCache: nonSampledCache,
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	sampledID, notSampledID, otherID := uInt64ToTraceID(1), uInt64ToTraceID(2), uInt64ToTraceID(3)
+
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 1, nextConsumer.SpanCount())
+
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(otherID)))
+	_, ok := tsp.idToTrace.Load(notSampledID)
+	require.False(t, ok, "decided trace should have been removed from memory")
+	require.EqualValues(t, 2, mpe.EvaluationCount)
+
+	// The late spans follow the cached decisions without being evaluated again.
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	require.EqualValues(t, 2, nextConsumer.SpanCount())
+	_, ok = tsp.idToTrace.Load(notSampledID)
+	require.False(t, ok, "late span should not start a new trace")
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 3, mpe.EvaluationCount, "only the other trace should have been evaluated")
+	require.EqualValues(t, 3, nextConsumer.SpanCount())
+}
+
+func TestProbabilisticPolicyModes(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		cfg  ProbabilisticCfg
+		err  string
+	}{
+		{
+			desc: "default mode",
+			cfg:  ProbabilisticCfg{SamplingPercentage: 10},
+		},
+		{
+			desc: "hash salt",
+			cfg:  ProbabilisticCfg{Mode: HashSalt, HashSalt: "salt", SamplingPercentage: 10},
+		},
+		{
+			desc: "consistent probability",
+			cfg:  ProbabilisticCfg{Mode: ConsistentProbability, SamplingPercentage: 10},
+		},
+		{
+			desc: "consistent probability with a hash salt",
+			cfg:  ProbabilisticCfg{Mode: ConsistentProbability, HashSalt: "salt", SamplingPercentage: 10},
+			err:  "hash_salt can't be used with the consistent_probability mode",
+		},
+		{
+			desc: "unknown mode",
+			cfg:  ProbabilisticCfg{Mode: "random", SamplingPercentage: 10},
+			err:  "unknown probabilistic mode random",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			evaluator, err := getSharedPolicyEvaluator(zap.NewNop(), &sharedPolicyCfg{Type: Probabilistic, ProbabilisticCfg: tt.cfg})
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, evaluator)
+		})
+	}
+}
+
 func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	const maxSize = 100
 	const decisionWaitSeconds = 1
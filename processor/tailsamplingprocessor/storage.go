@@ -0,0 +1,360 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// indexSegmentSize is the number of trace IDs per index segment, so that
+	// listing a new trace rewrites a small value
+	indexSegmentSize = 128
+	traceIDSize      = len(pcommon.TraceID{})
+)
+
+// traceStorage keeps the spans of the traces waiting for a sampling decision
+// in a storage extension, so that they don't need to be held in memory and
+// are not lost when the collector restarts.
+//
+// Each batch of spans gets its own key, and the state of the trace is written
+// under the trace key along with each batch. As the storage extension can't
+// list its keys, the traces are also listed by an index, split in segments of
+// indexSegmentSize trace IDs, which is written along with the first batch of
+// each trace: after a restart, even an unexpected one, all the traces left in
+// the storage extension can be found.
+type traceStorage struct {
+	client      storage.Client
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	// mu guards stored and index
+	mu sync.Mutex
+	// stored holds the traces with batches in the storage extension, and
+	// index the trace IDs listed by the index segments, which still include
+	// the traces deleted since the index was last compacted
+	stored map[pcommon.TraceID]struct{}
+	index  []pcommon.TraceID
+}
+
+// storedTrace is the persisted state of a trace waiting for a sampling decision.
+type storedTrace struct {
+	ArrivalTime time.Time `json:"arrival_time"`
+	SpanCount   int64     `json:"span_count"`
+	Batches     int       `json:"batches"`
+}
+
+// pendingTrace is a trace found in the storage extension when it was opened.
+type pendingTrace struct {
+	storedTrace
+	id pcommon.TraceID
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+func newTraceStorage(client storage.Client) *traceStorage {
+	return &traceStorage{
+		client: client,
+		stored: make(map[pcommon.TraceID]struct{}),
+	}
+}
+
+func traceKey(id pcommon.TraceID) string {
+	return "trace_" + hex.EncodeToString(id[:])
+}
+
+func batchKey(id pcommon.TraceID, batch int) string {
+	return fmt.Sprintf("trace_%s_%d", hex.EncodeToString(id[:]), batch)
+}
+
+func indexKey(segment int) string {
+	return fmt.Sprintf("index_%d", segment)
+}
+
+// appendBatch stores td as the last batch of the trace, along with the state
+// of the trace including it, and lists the trace in the index if it's its
+// first batch.
+func (s *traceStorage) appendBatch(ctx context.Context, id pcommon.TraceID, td ptrace.Traces, state storedTrace) error {
+	buf, err := s.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	stateBuf, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	// the index is written first and the state last, so that even a storage
+	// extension not applying the operations atomically doesn't leave keys
+	// that can't be found after a restart
+	ops := []storage.Operation{
+		storage.SetOperation(batchKey(id, state.Batches-1), buf),
+		storage.SetOperation(traceKey(id), stateBuf),
+	}
+
+	s.mu.Lock()
+	if _, ok := s.stored[id]; ok {
+		s.mu.Unlock()
+		return s.client.Batch(ctx, ops...)
+	}
+	// keep the lock while writing the index so that the segments are
+	// written in order
+	defer s.mu.Unlock()
+	index := append(s.index, id)
+	segment := (len(index) - 1) / indexSegmentSize
+	ops = append([]storage.Operation{storage.SetOperation(indexKey(segment), encodeIndexSegment(index, segment))}, ops...)
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	s.stored[id] = struct{}{}
+	s.index = index
+	return nil
+}
+
+// loadBatches appends the spans of the stored batches [from, to) of the trace to dest.
+func (s *traceStorage) loadBatches(ctx context.Context, id pcommon.TraceID, from, to int, dest ptrace.Traces) error {
+	if from >= to {
+		return nil
+	}
+
+	ops := make([]storage.Operation, 0, to-from)
+	for i := from; i < to; i++ {
+		ops = append(ops, storage.GetOperation(batchKey(id, i)))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		if op.Value == nil {
+			return fmt.Errorf("batch %s of trace not found in storage", op.Key)
+		}
+		td, err := s.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return err
+		}
+		td.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+	}
+	return nil
+}
+
+// deleteBatches removes the state and the first count stored batches of the
+// trace, and compacts the index once it mostly lists deleted traces.
+func (s *traceStorage) deleteBatches(ctx context.Context, id pcommon.TraceID, count int) error {
+	if count == 0 {
+		return nil
+	}
+
+	ops := make([]storage.Operation, 0, count+1)
+	ops = append(ops, storage.DeleteOperation(traceKey(id)))
+	for i := 0; i < count; i++ {
+		ops = append(ops, storage.DeleteOperation(batchKey(id, i)))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.stored, id)
+	if len(s.index) >= 2*len(s.stored)+indexSegmentSize {
+		return s.compactIndex(ctx)
+	}
+	return nil
+}
+
+// loadPendingTraces returns the traces found in the storage extension, in the
+// order they were first stored. The batches left behind by the traces deleted
+// or interrupted before their state was written are deleted, and the index is
+// compacted so that it only lists the returned traces.
+func (s *traceStorage) loadPendingTraces(ctx context.Context) ([]pendingTrace, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var index []pcommon.TraceID
+	for segment := 0; ; segment++ {
+		value, err := s.client.Get(ctx, indexKey(segment))
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			break
+		}
+		if len(value)%traceIDSize != 0 {
+			return nil, fmt.Errorf("invalid index segment %d of %d bytes", segment, len(value))
+		}
+		for i := 0; i < len(value); i += traceIDSize {
+			var id pcommon.TraceID
+			copy(id[:], value[i:])
+			index = append(index, id)
+		}
+	}
+
+	var ids []pcommon.TraceID
+	listed := make(map[pcommon.TraceID]struct{}, len(index))
+	for _, id := range index {
+		if _, ok := listed[id]; ok {
+			// listed again after being deleted and stored again
+			continue
+		}
+		listed[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	ops := make([]storage.Operation, len(ids))
+	for i, id := range ids {
+		ops[i] = storage.GetOperation(traceKey(id))
+	}
+	if len(ops) > 0 {
+		if err := s.client.Batch(ctx, ops...); err != nil {
+			return nil, err
+		}
+	}
+
+	var pending []pendingTrace
+	stateBatches := make(map[pcommon.TraceID]int, len(ids))
+	for i, id := range ids {
+		if ops[i].Value == nil {
+			// deleted since the index was last compacted
+			stateBatches[id] = 0
+			continue
+		}
+		p := pendingTrace{id: id}
+		if err := json.Unmarshal(ops[i].Value, &p.storedTrace); err != nil {
+			return nil, fmt.Errorf("failed to decode the state of trace %s: %w", hex.EncodeToString(id[:]), err)
+		}
+		stateBatches[id] = p.Batches
+		pending = append(pending, p)
+		s.stored[id] = struct{}{}
+	}
+
+	if err := s.deleteUnreferencedBatches(ctx, stateBatches); err != nil {
+		return nil, err
+	}
+
+	s.index = index
+	if err := s.compactIndex(ctx); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// deleteUnreferencedBatches deletes the batches of the traces following the
+// number of batches given by their state, which were stored without their
+// state being updated. It must be called with the lock held.
+func (s *traceStorage) deleteUnreferencedBatches(ctx context.Context, stateBatches map[pcommon.TraceID]int) error {
+	next := stateBatches
+	for len(next) > 0 {
+		ids := make([]pcommon.TraceID, 0, len(next))
+		ops := make([]storage.Operation, 0, len(next))
+		for id, batch := range next {
+			ids = append(ids, id)
+			ops = append(ops, storage.GetOperation(batchKey(id, batch)))
+		}
+		if err := s.client.Batch(ctx, ops...); err != nil {
+			return err
+		}
+
+		found := make(map[pcommon.TraceID]int)
+		var deletes []storage.Operation
+		for i, id := range ids {
+			if ops[i].Value == nil {
+				continue
+			}
+			found[id] = next[id] + 1
+			deletes = append(deletes, storage.DeleteOperation(ops[i].Key))
+		}
+		if len(deletes) > 0 {
+			if err := s.client.Batch(ctx, deletes...); err != nil {
+				return err
+			}
+		}
+		next = found
+	}
+	return nil
+}
+
+// compactIndex rewrites the index so that it only lists the traces in the
+// storage extension. It must be called with the lock held.
+func (s *traceStorage) compactIndex(ctx context.Context) error {
+	index := make([]pcommon.TraceID, 0, len(s.stored))
+	listed := make(map[pcommon.TraceID]struct{}, len(s.stored))
+	for _, id := range s.index {
+		if _, ok := s.stored[id]; !ok {
+			continue
+		}
+		if _, ok := listed[id]; ok {
+			continue
+		}
+		listed[id] = struct{}{}
+		index = append(index, id)
+	}
+
+	segments := (len(index) + indexSegmentSize - 1) / indexSegmentSize
+	previousSegments := (len(s.index) + indexSegmentSize - 1) / indexSegmentSize
+	var ops []storage.Operation
+	for segment := 0; segment < segments; segment++ {
+		ops = append(ops, storage.SetOperation(indexKey(segment), encodeIndexSegment(index, segment)))
+	}
+	for segment := segments; segment < previousSegments; segment++ {
+		ops = append(ops, storage.DeleteOperation(indexKey(segment)))
+	}
+	if len(ops) > 0 {
+		if err := s.client.Batch(ctx, ops...); err != nil {
+			return err
+		}
+	}
+	s.index = index
+	return nil
+}
+
+// encodeIndexSegment returns the trace IDs of the given index segment.
+func encodeIndexSegment(index []pcommon.TraceID, segment int) []byte {
+	start := segment * indexSegmentSize
+	end := start + indexSegmentSize
+	if end > len(index) {
+		end = len(index)
+	}
+	buf := make([]byte, 0, (end-start)*traceIDSize)
+	for _, id := range index[start:end] {
+		buf = append(buf, id[:]...)
+	}
+	return buf
+}
+
+func (s *traceStorage) close(ctx context.Context) error {
+	return s.client.Close(ctx)
+}
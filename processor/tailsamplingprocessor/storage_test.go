@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestTraceStorage(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID(typeStr), "")
+	s := newTraceStorage(client)
+
+	arrival := time.Unix(1000, 0).UTC()
+	id := uInt64ToTraceID(1)
+	require.NoError(t, s.appendBatch(ctx, id, simpleTracesWithID(id), storedTrace{ArrivalTime: arrival, SpanCount: 1, Batches: 1}))
+	require.NoError(t, s.appendBatch(ctx, id, simpleTracesWithID(id), storedTrace{ArrivalTime: arrival, SpanCount: 2, Batches: 2}))
+
+	td := ptrace.NewTraces()
+	require.NoError(t, s.loadBatches(ctx, id, 0, 2, td))
+	assert.Equal(t, 2, td.SpanCount())
+
+	td = ptrace.NewTraces()
+	require.NoError(t, s.loadBatches(ctx, id, 1, 2, td))
+	assert.Equal(t, 1, td.SpanCount())
+
+	// the trace is found by another instance of the storage, as after a crash
+	pending, err := newTraceStorage(client).loadPendingTraces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []pendingTrace{{id: id, storedTrace: storedTrace{ArrivalTime: arrival, SpanCount: 2, Batches: 2}}}, pending)
+
+	require.NoError(t, s.deleteBatches(ctx, id, 2))
+	assert.Error(t, s.loadBatches(ctx, id, 0, 1, ptrace.NewTraces()))
+
+	pending, err = newTraceStorage(client).loadPendingTraces(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, s.close(ctx))
+}
+
+func TestTraceStorageDeletesUnreferencedBatches(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID(typeStr), "")
+	s := newTraceStorage(client)
+
+	pendingID, deletedID := uInt64ToTraceID(1), uInt64ToTraceID(2)
+	require.NoError(t, s.appendBatch(ctx, pendingID, simpleTracesWithID(pendingID), storedTrace{SpanCount: 1, Batches: 1}))
+	require.NoError(t, s.appendBatch(ctx, deletedID, simpleTracesWithID(deletedID), storedTrace{SpanCount: 1, Batches: 1}))
+	require.NoError(t, s.deleteBatches(ctx, deletedID, 1))
+
+	// batches written without their trace state by a storage extension not
+	// applying the operations atomically
+	require.NoError(t, client.Set(ctx, batchKey(pendingID, 1), []byte("orphan")))
+	require.NoError(t, client.Set(ctx, batchKey(pendingID, 2), []byte("orphan")))
+	require.NoError(t, client.Set(ctx, batchKey(deletedID, 0), []byte("orphan")))
+
+	pending, err := newTraceStorage(client).loadPendingTraces(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, pendingID, pending[0].id)
+
+	for _, key := range []string{batchKey(pendingID, 1), batchKey(pendingID, 2), batchKey(deletedID, 0)} {
+		value, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Nil(t, value, key)
+	}
+	value, err := client.Get(ctx, batchKey(pendingID, 0))
+	require.NoError(t, err)
+	assert.NotNil(t, value)
+}
+
+func TestTraceStorageIndexCompactedAfterDeletes(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID(typeStr), "")
+	s := newTraceStorage(client)
+
+	const traces = 2 * indexSegmentSize
+	for i := 1; i <= traces; i++ {
+		id := uInt64ToTraceID(uint64(i))
+		require.NoError(t, s.appendBatch(ctx, id, simpleTracesWithID(id), storedTrace{SpanCount: 1, Batches: 1}))
+	}
+	value, err := client.Get(ctx, indexKey(1))
+	require.NoError(t, err)
+	assert.Len(t, value, indexSegmentSize*traceIDSize)
+
+	// the index is compacted once it lists more than twice the stored traces
+	// plus a segment
+	for i := 1; i <= 3*indexSegmentSize/2; i++ {
+		require.NoError(t, s.deleteBatches(ctx, uInt64ToTraceID(uint64(i)), 1))
+	}
+	assert.Len(t, s.index, indexSegmentSize/2)
+	value, err = client.Get(ctx, indexKey(1))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	pending, err := newTraceStorage(client).loadPendingTraces(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, indexSegmentSize/2)
+	assert.Equal(t, uInt64ToTraceID(3*indexSegmentSize/2+1), pending[0].id)
+}
+
+func TestStorageNotFound(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicyCfgs:   testPolicy,
+		Storage:      &storageID,
+	}
+	sp, err := newTracesProcessor(processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+
+	err = sp.Start(context.Background(), storagetest.NewStorageHost().WithNonStorageExtension("other"))
+	assert.EqualError(t, err, "storage extension 'test_storage/missing' not found")
+	require.NoError(t, sp.Shutdown(context.Background()))
+
+	nonStorageID := storagetest.NewNonStorageID("other")
+	cfg.Storage = &nonStorageID
+	sp, err = newTracesProcessor(processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+
+	err = sp.Start(context.Background(), storagetest.NewStorageHost().WithNonStorageExtension("other"))
+	assert.EqualError(t, err, "non-storage extension 'non_storage/other' found")
+	require.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestStorageRestoresPendingTraces(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("disk")
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicyCfgs:   testPolicy,
+		Storage:      &storageID,
+	}
+
+	newProcessor := func(next consumer.Traces) *tailSamplingSpanProcessor {
+		sp, err := newTracesProcessor(processortest.NewNopCreateSettings(), next, cfg)
+		require.NoError(t, err)
+		tsp := sp.(*tailSamplingSpanProcessor)
+		tsp.policyTicker = &manualTTicker{}
+		tsp.decisionBatcher.Stop()
+		tsp.decisionBatcher = newSyncIDBatcher(1)
+		require.NoError(t, tsp.Start(context.Background(), storagetest.NewStorageHost().WithFileBackedStorageExtension("disk", storageDir)))
+		return tsp
+	}
+
+	// Receive the spans but shut down before the decision is made.
+	sink := new(consumertest.TracesSink)
+	tsp := newProcessor(sink)
+	traceIds, batches := generateIdsAndBatches(10)
+	for _, td := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+	}
+	tsp.idToTrace.Range(func(_, value any) bool {
+		assert.Zero(t, value.(*sampling.TraceData).ReceivedBatches.SpanCount(), "spans should be kept in storage")
+		return true
+	})
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Zero(t, sink.SpanCount())
+
+	// The pending traces are restored and sampled after the restart.
+	tsp = newProcessor(sink)
+	assert.EqualValues(t, len(traceIds), tsp.numTracesOnMap.Load())
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	assert.Equal(t, len(batches), sink.SpanCount())
+
+	d, ok := tsp.idToTrace.Load(traceIds[9])
+	require.True(t, ok)
+	trace := d.(*sampling.TraceData)
+	assert.Equal(t, sampling.Sampled, trace.FinalDecision)
+	assert.EqualValues(t, 10, trace.SpanCount.Load())
+	assert.Zero(t, trace.StoredBatches)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	// Traces already sampled are not restored again.
+	tsp = newProcessor(sink)
+	assert.Zero(t, tsp.numTracesOnMap.Load())
+	require.NoError(t, tsp.Shutdown(context.Background()))
+}
+
+func TestStorageRestoresPendingTracesAfterCrash(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID(typeStr), "")
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicyCfgs:   testPolicy,
+	}
+
+	newProcessor := func(next consumer.Traces) *tailSamplingSpanProcessor {
+		sp, err := newTracesProcessor(processortest.NewNopCreateSettings(), next, cfg)
+		require.NoError(t, err)
+		tsp := sp.(*tailSamplingSpanProcessor)
+		tsp.policyTicker = &manualTTicker{}
+		tsp.decisionBatcher.Stop()
+		tsp.decisionBatcher = newSyncIDBatcher(1)
+		tsp.storage = newTraceStorage(client)
+		require.NoError(t, tsp.restorePendingTraces(context.Background()))
+		return tsp
+	}
+
+	// Receive the spans, the processor never being shut down.
+	sink := new(consumertest.TracesSink)
+	tsp := newProcessor(sink)
+	traceIds, batches := generateIdsAndBatches(10)
+	for _, td := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+	}
+	tsp.decisionBatcher.Stop()
+
+	// All the spans received are restored.
+	tsp = newProcessor(sink)
+	assert.EqualValues(t, len(traceIds), tsp.numTracesOnMap.Load())
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	assert.Equal(t, len(batches), sink.SpanCount())
+	tsp.decisionBatcher.Stop()
+}
+
+func TestStorageDropTraceDeletesBatches(t *testing.T) {
+	storageID := storagetest.NewStorageID("mem")
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    1,
+		PolicyCfgs:   testPolicy,
+		Storage:      &storageID,
+	}
+	sp, err := newTracesProcessor(processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+	tsp.policyTicker = &manualTTicker{}
+	require.NoError(t, tsp.Start(context.Background(), storagetest.NewStorageHost().WithInMemoryStorageExtension("mem")))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	first, second := uInt64ToTraceID(1), uInt64ToTraceID(2)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(first)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(second)))
+
+	// The first trace was dropped to make room for the second one.
+	assert.Error(t, tsp.storage.loadBatches(context.Background(), first, 0, 1, ptrace.NewTraces()))
+	assert.NoError(t, tsp.storage.loadBatches(context.Background(), second, 0, 1, ptrace.NewTraces()))
+}
@@ -15,7 +15,10 @@
 package transformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -34,6 +37,13 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// Macros declares named blocks of statements that can be invoked from the statements of any context.
+	Macros common.Macros `mapstructure:"macros"`
+
+	// HMACKeys are the secrets of the HMAC Converter, which statements refer to by name
+	// so that the secrets are not part of the statements that are logged.
+	HMACKeys map[string]configopaque.String `mapstructure:"hmac_keys"`
+
 	TraceStatements  []common.ContextStatements `mapstructure:"trace_statements"`
 	MetricStatements []common.ContextStatements `mapstructure:"metric_statements"`
 	LogStatements    []common.ContextStatements `mapstructure:"log_statements"`
@@ -42,13 +52,17 @@ type Config struct {
 var _ component.Config = (*Config)(nil)
 
 func (c *Config) Validate() error {
+	if err := c.Macros.Validate(functionNames()); err != nil {
+		return err
+	}
+
 	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()))
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, c.HMACKeys, common.WithSpanParser(traces.SpanFunctions(c.HMACKeys)), common.WithSpanEventParser(traces.SpanEventFunctions(c.HMACKeys)))
 		if err != nil {
 			return err
 		}
 		for _, cs := range c.TraceStatements {
-			_, err = pc.ParseContextStatements(cs)
+			err = validateContextStatements(c.Macros, cs, pc.ParseContextStatements)
 			if err != nil {
 				return err
 			}
@@ -56,12 +70,12 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.MetricStatements) > 0 {
-		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(metrics.MetricFunctions()), common.WithDataPointParser(metrics.DataPointFunctions()))
+		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, c.HMACKeys, common.WithMetricParser(metrics.MetricFunctions(c.HMACKeys)), common.WithDataPointParser(metrics.DataPointFunctions(c.HMACKeys)))
 		if err != nil {
 			return err
 		}
 		for _, cs := range c.MetricStatements {
-			_, err = pc.ParseContextStatements(cs)
+			err = validateContextStatements(c.Macros, cs, pc.ParseContextStatements)
 			if err != nil {
 				return err
 			}
@@ -69,12 +83,12 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.LogStatements) > 0 {
-		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(logs.LogFunctions()))
+		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, c.HMACKeys, common.WithLogParser(logs.LogFunctions(c.HMACKeys)))
 		if err != nil {
 			return err
 		}
 		for _, cs := range c.LogStatements {
-			_, err = pc.ParseContextStatements(cs)
+			err = validateContextStatements(c.Macros, cs, pc.ParseContextStatements)
 			if err != nil {
 				return err
 			}
@@ -83,3 +97,49 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// validateContextStatements parses the statements of cs, expanding macro invocations first so that
+// the statements of a macro are checked against the context it is invoked from.
+func validateContextStatements[T any](macros common.Macros, cs common.ContextStatements, parse func(common.ContextStatements) (T, error)) error {
+	var all []string
+	for _, statement := range cs.Statements {
+		expanded, err := macros.ExpandStatement(statement)
+		if err != nil {
+			return err
+		}
+		if name, ok := macros.Invocation(statement); ok {
+			_, err = parse(common.ContextStatements{Context: cs.Context, Statements: expanded})
+			if err != nil {
+				return fmt.Errorf("macro %q is not valid in the %v context: %w", name, cs.Context, err)
+			}
+		}
+		all = append(all, expanded...)
+	}
+	_, err := parse(common.ContextStatements{Context: cs.Context, Statements: all})
+	return err
+}
+
+// functionNames returns the names of all functions available to any context and the keywords of
+// OTTL control statements, which macros may not shadow.
+func functionNames() map[string]struct{} {
+	names := map[string]struct{}{
+		"if":   {},
+		"else": {},
+		"end":  {},
+		"stop": {},
+	}
+	addNames(names, common.ResourceFunctions(nil))
+	addNames(names, common.ScopeFunctions(nil))
+	addNames(names, traces.SpanFunctions(nil))
+	addNames(names, traces.SpanEventFunctions(nil))
+	addNames(names, metrics.MetricFunctions(nil))
+	addNames(names, metrics.DataPointFunctions(nil))
+	addNames(names, logs.LogFunctions(nil))
+	return names
+}
+
+func addNames[K any](names map[string]struct{}, functions map[string]ottl.Factory[K]) {
+	for name := range functions {
+		names[name] = struct{}{}
+	}
+}
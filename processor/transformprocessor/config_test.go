@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -102,6 +103,98 @@ func TestLoadConfig(t *testing.T) {
 				LogStatements:    []common.ContextStatements{},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macros"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Macros: common.Macros{
+					"normalize_http": {
+						Params: []string{"method"},
+						Statements: []string{
+							`set(attributes["http.method"], ConvertCase({{method}}, "upper"))`,
+							`delete_key(attributes, "http.request.method")`,
+						},
+					},
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						Context: "span",
+						Statements: []string{
+							`normalize_http(attributes["http.request.method"]) where attributes["http.method"] == nil`,
+						},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Context: "log",
+						Statements: []string{
+							`normalize_http(attributes["http.request.method"])`,
+						},
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "blocks"),
+			expected: &Config{
+				ErrorMode:        ottl.PropagateError,
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Context: "log",
+						Statements: []string{
+							`if severity_number >= SEVERITY_NUMBER_ERROR`,
+							`set(attributes["alert"], true)`,
+							`else`,
+							`stop`,
+							`end`,
+							`set(attributes["checked"], true)`,
+						},
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "hmac_keys"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				HMACKeys: map[string]configopaque.String{
+					"pii": "my-secret",
+				},
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Context: "log",
+						Statements: []string{
+							`set(attributes["user.id"], HMAC(attributes["user.id"], "pii"))`,
+						},
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unknown_hmac_key"),
+			errorMessage: `error while parsing arguments for call to 'set': invalid argument at position 1: couldn't create function: unknown HMAC key "other"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unclosed_block"),
+			errorMessage: `the block started by statement "if severity_number >= SEVERITY_NUMBER_ERROR" is missing its 'end'`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "macro_invalid_context"),
+			errorMessage: `macro "rename" is not valid in the log context: error while parsing arguments for call to 'set': invalid argument at position 0: invalid path expression [{name []}]`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "macro_shadows_function"),
+			errorMessage: `macro name "set" conflicts with an OTTL function of the same name`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "macro_undeclared_param"),
+			errorMessage: `statement "set(name, {{name}})" of macro "rename" references undeclared parameter "name"`,
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "bad_syntax_trace"),
 			errorMessage: "unable to parse OTTL statement: 1:18: unexpected token \"where\" (expected \")\" Key*)",
@@ -16,11 +16,14 @@ package transformprocessor
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -200,3 +203,62 @@ func TestFactoryCreateLogsProcessor_InvalidActions(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, ap)
 }
+
+func TestFactoryCreateLogsProcessor_Macros(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.ErrorMode = ottl.IgnoreError
+	oCfg.Macros = common.Macros{
+		"mark": {
+			Params:     []string{"value"},
+			Statements: []string{`set(attributes["test"], {{value}})`},
+		},
+	}
+	oCfg.LogStatements = []common.ContextStatements{
+		{
+			Context: "log",
+			Statements: []string{
+				`mark("pass") where body == "operationA"`,
+			},
+		},
+	}
+	lp, err := factory.CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	assert.NotNil(t, lp)
+	assert.NoError(t, err)
+
+	ld := plog.NewLogs()
+	log := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	log.Body().SetStr("operationA")
+
+	err = lp.ConsumeLogs(context.Background(), ld)
+	assert.NoError(t, err)
+
+	val, ok := log.Attributes().Get("test")
+	assert.True(t, ok)
+	assert.Equal(t, "pass", val.Str())
+}
+
+func TestFactoryCreateTracesProcessor_MacroConditionEvaluatedOnce(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "macros").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("http.request.method", "get")
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	// the condition no longer holds once http.method is set by the first
+	// statement of the macro, the second one must still run
+	assert.Equal(t, map[string]interface{}{"http.method": "GET"}, span.Attributes().AsRaw())
+}
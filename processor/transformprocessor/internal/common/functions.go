@@ -15,13 +15,16 @@
 package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 
 import (
+	"go.opentelemetry.io/collector/config/configopaque"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 )
 
-func Functions[K any]() map[string]ottl.Factory[K] {
+// Functions returns the functions available to every context, the HMAC Converter using the given keys.
+func Functions[K any](hmacKeys map[string]configopaque.String) map[string]ottl.Factory[K] {
 	return ottl.CreateFactoryMap(
 		ottlfuncs.NewTraceIDFactory[K](),
 		ottlfuncs.NewSpanIDFactory[K](),
@@ -31,6 +34,24 @@ func Functions[K any]() map[string]ottl.Factory[K] {
 		ottlfuncs.NewIntFactory[K](),
 		ottlfuncs.NewConvertCaseFactory[K](),
 		ottlfuncs.NewParseJSONFactory[K](),
+		ottlfuncs.NewParseCSVFactory[K](),
+		ottlfuncs.NewParseKeyValueFactory[K](),
+		ottlfuncs.NewParseLogfmtFactory[K](),
+		ottlfuncs.NewParseSyslogFactory[K](),
+		ottlfuncs.NewParseURLFactory[K](),
+		ottlfuncs.NewTimeFactory[K](),
+		ottlfuncs.NewNowFactory[K](),
+		ottlfuncs.NewDurationFactory[K](),
+		ottlfuncs.NewUnixNanoFactory[K](),
+		ottlfuncs.NewFormatTimeFactory[K](),
+		ottlfuncs.NewSHA256Factory[K](),
+		ottlfuncs.NewSHA1Factory[K](),
+		ottlfuncs.NewMD5Factory[K](),
+		ottlfuncs.NewFNVFactory[K](),
+		ottlfuncs.NewHMACFactory[K](plainKeys(hmacKeys)),
+		ottlfuncs.NewBase64EncodeFactory[K](),
+		ottlfuncs.NewBase64DecodeFactory[K](),
+		ottlfuncs.NewHexEncodeFactory[K](),
 		ottlfuncs.NewSubstringFactory[K](),
 		ottlfuncs.NewKeepKeysFactory[K](),
 		ottlfuncs.NewSetFactory[K](),
@@ -43,13 +64,23 @@ func Functions[K any]() map[string]ottl.Factory[K] {
 		ottlfuncs.NewDeleteKeyFactory[K](),
 		ottlfuncs.NewDeleteMatchingKeysFactory[K](),
 		ottlfuncs.NewMergeMapsFactory[K](),
+		ottlfuncs.NewTransformKeysFactory[K](),
+		ottlfuncs.NewTransformValuesFactory[K](),
 	)
 }
 
-func ResourceFunctions() map[string]ottl.Factory[ottlresource.TransformContext] {
-	return Functions[ottlresource.TransformContext]()
+func ResourceFunctions(hmacKeys map[string]configopaque.String) map[string]ottl.Factory[ottlresource.TransformContext] {
+	return Functions[ottlresource.TransformContext](hmacKeys)
+}
+
+func ScopeFunctions(hmacKeys map[string]configopaque.String) map[string]ottl.Factory[ottlscope.TransformContext] {
+	return Functions[ottlscope.TransformContext](hmacKeys)
 }
 
-func ScopeFunctions() map[string]ottl.Factory[ottlscope.TransformContext] {
-	return Functions[ottlscope.TransformContext]()
+func plainKeys(keys map[string]configopaque.String) map[string]string {
+	plain := make(map[string]string, len(keys))
+	for name, key := range keys {
+		plain[name] = string(key)
+	}
+	return plain
 }
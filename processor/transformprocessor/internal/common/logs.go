@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 
@@ -82,12 +83,12 @@ func WithLogErrorMode(errorMode ottl.ErrorMode) LogParserCollectionOption {
 	}
 }
 
-func NewLogParserCollection(settings component.TelemetrySettings, options ...LogParserCollectionOption) (*LogParserCollection, error) {
-	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
+func NewLogParserCollection(settings component.TelemetrySettings, hmacKeys map[string]configopaque.String, options ...LogParserCollectionOption) (*LogParserCollection, error) {
+	rp, err := ottlresource.NewParser(ResourceFunctions(hmacKeys), settings)
 	if err != nil {
 		return nil, err
 	}
-	sp, err := ottlscope.NewParser(ScopeFunctions(), settings)
+	sp, err := ottlscope.NewParser(ScopeFunctions(hmacKeys), settings)
 	if err != nil {
 		return nil, err
 	}
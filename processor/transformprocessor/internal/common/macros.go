@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxMacroDepth bounds how deeply macros may invoke other macros.
+const maxMacroDepth = 10
+
+var (
+	macroNamePattern        = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+	macroPlaceholderPattern = regexp.MustCompile(`{{\s*([^{}\s]*)\s*}}`)
+)
+
+// Macro is a named block of statements that can be invoked like a function from the statements of any context.
+// Every occurrence of `{{param}}` in Statements is replaced by the value passed for that parameter.
+type Macro struct {
+	Params     []string `mapstructure:"params"`
+	Statements []string `mapstructure:"statements"`
+}
+
+// Macros maps macro names to their definitions.
+type Macros map[string]Macro
+
+// Validate checks that every macro is well-formed. Whether the statements of a macro are valid
+// can only be decided once it is invoked from a context.
+func (m Macros) Validate(reserved map[string]struct{}) error {
+	for name, macro := range m {
+		if !macroNamePattern.MatchString(name) {
+			return fmt.Errorf("macro name %q must start with a lowercase letter and contain only letters, digits and underscores", name)
+		}
+		if _, ok := reserved[name]; ok {
+			return fmt.Errorf("macro name %q conflicts with an OTTL function of the same name", name)
+		}
+		if len(macro.Statements) == 0 {
+			return fmt.Errorf("macro %q must contain at least one statement", name)
+		}
+		params := make(map[string]struct{}, len(macro.Params))
+		for _, param := range macro.Params {
+			if !macroNamePattern.MatchString(param) {
+				return fmt.Errorf("parameter %q of macro %q must start with a lowercase letter and contain only letters, digits and underscores", param, name)
+			}
+			if _, ok := params[param]; ok {
+				return fmt.Errorf("parameter %q of macro %q is declared more than once", param, name)
+			}
+			params[param] = struct{}{}
+		}
+		for _, statement := range macro.Statements {
+			for _, match := range macroPlaceholderPattern.FindAllStringSubmatch(statement, -1) {
+				if _, ok := params[match[1]]; !ok {
+					return fmt.Errorf("statement %q of macro %q references undeclared parameter %q", statement, name, match[1])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Invocation returns the name of the macro invoked by statement, if any.
+func (m Macros) Invocation(statement string) (string, bool) {
+	name, _, ok := strings.Cut(strings.TrimSpace(statement), "(")
+	if !ok {
+		return "", false
+	}
+	name = strings.TrimSpace(name)
+	_, ok = m[name]
+	return name, ok
+}
+
+// ExpandStatement returns the statements that statement expands to.
+// Statements that don't invoke a macro are returned unchanged.
+func (m Macros) ExpandStatement(statement string) ([]string, error) {
+	return m.expandStatement(statement, nil)
+}
+
+// Expand returns a copy of contextStatements in which every macro invocation is replaced by the statements of the macro.
+func (m Macros) Expand(contextStatements ContextStatements) (ContextStatements, error) {
+	expanded := ContextStatements{Context: contextStatements.Context}
+	for _, statement := range contextStatements.Statements {
+		statements, err := m.ExpandStatement(statement)
+		if err != nil {
+			return ContextStatements{}, err
+		}
+		expanded.Statements = append(expanded.Statements, statements...)
+	}
+	return expanded, nil
+}
+
+// ExpandAll expands the macros invoked by each of the given ContextStatements.
+func (m Macros) ExpandAll(contextStatements []ContextStatements) ([]ContextStatements, error) {
+	expanded := make([]ContextStatements, len(contextStatements))
+	for i, cs := range contextStatements {
+		var err error
+		expanded[i], err = m.Expand(cs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return expanded, nil
+}
+
+func (m Macros) expandStatement(statement string, stack []string) ([]string, error) {
+	name, ok := m.Invocation(statement)
+	if !ok {
+		return []string{statement}, nil
+	}
+	for _, invoker := range stack {
+		if invoker == name {
+			return nil, fmt.Errorf("macro %q invokes itself: %v -> %v", name, strings.Join(stack, " -> "), name)
+		}
+	}
+	if len(stack) >= maxMacroDepth {
+		return nil, fmt.Errorf("macro %q exceeds the maximum nesting depth of %d", name, maxMacroDepth)
+	}
+
+	args, condition, err := splitInvocation(statement)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invocation of macro %q: %w", name, err)
+	}
+	macro := m[name]
+	if len(args) != len(macro.Params) {
+		return nil, fmt.Errorf("macro %q expects %d arguments but got %d", name, len(macro.Params), len(args))
+	}
+	values := make(map[string]string, len(args))
+	for i, param := range macro.Params {
+		values[param] = args[i]
+	}
+
+	// The condition is evaluated once, before the statements of the macro
+	// change the item, by wrapping them in a conditional block.
+	stack = append(stack[:len(stack):len(stack)], name)
+	var expanded []string
+	if condition != "" {
+		expanded = append(expanded, "if "+condition)
+	}
+	for _, s := range macro.Statements {
+		s = macroPlaceholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+			return values[macroPlaceholderPattern.FindStringSubmatch(placeholder)[1]]
+		})
+		statements, err := m.expandStatement(s, stack)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, statements...)
+	}
+	if condition != "" {
+		expanded = append(expanded, "end")
+	}
+	return expanded, nil
+}
+
+// splitInvocation splits `name(arg, ...) where condition` into its top-level arguments and condition.
+func splitInvocation(statement string) ([]string, string, error) {
+	statement = strings.TrimSpace(statement)
+	open := strings.Index(statement, "(")
+	var args []string
+	depth := 0
+	start := open + 1
+	end := -1
+	err := scanStatement(statement[open:], func(i int, c byte) bool {
+		i += open
+		switch c {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				end = i
+				return false
+			}
+		case ',':
+			if depth == 1 {
+				args = append(args, strings.TrimSpace(statement[start:i]))
+				start = i + 1
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if end == -1 {
+		return nil, "", fmt.Errorf("missing closing parenthesis")
+	}
+	if last := strings.TrimSpace(statement[start:end]); last != "" || len(args) > 0 {
+		args = append(args, last)
+	}
+
+	rest := strings.TrimSpace(statement[end+1:])
+	if rest == "" {
+		return args, "", nil
+	}
+	condition, ok := cutKeyword(rest, "where")
+	if !ok || condition == "" {
+		return nil, "", fmt.Errorf("unexpected %q after arguments", rest)
+	}
+	return args, condition, nil
+}
+
+// cutKeyword returns s without its leading keyword and the whitespace following it.
+func cutKeyword(s string, keyword string) (string, bool) {
+	if !strings.HasPrefix(s, keyword) || len(s) == len(keyword) || (s[len(keyword)] != ' ' && s[len(keyword)] != '\t' && s[len(keyword)] != '(') {
+		return "", false
+	}
+	return strings.TrimSpace(s[len(keyword):]), true
+}
+
+// scanStatement calls f with every byte of statement that is not part of a string literal until f returns false.
+func scanStatement(statement string, f func(i int, c byte) bool) error {
+	inString := false
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		switch {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case !inString:
+			if !f(i, c) {
+				return nil
+			}
+		}
+	}
+	if inString {
+		return fmt.Errorf("unterminated string")
+	}
+	return nil
+}
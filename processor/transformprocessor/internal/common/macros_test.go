@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Macros_ExpandStatement(t *testing.T) {
+	macros := Macros{
+		"normalize": {
+			Params: []string{"target", "to_case"},
+			Statements: []string{
+				`set({{target}}, ConvertCase({{target}}, {{ to_case }}))`,
+				`delete_key(attributes, "tmp") where attributes["tmp"] != nil`,
+			},
+		},
+		"noop": {
+			Statements: []string{
+				`set(attributes["noop"], true)`,
+			},
+		},
+		"classify": {
+			Statements: []string{
+				`if attributes["code"] >= 500`,
+				`set(attributes["class"], "error")`,
+				`else`,
+				`set(attributes["class"], "ok")`,
+				`end`,
+			},
+		},
+		"nested": {
+			Params: []string{"target"},
+			Statements: []string{
+				`normalize({{target}}, "lower") where name == "where (this) is"`,
+				`noop()`,
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		statement string
+		want      []string
+	}{
+		{
+			name:      "not a macro",
+			statement: `set(attributes["test"], "pass")`,
+			want: []string{
+				`set(attributes["test"], "pass")`,
+			},
+		},
+		{
+			name:      "no arguments",
+			statement: `noop()`,
+			want: []string{
+				`set(attributes["noop"], true)`,
+			},
+		},
+		{
+			name:      "arguments",
+			statement: `normalize(attributes["a,b"], Concat(["lo", "wer"], ""))`,
+			want: []string{
+				`set(attributes["a,b"], ConvertCase(attributes["a,b"], Concat(["lo", "wer"], "")))`,
+				`delete_key(attributes, "tmp") where attributes["tmp"] != nil`,
+			},
+		},
+		{
+			name:      "condition",
+			statement: `normalize(name, "upper") where kind == SPAN_KIND_SERVER`,
+			want: []string{
+				`if kind == SPAN_KIND_SERVER`,
+				`set(name, ConvertCase(name, "upper"))`,
+				`delete_key(attributes, "tmp") where attributes["tmp"] != nil`,
+				`end`,
+			},
+		},
+		{
+			name:      "condition with block",
+			statement: `classify() where kind == SPAN_KIND_SERVER`,
+			want: []string{
+				`if kind == SPAN_KIND_SERVER`,
+				`if attributes["code"] >= 500`,
+				`set(attributes["class"], "error")`,
+				`else`,
+				`set(attributes["class"], "ok")`,
+				`end`,
+				`end`,
+			},
+		},
+		{
+			name:      "nested",
+			statement: `nested(name)`,
+			want: []string{
+				`if name == "where (this) is"`,
+				`set(name, ConvertCase(name, "lower"))`,
+				`delete_key(attributes, "tmp") where attributes["tmp"] != nil`,
+				`end`,
+				`set(attributes["noop"], true)`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := macros.ExpandStatement(tt.statement)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Macros_ExpandStatement_Error(t *testing.T) {
+	macros := Macros{
+		"one": {
+			Params:     []string{"target"},
+			Statements: []string{`set({{target}}, "one")`},
+		},
+		"loop": {
+			Statements: []string{`recurse()`},
+		},
+		"recurse": {
+			Statements: []string{`loop()`},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		statement string
+		errorMsg  string
+	}{
+		{
+			name:      "too many arguments",
+			statement: `one(name, "two")`,
+			errorMsg:  `macro "one" expects 1 arguments but got 2`,
+		},
+		{
+			name:      "too few arguments",
+			statement: `one()`,
+			errorMsg:  `macro "one" expects 1 arguments but got 0`,
+		},
+		{
+			name:      "unbalanced parentheses",
+			statement: `one(Concat(["a"], "")`,
+			errorMsg:  `invalid invocation of macro "one": missing closing parenthesis`,
+		},
+		{
+			name:      "unterminated string",
+			statement: `one("name)`,
+			errorMsg:  `invalid invocation of macro "one": unterminated string`,
+		},
+		{
+			name:      "trailing text",
+			statement: `one(name) and more`,
+			errorMsg:  `invalid invocation of macro "one": unexpected "and more" after arguments`,
+		},
+		{
+			name:      "cycle",
+			statement: `loop()`,
+			errorMsg:  `macro "loop" invokes itself: loop -> recurse -> loop`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := macros.ExpandStatement(tt.statement)
+			assert.EqualError(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func Test_Macros_Expand(t *testing.T) {
+	macros := Macros{
+		"rename": {
+			Params:     []string{"to"},
+			Statements: []string{`set(name, {{to}})`},
+		},
+	}
+
+	expanded, err := macros.ExpandAll([]ContextStatements{
+		{
+			Context: Span,
+			Statements: []string{
+				`set(attributes["before"], true)`,
+				`rename("bear")`,
+				`set(attributes["after"], true)`,
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []ContextStatements{
+		{
+			Context: Span,
+			Statements: []string{
+				`set(attributes["before"], true)`,
+				`set(name, "bear")`,
+				`set(attributes["after"], true)`,
+			},
+		},
+	}, expanded)
+}
+
+func Test_Macros_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		macros   Macros
+		errorMsg string
+	}{
+		{
+			name: "valid",
+			macros: Macros{
+				"rename": {Params: []string{"to"}, Statements: []string{`set(name, {{to}})`}},
+			},
+		},
+		{
+			name: "invalid name",
+			macros: Macros{
+				"Rename": {Statements: []string{`set(name, "bear")`}},
+			},
+			errorMsg: `macro name "Rename" must start with a lowercase letter and contain only letters, digits and underscores`,
+		},
+		{
+			name: "reserved name",
+			macros: Macros{
+				"set": {Statements: []string{`set(name, "bear")`}},
+			},
+			errorMsg: `macro name "set" conflicts with an OTTL function of the same name`,
+		},
+		{
+			name: "no statements",
+			macros: Macros{
+				"rename": {},
+			},
+			errorMsg: `macro "rename" must contain at least one statement`,
+		},
+		{
+			name: "duplicate parameter",
+			macros: Macros{
+				"rename": {Params: []string{"to", "to"}, Statements: []string{`set(name, {{to}})`}},
+			},
+			errorMsg: `parameter "to" of macro "rename" is declared more than once`,
+		},
+		{
+			name: "undeclared parameter",
+			macros: Macros{
+				"rename": {Statements: []string{`set(name, {{to}})`}},
+			},
+			errorMsg: `statement "set(name, {{to}})" of macro "rename" references undeclared parameter "to"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.macros.Validate(map[string]struct{}{"set": {}})
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.errorMsg)
+		})
+	}
+}
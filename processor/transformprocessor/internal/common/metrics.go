@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -182,12 +183,12 @@ func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption
 	}
 }
 
-func NewMetricParserCollection(settings component.TelemetrySettings, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
-	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
+func NewMetricParserCollection(settings component.TelemetrySettings, hmacKeys map[string]configopaque.String, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
+	rp, err := ottlresource.NewParser(ResourceFunctions(hmacKeys), settings)
 	if err != nil {
 		return nil, err
 	}
-	sp, err := ottlscope.NewParser(ScopeFunctions(), settings)
+	sp, err := ottlscope.NewParser(ScopeFunctions(hmacKeys), settings)
 	if err != nil {
 		return nil, err
 	}
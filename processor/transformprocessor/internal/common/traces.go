@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
@@ -129,12 +130,12 @@ func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	}
 }
 
-func NewTraceParserCollection(settings component.TelemetrySettings, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
-	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
+func NewTraceParserCollection(settings component.TelemetrySettings, hmacKeys map[string]configopaque.String, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
+	rp, err := ottlresource.NewParser(ResourceFunctions(hmacKeys), settings)
 	if err != nil {
 		return nil, err
 	}
-	sp, err := ottlscope.NewParser(ScopeFunctions(), settings)
+	sp, err := ottlscope.NewParser(ScopeFunctions(hmacKeys), settings)
 	if err != nil {
 		return nil, err
 	}
@@ -15,12 +15,14 @@
 package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
 
 import (
+	"go.opentelemetry.io/collector/config/configopaque"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
-func LogFunctions() map[string]ottl.Factory[ottllog.TransformContext] {
+func LogFunctions(hmacKeys map[string]configopaque.String) map[string]ottl.Factory[ottllog.TransformContext] {
 	// No logs-only functions yet.
-	return common.Functions[ottllog.TransformContext]()
+	return common.Functions[ottllog.TransformContext](hmacKeys)
 }
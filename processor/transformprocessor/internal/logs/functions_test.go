@@ -25,8 +25,8 @@ import (
 )
 
 func Test_LogFunctions(t *testing.T) {
-	expected := common.Functions[ottllog.TransformContext]()
-	actual := LogFunctions()
+	expected := common.Functions[ottllog.TransformContext](nil)
+	actual := LogFunctions(nil)
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
 		assert.Contains(t, expected, k)
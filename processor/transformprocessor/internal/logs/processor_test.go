@@ -60,7 +60,7 @@ func Test_ProcessLogs_ResourceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, nil, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -95,7 +95,7 @@ func Test_ProcessLogs_ScopeContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, nil, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -328,12 +328,96 @@ func Test_ProcessLogs_LogContext(t *testing.T) {
 				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("json_test", "pass")
 			},
 		},
+		{
+			statement: `merge_maps(attributes, ParseKeyValue("kv_test=pass user='a b'", "=", ""), "insert") where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("kv_test", "pass")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("user", "a b")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseCSV("a;b", "first;second", ";", "", "strict")["second"]) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "b")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseURL("https://example.com/path?q=1")["host"]) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "example.com")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseLogfmt("level=warn msg=\"disk full\"")["msg"]) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "disk full")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseSyslog("<165>1 2003-10-11T22:14:15.003Z host app 1 ID47 - started", "rfc5424", "")["appname"]) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "app")
+			},
+		},
+		{
+			statement: `set(attributes["test"], SHA256(body)) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "0fe164ca87e2770c80b12f9778366f6c91267508bdc442dbf49cddba376933e5")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Base64Decode(Base64Encode(body))) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "operationA")
+			},
+		},
+		{
+			statement: `replace_pattern(attributes["http.method"], "(g)(et)", Concat([SHA256($value[1]), $value[2]], "-"))`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("http.method", "cd0aa9856147b6c5b4ff2b7dfee5da20aa38253099ef1b4a64aced233c9afe29-et")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("http.method", "cd0aa9856147b6c5b4ff2b7dfee5da20aa38253099ef1b4a64aced233c9afe29-et")
+			},
+		},
+		{
+			statement: `set(attributes["test"], {"body": body, "flags": Split(attributes["flags"], "|")}) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				m := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptyMap("test")
+				m.PutStr("body", "operationA")
+				s := m.PutEmptySlice("flags")
+				s.AppendEmpty().SetStr("A")
+				s.AppendEmpty().SetStr("B")
+				s.AppendEmpty().SetStr("C")
+			},
+		},
+		{
+			statement: `transform_keys(attributes, ConvertCase($key, "upper")) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				attrs := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes()
+				attrs.Clear()
+				attrs.PutStr("HTTP.METHOD", "get")
+				attrs.PutStr("HTTP.PATH", "/health")
+				attrs.PutStr("HTTP.URL", "http://localhost/health")
+				attrs.PutStr("FLAGS", "C|D")
+				attrs.PutStr("TOTAL.STRING", "345678")
+			},
+		},
+		{
+			statement: `transform_values(attributes, Concat([$key, $value], "=")) where body == "operationB"`,
+			want: func(td plog.Logs) {
+				attrs := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes()
+				attrs.PutStr("http.method", "http.method=get")
+				attrs.PutStr("http.path", "http.path=/health")
+				attrs.PutStr("http.url", "http.url=http://localhost/health")
+				attrs.PutStr("flags", "flags=C|D")
+				attrs.PutStr("total.string", "total.string=345678")
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{tt.statement}}}, ottl.IgnoreError, nil, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -445,12 +529,41 @@ func Test_ProcessLogs_MixContext(t *testing.T) {
 				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			name: "conditional blocks and stop",
+			contextStatments: []common.ContextStatements{
+				{
+					Context: "log",
+					Statements: []string{
+						`if body == "operationA"`,
+						`set(attributes["test"], "A")`,
+						`else`,
+						`set(attributes["test"], "B")`,
+						`stop`,
+						`end`,
+						`set(attributes["after"], "pass")`,
+					},
+				},
+				{
+					Context: "scope",
+					Statements: []string{
+						`set(attributes["test"], "pass")`,
+					},
+				},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "A")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("after", "pass")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("test", "B")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Attributes().PutStr("test", "pass")
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.contextStatments, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor(tt.contextStatments, ottl.IgnoreError, nil, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -483,7 +596,7 @@ func Test_ProcessTraces_Error(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(string(tt.context), func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, nil, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
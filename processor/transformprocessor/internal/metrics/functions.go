@@ -15,14 +15,16 @@
 package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
 
 import (
+	"go.opentelemetry.io/collector/config/configopaque"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
-func DataPointFunctions() map[string]ottl.Factory[ottldatapoint.TransformContext] {
-	functions := common.Functions[ottldatapoint.TransformContext]()
+func DataPointFunctions(hmacKeys map[string]configopaque.String) map[string]ottl.Factory[ottldatapoint.TransformContext] {
+	functions := common.Functions[ottldatapoint.TransformContext](hmacKeys)
 
 	datapointFunctions := ottl.CreateFactoryMap[ottldatapoint.TransformContext](
 		newConvertSumToGaugeFactory(),
@@ -38,6 +40,6 @@ func DataPointFunctions() map[string]ottl.Factory[ottldatapoint.TransformContext
 	return functions
 }
 
-func MetricFunctions() map[string]ottl.Factory[ottlmetric.TransformContext] {
-	return common.Functions[ottlmetric.TransformContext]()
+func MetricFunctions(hmacKeys map[string]configopaque.String) map[string]ottl.Factory[ottlmetric.TransformContext] {
+	return common.Functions[ottlmetric.TransformContext](hmacKeys)
 }
@@ -26,13 +26,13 @@ import (
 )
 
 func Test_DataPointFunctions(t *testing.T) {
-	expected := common.Functions[ottldatapoint.TransformContext]()
+	expected := common.Functions[ottldatapoint.TransformContext](nil)
 	expected["convert_sum_to_gauge"] = newConvertSumToGaugeFactory()
 	expected["convert_gauge_to_sum"] = newConvertGaugeToSumFactory()
 	expected["convert_summary_sum_val_to_sum"] = newConvertSummarySumValToSumFactory()
 	expected["convert_summary_count_val_to_sum"] = newConvertSummaryCountValToSumFactory()
 
-	actual := DataPointFunctions()
+	actual := DataPointFunctions(nil)
 
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
@@ -41,8 +41,8 @@ func Test_DataPointFunctions(t *testing.T) {
 }
 
 func Test_MetricFunctions(t *testing.T) {
-	expected := common.Functions[ottlmetric.TransformContext]()
-	actual := MetricFunctions()
+	expected := common.Functions[ottlmetric.TransformContext](nil)
+	actual := MetricFunctions(nil)
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
 		assert.Contains(t, expected, k)
@@ -15,18 +15,20 @@
 package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
 
 import (
+	"go.opentelemetry.io/collector/config/configopaque"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
-func SpanFunctions() map[string]ottl.Factory[ottlspan.TransformContext] {
+func SpanFunctions(hmacKeys map[string]configopaque.String) map[string]ottl.Factory[ottlspan.TransformContext] {
 	// No trace-only functions yet.
-	return common.Functions[ottlspan.TransformContext]()
+	return common.Functions[ottlspan.TransformContext](hmacKeys)
 }
 
-func SpanEventFunctions() map[string]ottl.Factory[ottlspanevent.TransformContext] {
+func SpanEventFunctions(hmacKeys map[string]configopaque.String) map[string]ottl.Factory[ottlspanevent.TransformContext] {
 	// No trace-only functions yet.
-	return common.Functions[ottlspanevent.TransformContext]()
+	return common.Functions[ottlspanevent.TransformContext](hmacKeys)
 }
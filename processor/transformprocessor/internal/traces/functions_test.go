@@ -26,8 +26,8 @@ import (
 )
 
 func Test_SpanFunctions(t *testing.T) {
-	expected := common.Functions[ottlspan.TransformContext]()
-	actual := SpanFunctions()
+	expected := common.Functions[ottlspan.TransformContext](nil)
+	actual := SpanFunctions(nil)
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
 		assert.Contains(t, expected, k)
@@ -35,8 +35,8 @@ func Test_SpanFunctions(t *testing.T) {
 }
 
 func Test_SpanEventFunctions(t *testing.T) {
-	expected := common.Functions[ottlspanevent.TransformContext]()
-	actual := SpanEventFunctions()
+	expected := common.Functions[ottlspanevent.TransformContext](nil)
+	actual := SpanEventFunctions(nil)
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
 		assert.Contains(t, expected, k)
@@ -131,6 +131,8 @@ func testdataConfigYamlAsMap() *Config {
 			IncludeFileNameResolved: false,
 			IncludeFilePathResolved: false,
 			PollInterval:            200 * time.Millisecond,
+			WatchMode:               "poll",
+			WatchFallbackInterval:   time.Minute,
 			Splitter:                helper.NewSplitterConfig(),
 			StartAt:                 "end",
 			FingerprintSize:         1000,
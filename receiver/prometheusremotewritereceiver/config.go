@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the Prometheus remote write receiver.
+type Config struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MaxStaleness is how long the metadata, the metric types and the incomplete histogram and summary points
+	// received in a request are kept to convert the following requests.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+
+	// MaxDecodedBodySize is the maximum size in bytes of a decompressed request, the compressed size being limited by
+	// MaxRequestBodySize.
+	MaxDecodedBodySize int64 `mapstructure:"max_decoded_body_size"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if cfg.MaxRequestBodySize <= 0 {
+		return errors.New("max_request_body_size must be positive")
+	}
+	if cfg.MaxDecodedBodySize <= 0 {
+		return errors.New("max_decoded_body_size must be positive")
+	}
+	if cfg.MaxStaleness <= 0 {
+		return errors.New("max_staleness must be positive")
+	}
+	return nil
+}
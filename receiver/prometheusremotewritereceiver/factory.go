@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mdatagen metadata.yaml
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver/internal/metadata"
+)
+
+const (
+	defaultBindEndpoint = "0.0.0.0:19291"
+	// defaultMaxStaleness is longer than the interval Prometheus sends the metadata at
+	defaultMaxStaleness = 5 * time.Minute
+	// the sizes Prometheus sends are far below those, with its default of 2000 samples per request
+	defaultMaxRequestBodySize = 10 * 1024 * 1024
+	defaultMaxDecodedBodySize = 64 * 1024 * 1024
+)
+
+// NewFactory returns a new receiver.Factory for the Prometheus remote write receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint:           defaultBindEndpoint,
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
+		MaxStaleness:       defaultMaxStaleness,
+		MaxDecodedBodySize: defaultMaxDecodedBodySize,
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	settings receiver.CreateSettings,
+	cfg component.Config,
+	consumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	rCfg := cfg.(*Config)
+	return newPRWReceiver(rCfg, consumer, settings)
+}
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+)
+
+const (
+	writePath = "/api/v1/write"
+	format    = "protobuf"
+)
+
+var errBodyTooLarge = errors.New("request body too large")
+
+type prwReceiver struct {
+	conf         *Config
+	nextConsumer consumer.Metrics
+	settings     receiver.CreateSettings
+	server       *http.Server
+	done         chan struct{}
+	shutdownWG   sync.WaitGroup
+	obsrecv      *obsreport.Receiver
+	translator   *prometheusremotewrite.Translator
+}
+
+func newPRWReceiver(conf *Config, nextConsumer consumer.Metrics, settings receiver.CreateSettings) (*prwReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &prwReceiver{
+		conf:         conf,
+		nextConsumer: nextConsumer,
+		settings:     settings,
+		obsrecv:      obsrecv,
+		translator:   prometheusremotewrite.NewTranslator(conf.MaxStaleness),
+	}, nil
+}
+
+func (r *prwReceiver) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(writePath, r.handleWrite)
+
+	var err error
+	r.server, err = r.conf.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return fmt.Errorf("failed to create http server: %w", err)
+	}
+
+	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", r.conf.Endpoint))
+	listener, err := r.conf.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to start http server: %w", err)
+	}
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.server.Serve(listener); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	r.done = make(chan struct{})
+	r.shutdownWG.Add(1)
+	go r.releaseStalePoints(r.done)
+	return nil
+}
+
+func (r *prwReceiver) Shutdown(ctx context.Context) error {
+	var err error
+	if r.server != nil {
+		err = r.server.Shutdown(ctx)
+	}
+	if r.done != nil {
+		close(r.done)
+	}
+	r.shutdownWG.Wait()
+
+	// the points still held are not going to be completed by other requests
+	if metrics, released := r.translator.ReleaseAll(); metrics.DataPointCount() > 0 {
+		err = multierr.Append(err, r.consume(ctx, metrics, released))
+	}
+	return err
+}
+
+// releaseStalePoints consumes the histogram and summary points held for longer than MaxStaleness, which would
+// otherwise wait for the next request.
+func (r *prwReceiver) releaseStalePoints(done <-chan struct{}) {
+	defer r.shutdownWG.Done()
+
+	ticker := time.NewTicker(r.conf.MaxStaleness / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			metrics, released := r.translator.ReleaseStale()
+			if metrics.DataPointCount() == 0 {
+				continue
+			}
+			if err := r.consume(context.Background(), metrics, released); err != nil {
+				r.settings.Logger.Warn("Failed to consume the stale histogram and summary points", zap.Error(err))
+			}
+		}
+	}
+}
+
+// consume sends the metrics to the next consumer, and holds the points released to them again when the error can
+// be retried.
+func (r *prwReceiver) consume(ctx context.Context, metrics pmetric.Metrics, released *prometheusremotewrite.Released) error {
+	ctx = r.obsrecv.StartMetricsOp(ctx)
+	err := r.nextConsumer.ConsumeMetrics(ctx, metrics)
+	r.obsrecv.EndMetricsOp(ctx, format, metrics.DataPointCount(), err)
+	if err != nil && !consumererror.IsPermanent(err) {
+		r.translator.Restore(released)
+	}
+	return err
+}
+
+// handleWrite handles the snappy compressed WriteRequest protobufs sent by the Prometheus remote write clients.
+// The clients retry on the 5xx responses, so the requests which cannot succeed get a 4xx response.
+func (r *prwReceiver) handleWrite(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(resp, fmt.Sprintf("%v method not allowed, supported: [POST]", http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeRequest, err := decodeWriteRequest(req.Body, r.conf.MaxRequestBodySize, r.conf.MaxDecodedBodySize)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errBodyTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(resp, err.Error(), status)
+		return
+	}
+
+	metrics, released, err := r.translator.ToMetrics(writeRequest)
+	if err != nil {
+		// the series which cannot be translated are dropped, the others are still consumed
+		r.settings.Logger.Warn("Failed to translate some of the time series", zap.Error(err))
+	}
+	if metrics.DataPointCount() == 0 {
+		// metadata only requests, or points held until their other series are received
+		resp.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err = r.consume(req.Context(), metrics, released); err != nil {
+		status := http.StatusInternalServerError
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(resp, err.Error(), status)
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+// decodeWriteRequest reads at most maxSize bytes from the body, and decompresses it only when the decoded length from
+// the snappy header is at most maxDecodedSize, as the buffer is allocated from that length.
+func decodeWriteRequest(body io.Reader, maxSize, maxDecodedSize int64) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || int64(len(compressed)) > maxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", errBodyTooLarge, maxSize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the request body: %w", err)
+	}
+
+	decodedLen, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the request body: %w", err)
+	}
+	if int64(decodedLen) > maxDecodedSize {
+		return nil, fmt.Errorf("%w: %d bytes once decompressed, more than %d", errBodyTooLarge, decodedLen, maxDecodedSize)
+	}
+
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the request body: %w", err)
+	}
+
+	writeRequest := &prompb.WriteRequest{}
+	if err := writeRequest.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the write request: %w", err)
+	}
+	return writeRequest, nil
+}
@@ -0,0 +1,378 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, next consumer.Metrics) string {
+	return startReceiverWithConfig(t, next, &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
+		MaxStaleness:       time.Minute,
+		MaxDecodedBodySize: defaultMaxDecodedBodySize,
+	})
+}
+
+func startReceiverWithConfig(t *testing.T, next consumer.Metrics, cfg *Config) string {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg.Endpoint = addr
+
+	r, err := newPRWReceiver(cfg, next, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	})
+	return fmt.Sprintf("http://%s%s", addr, writePath)
+}
+
+func sendWriteRequest(t *testing.T, endpoint string, writeRequest *prompb.WriteRequest) *http.Response {
+	data, err := writeRequest.Marshal()
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(snappy.Encode(nil, data)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestReceiveWriteRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	endpoint := startReceiver(t, sink)
+
+	resp := sendWriteRequest(t, endpoint, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "target_info"},
+					{Name: "job", Value: "ns/svc"},
+					{Name: "instance", Value: "host:80"},
+					{Name: "host_name", Value: "host"},
+				},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "job", Value: "ns/svc"},
+					{Name: "instance", Value: "host:80"},
+					{Name: "code", Value: "200"},
+				},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_duration_seconds_bucket"},
+					{Name: "job", Value: "ns/svc"},
+					{Name: "instance", Value: "host:80"},
+					{Name: "le", Value: "+Inf"},
+				},
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_duration_seconds_sum"},
+					{Name: "job", Value: "ns/svc"},
+					{Name: "instance", Value: "host:80"},
+				},
+				Samples: []prompb.Sample{{Value: 1.5, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_duration_seconds_count"},
+					{Name: "job", Value: "ns/svc"},
+					{Name: "instance", Value: "host:80"},
+				},
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 1000}},
+			},
+		},
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	attrs := md.ResourceMetrics().At(0).Resource().Attributes().AsRaw()
+	assert.Equal(t, map[string]any{
+		"service.namespace":   "ns",
+		"service.name":        "svc",
+		"service.instance.id": "host:80",
+		"host_name":           "host",
+	}, attrs)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "http_requests", metrics.At(0).Name())
+	assert.Equal(t, pmetric.MetricTypeSum, metrics.At(0).Type())
+	assert.Equal(t, "http_duration", metrics.At(1).Name())
+	assert.Equal(t, "s", metrics.At(1).Unit())
+	require.Equal(t, pmetric.MetricTypeHistogram, metrics.At(1).Type())
+	assert.Equal(t, uint64(3), metrics.At(1).Histogram().DataPoints().At(0).Count())
+	assert.Equal(t, 1.5, metrics.At(1).Histogram().DataPoints().At(0).Sum())
+}
+
+func TestReceiveSplitWriteRequests(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	endpoint := startReceiver(t, sink)
+
+	series := func(name string, value float64, labels ...prompb.Label) prompb.TimeSeries {
+		return prompb.TimeSeries{
+			Labels:  append([]prompb.Label{{Name: "__name__", Value: name}, {Name: "job", Value: "svc"}}, labels...),
+			Samples: []prompb.Sample{{Value: value, Timestamp: 1000}},
+		}
+	}
+
+	resp := sendWriteRequest(t, endpoint, &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "rpc_duration_seconds"},
+		},
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp = sendWriteRequest(t, endpoint, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("rpc_duration_seconds_bucket", 1, prompb.Label{Name: "le", Value: "0.5"}),
+			series("rpc_duration_seconds_bucket", 2, prompb.Label{Name: "le", Value: "+Inf"}),
+		},
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, sink.AllMetrics())
+
+	resp = sendWriteRequest(t, endpoint, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("rpc_duration_seconds_sum", 0.7),
+			series("rpc_duration_seconds_count", 2),
+		},
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "rpc_duration", metrics.At(0).Name())
+	require.Equal(t, pmetric.MetricTypeHistogram, metrics.At(0).Type())
+	dp := metrics.At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, 0.7, dp.Sum())
+	assert.Equal(t, []uint64{1, 1}, dp.BucketCounts().AsRaw())
+}
+
+// failingOnceSink fails to consume the first metrics with a retryable error
+type failingOnceSink struct {
+	consumertest.MetricsSink
+	failed atomic.Bool
+}
+
+func (s *failingOnceSink) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if !s.failed.Swap(true) {
+		return errors.New("temporary failure")
+	}
+	return s.MetricsSink.ConsumeMetrics(ctx, md)
+}
+
+func TestReceiveStalePointsWithoutFurtherRequests(t *testing.T) {
+	sink := new(failingOnceSink)
+	endpoint := startReceiverWithConfig(t, sink, &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
+		MaxStaleness:       100 * time.Millisecond,
+		MaxDecodedBodySize: defaultMaxDecodedBodySize,
+	})
+
+	resp := sendWriteRequest(t, endpoint, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "rpc_duration_seconds_bucket"}, {Name: "le", Value: "+Inf"}},
+			Samples: []prompb.Sample{{Value: 2, Timestamp: 1000}},
+		}},
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// the held point is released by the receiver, and consumed again after the first failure
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	require.Equal(t, pmetric.MetricTypeHistogram, metrics.At(0).Type())
+	assert.Equal(t, []uint64{2}, metrics.At(0).Histogram().DataPoints().At(0).BucketCounts().AsRaw())
+}
+
+func TestShutdownReleasesHeldPoints(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint:           testutil.GetAvailableLocalAddress(t),
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
+		MaxStaleness:       time.Minute,
+		MaxDecodedBodySize: defaultMaxDecodedBodySize,
+	}
+	r, err := newPRWReceiver(cfg, sink, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	resp := sendWriteRequest(t, fmt.Sprintf("http://%s%s", cfg.Endpoint, writePath), &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "rpc_duration_seconds_sum"}},
+			Samples: []prompb.Sample{{Value: 0.7, Timestamp: 1000}},
+		}},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_SUMMARY, MetricFamilyName: "rpc_duration_seconds"},
+		},
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, sink.AllMetrics())
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, pmetric.MetricTypeSummary, metrics.At(0).Type())
+	assert.Equal(t, 0.7, metrics.At(0).Summary().DataPoints().At(0).Sum())
+}
+
+func TestReceiveInvalidRequests(t *testing.T) {
+	endpoint := startReceiver(t, consumertest.NewNop())
+
+	resp, err := http.Get(endpoint)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(endpoint, "application/x-protobuf", bytes.NewReader([]byte("not snappy")))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestReceiveTooLargeRequests(t *testing.T) {
+	writeRequest := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "up"}, {Name: "padding", Value: strings.Repeat("a", 4096)}},
+			Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+		}},
+	}
+
+	tests := []struct {
+		name               string
+		maxRequestBodySize int64
+		maxDecodedBodySize int64
+		wantStatus         int
+	}{
+		{
+			name:               "accepted",
+			maxRequestBodySize: 8192,
+			maxDecodedBodySize: 8192,
+			wantStatus:         http.StatusNoContent,
+		},
+		{
+			name:               "compressed body too large",
+			maxRequestBodySize: 16,
+			maxDecodedBodySize: 8192,
+			wantStatus:         http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:               "decompressed body too large",
+			maxRequestBodySize: 8192,
+			maxDecodedBodySize: 1024,
+			wantStatus:         http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			endpoint := startReceiverWithConfig(t, sink, &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					MaxRequestBodySize: tt.maxRequestBodySize,
+				},
+				MaxStaleness:       time.Minute,
+				MaxDecodedBodySize: tt.maxDecodedBodySize,
+			})
+			resp := sendWriteRequest(t, endpoint, writeRequest)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus != http.StatusNoContent {
+				assert.Empty(t, sink.AllMetrics())
+			}
+		})
+	}
+}
+
+func TestDecodeWriteRequestChecksDecodedLength(t *testing.T) {
+	// a snappy header announcing 1GiB followed by nothing must not allocate the announced length
+	compressed := []byte{0x80, 0x80, 0x80, 0x80, 0x04}
+	_, err := decodeWriteRequest(bytes.NewReader(compressed), 1024, 1024)
+	assert.ErrorIs(t, err, errBodyTooLarge)
+}
+
+func TestReceiveConsumerErrors(t *testing.T) {
+	writeRequest := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "up"}},
+			Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "retryable",
+			err:        errors.New("temporary failure"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "permanent",
+			err:        consumererror.NewPermanent(errors.New("invalid data")),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := startReceiver(t, consumertest.NewErr(tt.err))
+			resp := sendWriteRequest(t, endpoint, writeRequest)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}